@@ -100,6 +100,9 @@ type GCPolicy struct {
 	KeepDuration         int64    `protobuf:"varint,2,opt,name=keepDuration,proto3" json:"keepDuration,omitempty"`
 	KeepBytes            int64    `protobuf:"varint,3,opt,name=keepBytes,proto3" json:"keepBytes,omitempty"`
 	Filters              []string `protobuf:"bytes,4,rep,name=filters,proto3" json:"filters,omitempty"`
+	ReservedSpace        int64    `protobuf:"varint,5,opt,name=reservedSpace,proto3" json:"reservedSpace,omitempty"`
+	MaxUsedSpace         int64    `protobuf:"varint,6,opt,name=maxUsedSpace,proto3" json:"maxUsedSpace,omitempty"`
+	MinFreeSpace         int64    `protobuf:"varint,7,opt,name=minFreeSpace,proto3" json:"minFreeSpace,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -166,6 +169,27 @@ func (m *GCPolicy) GetFilters() []string {
 	return nil
 }
 
+func (m *GCPolicy) GetReservedSpace() int64 {
+	if m != nil {
+		return m.ReservedSpace
+	}
+	return 0
+}
+
+func (m *GCPolicy) GetMaxUsedSpace() int64 {
+	if m != nil {
+		return m.MaxUsedSpace
+	}
+	return 0
+}
+
+func (m *GCPolicy) GetMinFreeSpace() int64 {
+	if m != nil {
+		return m.MinFreeSpace
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*WorkerRecord)(nil), "moby.buildkit.v1.types.WorkerRecord")
 	proto.RegisterMapType((map[string]string)(nil), "moby.buildkit.v1.types.WorkerRecord.LabelsEntry")
@@ -175,30 +199,32 @@ func init() {
 func init() { proto.RegisterFile("worker.proto", fileDescriptor_e4ff6184b07e587a) }
 
 var fileDescriptor_e4ff6184b07e587a = []byte{
-	// 355 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xc1, 0x4e, 0xea, 0x40,
-	0x14, 0x86, 0x6f, 0x5b, 0x2e, 0x97, 0x0e, 0xcd, 0x8d, 0x99, 0x18, 0xd3, 0x10, 0x83, 0x84, 0x15,
-	0x0b, 0x9d, 0xa2, 0x6e, 0xd4, 0xb8, 0x42, 0x8c, 0x92, 0xb8, 0x20, 0xb3, 0x71, 0xdd, 0x81, 0x01,
-	0x9b, 0x0e, 0x9c, 0xc9, 0x74, 0x8a, 0xf6, 0x39, 0x7c, 0x29, 0x96, 0x3e, 0x81, 0x31, 0x3c, 0x89,
-	0x99, 0x29, 0x08, 0x26, 0xba, 0x3b, 0xff, 0x9f, 0xff, 0xfb, 0xe7, 0x9c, 0x0c, 0x0a, 0x9e, 0x41,
-	0xa5, 0x5c, 0x11, 0xa9, 0x40, 0x03, 0x3e, 0x98, 0x01, 0x2b, 0x08, 0xcb, 0x13, 0x31, 0x4e, 0x13,
-	0x4d, 0x16, 0xa7, 0x44, 0x17, 0x92, 0x67, 0x8d, 0x93, 0x69, 0xa2, 0x9f, 0x72, 0x46, 0x46, 0x30,
-	0x8b, 0xa6, 0x30, 0x85, 0xc8, 0xc6, 0x59, 0x3e, 0xb1, 0xca, 0x0a, 0x3b, 0x95, 0x35, 0x8d, 0xe3,
-	0x9d, 0xb8, 0x69, 0x8c, 0x36, 0x8d, 0x51, 0x06, 0x62, 0xc1, 0x55, 0x24, 0x59, 0x04, 0x32, 0x2b,
-	0xd3, 0xed, 0x57, 0x17, 0x05, 0x8f, 0x76, 0x0b, 0xca, 0x47, 0xa0, 0xc6, 0xf8, 0x3f, 0x72, 0x07,
-	0xfd, 0xd0, 0x69, 0x39, 0x1d, 0x9f, 0xba, 0x83, 0x3e, 0xbe, 0x47, 0xd5, 0x87, 0x98, 0x71, 0x91,
-	0x85, 0x6e, 0xcb, 0xeb, 0xd4, 0xcf, 0xba, 0xe4, 0xe7, 0x35, 0xc9, 0x6e, 0x0b, 0x29, 0x91, 0xdb,
-	0xb9, 0x56, 0x05, 0x5d, 0xf3, 0xb8, 0x8b, 0x7c, 0x29, 0x62, 0x3d, 0x01, 0x35, 0xcb, 0x42, 0xcf,
-	0x96, 0x05, 0x44, 0x32, 0x32, 0x5c, 0x9b, 0xbd, 0xca, 0xf2, 0xfd, 0xe8, 0x0f, 0xdd, 0x86, 0xf0,
-	0x35, 0xaa, 0xdd, 0xdd, 0x0c, 0x41, 0x24, 0xa3, 0x22, 0xac, 0x58, 0xa0, 0xf5, 0xdb, 0xeb, 0x9b,
-	0x1c, 0xfd, 0x22, 0x1a, 0x97, 0xa8, 0xbe, 0xb3, 0x06, 0xde, 0x43, 0x5e, 0xca, 0x8b, 0xf5, 0x65,
-	0x66, 0xc4, 0xfb, 0xe8, 0xef, 0x22, 0x16, 0x39, 0x0f, 0x5d, 0xeb, 0x95, 0xe2, 0xca, 0xbd, 0x70,
-	0xda, 0x2f, 0xdb, 0x87, 0x0d, 0x17, 0x0b, 0x61, 0xb9, 0x1a, 0x35, 0x23, 0x6e, 0xa3, 0x20, 0xe5,
-	0x5c, 0xf6, 0x73, 0x15, 0xeb, 0x04, 0xe6, 0x16, 0xf7, 0xe8, 0x37, 0x0f, 0x1f, 0x22, 0xdf, 0xe8,
-	0x5e, 0xa1, 0xb9, 0x39, 0xd6, 0x04, 0xb6, 0x06, 0x0e, 0xd1, 0xbf, 0x49, 0x22, 0x34, 0x57, 0x99,
-	0xbd, 0xcb, 0xa7, 0x1b, 0xd9, 0x0b, 0x96, 0xab, 0xa6, 0xf3, 0xb6, 0x6a, 0x3a, 0x1f, 0xab, 0xa6,
-	0xc3, 0xaa, 0xf6, 0x93, 0xce, 0x3f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xfc, 0x79, 0x52, 0x6a, 0x29,
-	0x02, 0x00, 0x00,
+	// 400 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcd, 0x8e, 0xd3, 0x30,
+	0x14, 0x85, 0x49, 0x32, 0xd3, 0x99, 0x78, 0x02, 0x42, 0x16, 0x42, 0x51, 0x85, 0x4a, 0x55, 0xb1,
+	0x98, 0x05, 0x38, 0x03, 0x6c, 0x00, 0xb1, 0x2a, 0xe5, 0x67, 0x24, 0x16, 0x95, 0x11, 0x62, 0x1d,
+	0xa7, 0xb7, 0x25, 0x8a, 0x53, 0x5b, 0xb6, 0x13, 0xc8, 0x73, 0xf0, 0x52, 0x5d, 0xf2, 0x04, 0x08,
+	0x75, 0xc1, 0x73, 0x20, 0x3b, 0x09, 0x4d, 0x25, 0x66, 0x77, 0xef, 0xd1, 0x77, 0x8e, 0xcf, 0x95,
+	0x8c, 0xa2, 0x6f, 0x42, 0x15, 0xa0, 0x88, 0x54, 0xc2, 0x08, 0x7c, 0xbf, 0x14, 0xac, 0x21, 0xac,
+	0xca, 0xf9, 0xaa, 0xc8, 0x0d, 0xa9, 0x9f, 0x12, 0xd3, 0x48, 0xd0, 0xe3, 0x27, 0x9b, 0xdc, 0x7c,
+	0xad, 0x18, 0xc9, 0x44, 0x99, 0x6c, 0xc4, 0x46, 0x24, 0x0e, 0x67, 0xd5, 0xda, 0x6d, 0x6e, 0x71,
+	0x53, 0x1b, 0x33, 0x7e, 0x3c, 0xc0, 0x6d, 0x62, 0xd2, 0x27, 0x26, 0x5a, 0xf0, 0x1a, 0x54, 0x22,
+	0x59, 0x22, 0xa4, 0x6e, 0xe9, 0xd9, 0x0f, 0x1f, 0x45, 0x5f, 0x5c, 0x0b, 0x0a, 0x99, 0x50, 0x2b,
+	0x7c, 0x07, 0xf9, 0xd7, 0x8b, 0xd8, 0x9b, 0x7a, 0x97, 0x21, 0xf5, 0xaf, 0x17, 0xf8, 0x03, 0x1a,
+	0x7d, 0x4c, 0x19, 0x70, 0x1d, 0xfb, 0xd3, 0xe0, 0xf2, 0xe2, 0xd9, 0x15, 0xf9, 0x7f, 0x4d, 0x32,
+	0x4c, 0x21, 0xad, 0xe5, 0xed, 0xd6, 0xa8, 0x86, 0x76, 0x7e, 0x7c, 0x85, 0x42, 0xc9, 0x53, 0xb3,
+	0x16, 0xaa, 0xd4, 0x71, 0xe0, 0xc2, 0x22, 0x22, 0x19, 0x59, 0x76, 0xe2, 0xfc, 0x64, 0xf7, 0xeb,
+	0xe1, 0x2d, 0x7a, 0x80, 0xf0, 0x6b, 0x74, 0xfe, 0xfe, 0xcd, 0x52, 0xf0, 0x3c, 0x6b, 0xe2, 0x13,
+	0x67, 0x98, 0xde, 0xf4, 0x7a, 0xcf, 0xd1, 0x7f, 0x8e, 0xf1, 0x4b, 0x74, 0x31, 0xa8, 0x81, 0xef,
+	0xa2, 0xa0, 0x80, 0xa6, 0xbb, 0xcc, 0x8e, 0xf8, 0x1e, 0x3a, 0xad, 0x53, 0x5e, 0x41, 0xec, 0x3b,
+	0xad, 0x5d, 0x5e, 0xf9, 0x2f, 0xbc, 0xd9, 0x1f, 0xef, 0xf0, 0xb2, 0x35, 0xa6, 0x9c, 0x3b, 0xe3,
+	0x39, 0xb5, 0x23, 0x9e, 0xa1, 0xa8, 0x00, 0x90, 0x8b, 0x4a, 0xa5, 0x26, 0x17, 0x5b, 0xe7, 0x0f,
+	0xe8, 0x91, 0x86, 0x1f, 0xa0, 0xd0, 0xee, 0xf3, 0xc6, 0x80, 0xbd, 0xd6, 0x02, 0x07, 0x01, 0xc7,
+	0xe8, 0x6c, 0x9d, 0x73, 0x03, 0x4a, 0xbb, 0xc3, 0x42, 0xda, 0xaf, 0xf8, 0x11, 0xba, 0xad, 0x40,
+	0x83, 0xaa, 0x61, 0xf5, 0x49, 0xa6, 0x19, 0xc4, 0xa7, 0xce, 0x7b, 0x2c, 0xda, 0x06, 0x65, 0xfa,
+	0xfd, 0xb3, 0xee, 0xa1, 0x51, 0xdb, 0x60, 0xa8, 0x39, 0x26, 0xdf, 0xbe, 0x53, 0x00, 0x2d, 0x73,
+	0xd6, 0x31, 0x03, 0x6d, 0x1e, 0xed, 0xf6, 0x13, 0xef, 0xe7, 0x7e, 0xe2, 0xfd, 0xde, 0x4f, 0x3c,
+	0x36, 0x72, 0x7f, 0xe2, 0xf9, 0xdf, 0x01, 0x00, 0x6d, 0xda, 0xd4, 0xbe, 0x98, 0x02, 0x00, 0x00,
 }
 
 func (m *WorkerRecord) Marshal() (dAtA []byte, err error) {
@@ -306,6 +332,21 @@ func (m *GCPolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.MinFreeSpace != 0 {
+		i = encodeVarintWorker(dAtA, i, uint64(m.MinFreeSpace))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.MaxUsedSpace != 0 {
+		i = encodeVarintWorker(dAtA, i, uint64(m.MaxUsedSpace))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.ReservedSpace != 0 {
+		i = encodeVarintWorker(dAtA, i, uint64(m.ReservedSpace))
+		i--
+		dAtA[i] = 0x28
+	}
 	if len(m.Filters) > 0 {
 		for iNdEx := len(m.Filters) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Filters[iNdEx])
@@ -406,6 +447,15 @@ func (m *GCPolicy) Size() (n int) {
 			n += 1 + l + sovWorker(uint64(l))
 		}
 	}
+	if m.ReservedSpace != 0 {
+		n += 1 + sovWorker(uint64(m.ReservedSpace))
+	}
+	if m.MaxUsedSpace != 0 {
+		n += 1 + sovWorker(uint64(m.MaxUsedSpace))
+	}
+	if m.MinFreeSpace != 0 {
+		n += 1 + sovWorker(uint64(m.MinFreeSpace))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -818,6 +868,63 @@ func (m *GCPolicy) Unmarshal(dAtA []byte) error {
 			}
 			m.Filters = append(m.Filters, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReservedSpace", wireType)
+			}
+			m.ReservedSpace = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReservedSpace |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxUsedSpace", wireType)
+			}
+			m.MaxUsedSpace = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxUsedSpace |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinFreeSpace", wireType)
+			}
+			m.MinFreeSpace = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinFreeSpace |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorker(dAtA[iNdEx:])
@@ -8,8 +8,10 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"sync"
 
+	"github.com/docker/docker/pkg/fileutils"
 	"github.com/docker/docker/pkg/idtools"
 	iradix "github.com/hashicorp/go-immutable-radix"
 	"github.com/hashicorp/golang-lru/simplelru"
@@ -53,6 +55,13 @@ func ChecksumWildcard(ctx context.Context, ref cache.ImmutableRef, path string,
 	return getDefaultManager().ChecksumWildcard(ctx, ref, path, followLinks, s)
 }
 
+// ChecksumFiltered returns the checksum of the files of path selected by the
+// include and exclude patterns of opts. Patterns are matched relative to a
+// directory, and to the root for a single file, like in a filtered copy.
+func ChecksumFiltered(ctx context.Context, ref cache.ImmutableRef, path string, opts FilterOpts, s session.Group) (digest.Digest, error) {
+	return getDefaultManager().ChecksumFiltered(ctx, ref, path, opts, s)
+}
+
 func GetCacheContext(ctx context.Context, md *metadata.StorageItem, idmap *idtools.IdentityMapping) (CacheContext, error) {
 	return getDefaultManager().GetCacheContext(ctx, md, idmap)
 }
@@ -68,9 +77,18 @@ func ClearCacheContext(md *metadata.StorageItem) {
 type CacheContext interface {
 	Checksum(ctx context.Context, ref cache.Mountable, p string, followLinks bool, s session.Group) (digest.Digest, error)
 	ChecksumWildcard(ctx context.Context, ref cache.Mountable, p string, followLinks bool, s session.Group) (digest.Digest, error)
+	ChecksumFiltered(ctx context.Context, ref cache.Mountable, p string, opts FilterOpts, s session.Group) (digest.Digest, error)
 	HandleChange(kind fsutil.ChangeKind, p string, fi os.FileInfo, err error) error
 }
 
+// FilterOpts are the options of ChecksumFiltered.
+type FilterOpts struct {
+	IncludePatterns []string
+	ExcludePatterns []string
+	Wildcard        bool
+	FollowLinks     bool
+}
+
 type Hashed interface {
 	Digest() digest.Digest
 }
@@ -102,6 +120,14 @@ func (cm *cacheManager) ChecksumWildcard(ctx context.Context, ref cache.Immutabl
 	return cc.ChecksumWildcard(ctx, ref, p, followLinks, s)
 }
 
+func (cm *cacheManager) ChecksumFiltered(ctx context.Context, ref cache.ImmutableRef, p string, opts FilterOpts, s session.Group) (digest.Digest, error) {
+	cc, err := cm.GetCacheContext(ctx, ensureOriginMetadata(ref.Metadata()), ref.IdentityMapping())
+	if err != nil {
+		return "", nil
+	}
+	return cc.ChecksumFiltered(ctx, ref, p, opts, s)
+}
+
 func (cm *cacheManager) GetCacheContext(ctx context.Context, md *metadata.StorageItem, idmap *idtools.IdentityMapping) (CacheContext, error) {
 	cm.locker.Lock(md.ID())
 	cm.lruMu.Lock()
@@ -426,6 +452,177 @@ func (cc *cacheContext) Checksum(ctx context.Context, mountable cache.Mountable,
 	return cc.checksumFollow(ctx, m, p, followLinks)
 }
 
+func (cc *cacheContext) ChecksumFiltered(ctx context.Context, mountable cache.Mountable, p string, opts FilterOpts, s session.Group) (digest.Digest, error) {
+	include, err := newMatcher(opts.IncludePatterns)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid include patterns")
+	}
+	exclude, err := newMatcher(opts.ExcludePatterns)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid exclude patterns")
+	}
+
+	m := &mount{mountable: mountable, session: s}
+	defer m.clean()
+
+	paths := []string{p}
+	if opts.Wildcard {
+		wildcards, err := cc.wildcards(ctx, m, p)
+		if err != nil {
+			return "", err
+		}
+		paths = paths[:0]
+		for _, w := range wildcards {
+			paths = append(paths, w.Path)
+		}
+	}
+
+	dgsts := make([][]byte, 0, len(paths))
+	for _, p := range paths {
+		dgst, err := cc.checksumFiltered(ctx, m, p, opts.FollowLinks, include, exclude)
+		if err != nil {
+			return "", err
+		}
+		dgsts = append(dgsts, []byte(dgst))
+	}
+	if len(dgsts) == 1 {
+		return digest.Digest(dgsts[0]), nil
+	}
+	return digest.FromBytes(bytes.Join(dgsts, []byte{0})), nil
+}
+
+// checksumFiltered returns the checksum of the headers and files below p that
+// are selected by the patterns. A single file is matched by its path relative
+// to the root.
+func (cc *cacheContext) checksumFiltered(ctx context.Context, m *mount, p string, follow bool, include, exclude *fileutils.PatternMatcher) (digest.Digest, error) {
+	const maxSymlinkLimit = 255
+	orig := p
+	var cr *CacheRecord
+	for i := 0; ; i++ {
+		if i > maxSymlinkLimit {
+			return "", errors.Errorf("too many symlinks: %s", orig)
+		}
+		var err error
+		// also scans the path and computes the digests of its records
+		cr, err = cc.checksumNoFollow(ctx, m, p)
+		if err != nil {
+			return "", err
+		}
+		if cr.Type != CacheRecordTypeSymlink || !follow {
+			break
+		}
+		link := cr.Linkname
+		if !path.IsAbs(cr.Linkname) {
+			link = path.Join(path.Dir(p), link)
+		}
+		p = link
+	}
+
+	if cr.Type != CacheRecordTypeDir {
+		ok, err := matchPath(include, exclude, strings.TrimPrefix(path.Join("/", filepath.ToSlash(orig)), "/"))
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return digest.FromBytes([]byte{}), nil
+		}
+		return cr.Digest, nil
+	}
+
+	p = path.Join("/", filepath.ToSlash(p))
+	if p == "/" {
+		p = ""
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if cc.txn != nil {
+		cc.commitActiveTransaction()
+	}
+
+	defer func() {
+		if cc.dirty {
+			go cc.save()
+			cc.dirty = false
+		}
+	}()
+
+	txn := cc.tree.Txn()
+	root := txn.Root()
+	var updated bool
+
+	h := sha256.New()
+	next := append(convertPathToKey([]byte(p)), 0)
+	iter := root.Seek(next)
+	for subk, ok := next, true; ok && bytes.HasPrefix(subk, next); subk, _, ok = iter.Next() {
+		relk := bytes.TrimPrefix(subk, next)
+		isHeader := len(relk) == 0 || relk[len(relk)-1] == 0
+		rel := string(convertKeyToPath(bytes.TrimSuffix(relk, []byte{0})))
+
+		if rel != "" {
+			if exclude != nil && !isHeader {
+				excluded, err := exclude.Matches(rel)
+				if err != nil {
+					return "", err
+				}
+				if excluded && !exclude.Exclusions() {
+					// skip the header and contents of the directory
+					iter = root.Seek(append(subk, 0, 0xff))
+					continue
+				}
+			}
+			ok, err := matchPath(include, exclude, rel)
+			if err != nil {
+				return "", err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		// entries are copied as is, symlinks are not followed
+		subcr, upt, err := cc.checksum(ctx, root, txn, m, subk, false)
+		if err != nil {
+			return "", err
+		}
+		if upt {
+			updated = true
+		}
+		// directory contents are made of the records that follow
+		if subcr.Type == CacheRecordTypeDir && !isHeader {
+			continue
+		}
+		h.Write(relk)
+		h.Write([]byte(subcr.Digest))
+	}
+
+	cc.tree = txn.Commit()
+	cc.dirty = updated
+
+	return digest.NewDigest(digest.SHA256, h), nil
+}
+
+func newMatcher(patterns []string) (*fileutils.PatternMatcher, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	return fileutils.NewPatternMatcher(patterns)
+}
+
+func matchPath(include, exclude *fileutils.PatternMatcher, p string) (bool, error) {
+	if exclude != nil {
+		excluded, err := exclude.Matches(p)
+		if err != nil || excluded {
+			return false, err
+		}
+	}
+	if include != nil {
+		return include.Matches(p)
+	}
+	return true, nil
+}
+
 func (cc *cacheContext) checksumFollow(ctx context.Context, m *mount, p string, follow bool) (digest.Digest, error) {
 	const maxSymlinkLimit = 255
 	i := 0
@@ -203,6 +203,81 @@ func TestChecksumWildcard(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestChecksumFiltered(t *testing.T) {
+	t.Parallel()
+	tmpdir, err := ioutil.TempDir("", "buildkit-state")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	snapshotter, err := native.NewSnapshotter(filepath.Join(tmpdir, "snapshots"))
+	require.NoError(t, err)
+	cm, _ := setupCacheManager(t, tmpdir, "native", snapshotter)
+	defer cm.Close()
+
+	checksums := func(ch []string) map[string]digest.Digest {
+		ref := createRef(t, cm, ch)
+		defer ref.Release(context.TODO())
+
+		cc, err := newCacheContext(ref.Metadata(), nil)
+		require.NoError(t, err)
+
+		dgsts := map[string]digest.Digest{}
+		for name, tc := range map[string]struct {
+			p    string
+			opts FilterOpts
+		}{
+			"exclude":     {"x", FilterOpts{ExcludePatterns: []string{"*.go"}}},
+			"exclude-dir": {"x", FilterOpts{ExcludePatterns: []string{"sub"}}},
+			"include":     {"x", FilterOpts{IncludePatterns: []string{"sub"}}},
+			"wildcard":    {"x*", FilterOpts{ExcludePatterns: []string{"*.go"}, Wildcard: true}},
+			"file":        {"x/b.go", FilterOpts{ExcludePatterns: []string{"x/*.go"}}},
+		} {
+			dgst, err := cc.ChecksumFiltered(context.TODO(), ref, tc.p, tc.opts, nil)
+			require.NoError(t, err)
+			dgsts[name] = dgst
+		}
+		dgst, err := cc.Checksum(context.TODO(), ref, "x", false, nil)
+		require.NoError(t, err)
+		dgsts["all"] = dgst
+		return dgsts
+	}
+
+	base := checksums([]string{
+		"ADD x dir",
+		"ADD x/a.txt file data0",
+		"ADD x/b.go file data1",
+		"ADD x/sub dir",
+		"ADD x/sub/c.txt file data2",
+	})
+	// changes of files that are not in sub
+	modified := checksums([]string{
+		"ADD x dir",
+		"ADD x/a.txt file data0",
+		"ADD x/b.go file data3",
+		"ADD x/sub dir",
+		"ADD x/sub/c.txt file data2",
+	})
+	require.NotEqual(t, base["all"], modified["all"])
+	require.Equal(t, base["exclude"], modified["exclude"])
+	require.NotEqual(t, base["exclude-dir"], modified["exclude-dir"])
+	require.Equal(t, base["include"], modified["include"])
+	require.Equal(t, base["wildcard"], modified["wildcard"])
+	require.Equal(t, digest.FromBytes([]byte{}), base["file"])
+
+	// changes of files in sub
+	modified = checksums([]string{
+		"ADD x dir",
+		"ADD x/a.txt file data0",
+		"ADD x/b.go file data1",
+		"ADD x/sub dir",
+		"ADD x/sub/c.txt file data3",
+	})
+	require.NotEqual(t, base["exclude"], modified["exclude"])
+	require.Equal(t, base["exclude-dir"], modified["exclude-dir"])
+	require.NotEqual(t, base["include"], modified["include"])
+	require.NotEqual(t, base["wildcard"], modified["wildcard"])
+}
+
 func TestChecksumWildcardWithBadMountable(t *testing.T) {
 	t.Parallel()
 	tmpdir, err := ioutil.TempDir("", "buildkit-state")
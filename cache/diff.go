@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/diff"
+	"github.com/containerd/containerd/mount"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/util/compression"
+	"github.com/moby/buildkit/util/leaseutil"
+	"github.com/moby/buildkit/util/progress"
+	"github.com/moby/buildkit/util/progress/controller"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// Diff returns a ref with a single layer containing the changes from lower to
+// upper, including deletions. The returned ref has no parent so GetRemote
+// exports it as one blob that can be applied on top of any other ref. A nil
+// lower or upper is scratch. Diff returns nil if upper is scratch.
+func (cm *cacheManager) Diff(ctx context.Context, lower, upper ImmutableRef, s session.Group, opts ...RefOption) (ImmutableRef, error) {
+	if upper == nil {
+		return nil, nil
+	}
+
+	ctx, done, err := leaseutil.WithLease(ctx, cm.LeaseManager, leaseutil.MakeTemporary)
+	if err != nil {
+		return nil, err
+	}
+	defer done(context.TODO())
+
+	desc, provider, err := cm.diffBlob(ctx, lower, upper, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if provider != nil {
+		pw, _, _ := progress.FromContext(ctx)
+		opts = append(opts, DescHandlers(map[digest.Digest]*DescHandler{
+			desc.Digest: {
+				Provider: func(session.Group) content.Provider { return provider },
+				Progress: &controller.Controller{Writer: pw},
+			},
+		}))
+	}
+	return cm.GetByBlob(ctx, desc, nil, opts...)
+}
+
+// diffBlob returns the blob for the changes from lower to upper. If upper is
+// a direct child of lower its existing blob is reused, otherwise a new blob is
+// created by comparing the mounts of both refs.
+func (cm *cacheManager) diffBlob(ctx context.Context, lower, upper ImmutableRef, s session.Group) (ocispec.Descriptor, content.Provider, error) {
+	if sr, ok := upper.(*immutableRef); ok && sr.parent != nil && lower != nil && sr.parent.ID() == lower.ID() {
+		remote, err := upper.GetRemote(ctx, true, compression.Default, s)
+		if err != nil {
+			return ocispec.Descriptor{}, nil, err
+		}
+		return remote.Descriptors[len(remote.Descriptors)-1], remote.Provider, nil
+	}
+
+	if cm.Differ == nil {
+		return ocispec.Descriptor{}, nil, errors.Errorf("diff is not supported without a differ")
+	}
+
+	var lowerMounts []mount.Mount
+	if lower != nil {
+		m, err := lower.Mount(ctx, true, s)
+		if err != nil {
+			return ocispec.Descriptor{}, nil, err
+		}
+		var release func() error
+		lowerMounts, release, err = m.Mount()
+		if err != nil {
+			return ocispec.Descriptor{}, nil, err
+		}
+		if release != nil {
+			defer release()
+		}
+	}
+
+	m, err := upper.Mount(ctx, true, s)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	upperMounts, release, err := m.Mount()
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	if release != nil {
+		defer release()
+	}
+
+	desc, err := cm.Differ.Compare(ctx, lowerMounts, upperMounts, diff.WithMediaType(ocispec.MediaTypeImageLayerGzip))
+	if err != nil {
+		return ocispec.Descriptor{}, nil, errors.Wrap(err, "failed to compute diff")
+	}
+
+	info, err := cm.ContentStore.Info(ctx, desc.Digest)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	diffID, ok := info.Labels[containerdUncompressed]
+	if !ok {
+		return ocispec.Descriptor{}, nil, errors.Errorf("missing uncompressed digest for diff %s", desc.Digest)
+	}
+	if desc.Annotations == nil {
+		desc.Annotations = map[string]string{}
+	}
+	desc.Annotations[containerdUncompressed] = diffID
+	return desc, nil, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/snapshot"
+	"github.com/moby/buildkit/util/disk"
 	"github.com/moby/buildkit/util/flightcontrol"
 	digest "github.com/opencontainers/go-digest"
 	imagespecidentity "github.com/opencontainers/image-spec/identity"
@@ -42,6 +43,9 @@ type ManagerOpt struct {
 	GarbageCollect  func(ctx context.Context) (gc.Stats, error)
 	Applier         diff.Applier
 	Differ          diff.Comparer
+	// Root is a directory on the filesystem holding the cache. It is used to
+	// measure free disk space for MinFreeSpace prune policies.
+	Root string
 }
 
 type Accessor interface {
@@ -50,6 +54,8 @@ type Accessor interface {
 
 	New(ctx context.Context, parent ImmutableRef, s session.Group, opts ...RefOption) (MutableRef, error)
 	GetMutable(ctx context.Context, id string, opts ...RefOption) (MutableRef, error) // Rebase?
+	Merge(ctx context.Context, inputs []ImmutableRef, s session.Group, opts ...RefOption) (ImmutableRef, error)
+	Diff(ctx context.Context, lower, upper ImmutableRef, s session.Group, opts ...RefOption) (ImmutableRef, error)
 	IdentityMapping() *idtools.IdentityMapping
 	Metadata(string) *metadata.StorageItem
 }
@@ -326,10 +332,17 @@ func (cm *cacheManager) get(ctx context.Context, id string, opts ...RefOption) (
 
 	descHandlers := descHandlersOf(opts...)
 
-	if rec.mutable {
-		if len(rec.refs) != 0 {
-			return nil, errors.Wrapf(ErrLocked, "%s is locked", id)
+	if rec.mutable && len(rec.refs) != 0 {
+		return nil, errors.Wrapf(ErrLocked, "%s is locked", id)
+	}
+
+	if triggerUpdate {
+		if err := setLastUsedBuild(rec.md, buildRefOf(opts...)); err != nil {
+			return nil, err
 		}
+	}
+
+	if rec.mutable {
 		if rec.equalImmutable != nil {
 			return rec.equalImmutable.ref(triggerUpdate, descHandlers), nil
 		}
@@ -579,22 +592,29 @@ func (cm *cacheManager) GetMutable(ctx context.Context, id string, opts ...RefOp
 		rec.equalImmutable = nil
 	}
 
+	if err := setLastUsedBuild(rec.md, buildRefOf(opts...)); err != nil {
+		return nil, err
+	}
+
 	return rec.mref(true, descHandlersOf(opts...)), nil
 }
 
 func (cm *cacheManager) Prune(ctx context.Context, ch chan client.UsageInfo, opts ...client.PruneInfo) error {
 	cm.muPrune.Lock()
 
+	plan := &prunePlan{records: map[*cacheRecord]struct{}{}}
+	dryRun := false
 	for _, opt := range opts {
-		if err := cm.pruneOnce(ctx, ch, opt); err != nil {
+		if err := cm.pruneOnce(ctx, ch, opt, plan); err != nil {
 			cm.muPrune.Unlock()
 			return err
 		}
+		dryRun = dryRun || opt.DryRun
 	}
 
 	cm.muPrune.Unlock()
 
-	if cm.GarbageCollect != nil {
+	if cm.GarbageCollect != nil && !dryRun {
 		if _, err := cm.GarbageCollect(ctx); err != nil {
 			return err
 		}
@@ -603,7 +623,7 @@ func (cm *cacheManager) Prune(ctx context.Context, ch chan client.UsageInfo, opt
 	return nil
 }
 
-func (cm *cacheManager) pruneOnce(ctx context.Context, ch chan client.UsageInfo, opt client.PruneInfo) error {
+func (cm *cacheManager) pruneOnce(ctx context.Context, ch chan client.UsageInfo, opt client.PruneInfo, plan *prunePlan) error {
 	filter, err := filters.ParseAll(opt.Filter...)
 	if err != nil {
 		return errors.Wrapf(err, "failed to parse prune filters %v", opt.Filter)
@@ -619,7 +639,7 @@ func (cm *cacheManager) pruneOnce(ctx context.Context, ch chan client.UsageInfo,
 	}
 
 	totalSize := int64(0)
-	if opt.KeepBytes != 0 {
+	if opt.KeepBytes != 0 || opt.ReservedSpace != 0 || opt.MaxUsedSpace != 0 || opt.MinFreeSpace != 0 {
 		du, err := cm.DiskUsage(ctx, client.DiskUsageInfo{})
 		if err != nil {
 			return err
@@ -630,6 +650,21 @@ func (cm *cacheManager) pruneOnce(ctx context.Context, ch chan client.UsageInfo,
 			}
 			totalSize += ui.Size
 		}
+		// records planned for removal by a previous dry-run policy
+		totalSize -= plan.size
+	}
+
+	keepBytes, ok, err := cm.calculateKeepBytes(totalSize, plan.freed, opt)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	var p *prunePlan
+	if opt.DryRun {
+		p = plan
 	}
 
 	return cm.prune(ctx, ch, pruneOpt{
@@ -637,11 +672,57 @@ func (cm *cacheManager) pruneOnce(ctx context.Context, ch chan client.UsageInfo,
 		all:          opt.All,
 		checkShared:  check,
 		keepDuration: opt.KeepDuration,
-		keepBytes:    opt.KeepBytes,
+		keepBytes:    keepBytes,
 		totalSize:    totalSize,
+		plan:         p,
+		freed:        &plan.freed,
 	})
 }
 
+// calculateKeepBytes returns the cache size that the policy prunes down to.
+// Zero means that all matching records are pruned. It returns false if the
+// free disk space is above the policy threshold and there is no other limit.
+// freed is the size of the records removed by the previous policies, which is
+// counted as free space as it is only returned to the disk by GarbageCollect.
+func (cm *cacheManager) calculateKeepBytes(totalSize, freed int64, opt client.PruneInfo) (int64, bool, error) {
+	maxUsed := opt.MaxUsedSpace
+	if maxUsed == 0 {
+		maxUsed = opt.KeepBytes
+	}
+	if opt.MinFreeSpace == 0 {
+		return maxInt64(maxUsed, opt.ReservedSpace), true, nil
+	}
+	if cm.Root == "" {
+		return 0, false, errors.New("prune policy with min free space requires cache root")
+	}
+	dstat, err := getDiskStat(cm.Root)
+	if err != nil {
+		return 0, false, err
+	}
+	excess := opt.MinFreeSpace - (dstat.Available + freed)
+	if excess <= 0 {
+		if maxUsed == 0 {
+			return 0, false, nil
+		}
+		return maxInt64(maxUsed, opt.ReservedSpace), true, nil
+	}
+	keep := totalSize - excess
+	if maxUsed != 0 && maxUsed < keep {
+		keep = maxUsed
+	}
+	return maxInt64(keep, opt.ReservedSpace), true, nil
+}
+
+// getDiskStat is replaced in tests
+var getDiskStat = disk.GetDiskStat
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func (cm *cacheManager) prune(ctx context.Context, ch chan client.UsageInfo, opt pruneOpt) error {
 	var toDelete []*deleteRecord
 
@@ -673,7 +754,7 @@ func (cm *cacheManager) prune(ctx context.Context, ch chan client.UsageInfo, opt
 			continue
 		}
 
-		if len(cr.refs) == 0 {
+		if opt.plan.activeRefs(cr) == 0 {
 			recordType := GetRecordType(cr)
 			if recordType == "" {
 				recordType = client.UsageRecordTypeRegular
@@ -692,10 +773,17 @@ func (cm *cacheManager) prune(ctx context.Context, ch chan client.UsageInfo, opt
 			}
 
 			c := &client.UsageInfo{
-				ID:         cr.ID(),
-				Mutable:    cr.mutable,
-				RecordType: recordType,
-				Shared:     shared,
+				ID:            cr.ID(),
+				Mutable:       cr.mutable,
+				RecordType:    recordType,
+				Shared:        shared,
+				Description:   GetDescription(cr.md),
+				CacheMountID:  GetCacheMountID(cr.md),
+				SourceOp:      GetSourceOp(cr.md),
+				BuildRef:      GetBuildRef(cr.md),
+				LastUsedBuild: GetLastUsedBuild(cr.md),
+				Frontend:      GetFrontend(cr.md),
+				CreatedAt:     GetCreatedAt(cr.md),
 			}
 
 			usageCount, lastUsedAt := getLastUsed(cr.md)
@@ -716,6 +804,11 @@ func (cm *cacheManager) prune(ctx context.Context, ch chan client.UsageInfo, opt
 					usageCount:  c.UsageCount,
 				})
 				if !gcMode {
+					if opt.plan != nil {
+						opt.plan.add(cr)
+						cr.mu.Unlock()
+						continue
+					}
 					cr.dead = true
 
 					// mark metadata as deleted in case we crash before cleanup finished
@@ -739,8 +832,12 @@ func (cm *cacheManager) prune(ctx context.Context, ch chan client.UsageInfo, opt
 		for i, cr := range toDelete {
 			// only remove single record at a time
 			if i == 0 {
-				cr.dead = true
-				err = setDeleted(cr.md)
+				if opt.plan != nil {
+					opt.plan.add(cr.cacheRecord)
+				} else {
+					cr.dead = true
+					err = setDeleted(cr.md)
+				}
 			}
 			cr.mu.Unlock()
 		}
@@ -763,14 +860,20 @@ func (cm *cacheManager) prune(ctx context.Context, ch chan client.UsageInfo, opt
 		usageCount, lastUsedAt := getLastUsed(cr.md)
 
 		c := client.UsageInfo{
-			ID:          cr.ID(),
-			Mutable:     cr.mutable,
-			InUse:       len(cr.refs) > 0,
-			Size:        getSize(cr.md),
-			CreatedAt:   GetCreatedAt(cr.md),
-			Description: GetDescription(cr.md),
-			LastUsedAt:  lastUsedAt,
-			UsageCount:  usageCount,
+			ID:            cr.ID(),
+			Mutable:       cr.mutable,
+			InUse:         len(cr.refs) > 0,
+			Size:          getSize(cr.md),
+			CreatedAt:     GetCreatedAt(cr.md),
+			Description:   GetDescription(cr.md),
+			LastUsedAt:    lastUsedAt,
+			UsageCount:    usageCount,
+			RecordType:    GetRecordType(cr),
+			CacheMountID:  GetCacheMountID(cr.md),
+			SourceOp:      GetSourceOp(cr.md),
+			BuildRef:      GetBuildRef(cr.md),
+			LastUsedBuild: GetLastUsedBuild(cr.md),
+			Frontend:      GetFrontend(cr.md),
 		}
 
 		if cr.parent != nil {
@@ -791,6 +894,16 @@ func (cm *cacheManager) prune(ctx context.Context, ch chan client.UsageInfo, opt
 
 		opt.totalSize -= c.Size
 
+		if opt.plan != nil {
+			opt.plan.size += c.Size
+			*opt.freed += c.Size
+			if ch != nil {
+				ch <- c
+			}
+			cr.mu.Unlock()
+			continue
+		}
+
 		if cr.equalImmutable != nil {
 			if err1 := cr.equalImmutable.remove(ctx, false); err == nil {
 				err = err1
@@ -799,6 +912,9 @@ func (cm *cacheManager) prune(ctx context.Context, ch chan client.UsageInfo, opt
 		if err1 := cr.remove(ctx, true); err == nil {
 			err = err1
 		}
+		if err == nil {
+			*opt.freed += c.Size
+		}
 
 		if err == nil && ch != nil {
 			ch <- c
@@ -856,6 +972,11 @@ type cacheUsageInfo struct {
 	usageCount  int
 	lastUsedAt  *time.Time
 	description string
+	cacheMount  string
+	sourceOp    string
+	buildRef    string
+	lastBuild   string
+	frontend    string
 	doubleRef   bool
 	recordType  client.UsageRecordType
 	shared      bool
@@ -890,6 +1011,11 @@ func (cm *cacheManager) DiskUsage(ctx context.Context, opt client.DiskUsageInfo)
 			usageCount:  usageCount,
 			lastUsedAt:  lastUsedAt,
 			description: GetDescription(cr.md),
+			cacheMount:  GetCacheMountID(cr.md),
+			sourceOp:    GetSourceOp(cr.md),
+			buildRef:    GetBuildRef(cr.md),
+			lastBuild:   GetLastUsedBuild(cr.md),
+			frontend:    GetFrontend(cr.md),
 			doubleRef:   cr.equalImmutable != nil,
 			recordType:  GetRecordType(cr),
 			parentChain: cr.parentChain(),
@@ -933,17 +1059,22 @@ func (cm *cacheManager) DiskUsage(ctx context.Context, opt client.DiskUsageInfo)
 	var du []*client.UsageInfo
 	for id, cr := range m {
 		c := &client.UsageInfo{
-			ID:          id,
-			Mutable:     cr.mutable,
-			InUse:       cr.refs > 0,
-			Size:        cr.size,
-			Parent:      cr.parent,
-			CreatedAt:   cr.createdAt,
-			Description: cr.description,
-			LastUsedAt:  cr.lastUsedAt,
-			UsageCount:  cr.usageCount,
-			RecordType:  cr.recordType,
-			Shared:      cr.shared,
+			ID:            id,
+			Mutable:       cr.mutable,
+			InUse:         cr.refs > 0,
+			Size:          cr.size,
+			Parent:        cr.parent,
+			CreatedAt:     cr.createdAt,
+			Description:   cr.description,
+			LastUsedAt:    cr.lastUsedAt,
+			UsageCount:    cr.usageCount,
+			RecordType:    cr.recordType,
+			Shared:        cr.shared,
+			CacheMountID:  cr.cacheMount,
+			SourceOp:      cr.sourceOp,
+			BuildRef:      cr.buildRef,
+			LastUsedBuild: cr.lastBuild,
+			Frontend:      cr.frontend,
 		}
 		if filter.Match(adaptUsageInfo(c)) {
 			du = append(du, c)
@@ -1024,6 +1155,45 @@ func WithRecordType(t client.UsageRecordType) RefOption {
 	}
 }
 
+// WithCacheMountID records the ID of the cache mount the ref backs.
+func WithCacheMountID(id string) RefOption {
+	return func(m withMetadata) error {
+		return queueStringValue(m.Metadata(), keyCacheMountID, id)
+	}
+}
+
+// WithSourceOp records the identifier of the source op, e.g.
+// "local://context", that created the ref.
+func WithSourceOp(identifier string) RefOption {
+	return func(m withMetadata) error {
+		return queueStringValue(m.Metadata(), keySourceOp, identifier)
+	}
+}
+
+type buildRef string
+
+// WithBuildRef records the build that created the ref, or that last used it
+// when passed to Get or GetMutable.
+func WithBuildRef(ref string) RefOption {
+	return buildRef(ref)
+}
+
+func buildRefOf(opts ...RefOption) string {
+	for _, opt := range opts {
+		if opt, ok := opt.(buildRef); ok {
+			return string(opt)
+		}
+	}
+	return ""
+}
+
+// WithFrontend records the frontend of the build that created the ref.
+func WithFrontend(frontend string) RefOption {
+	return func(m withMetadata) error {
+		return queueStringValue(m.Metadata(), keyFrontend, frontend)
+	}
+}
+
 func WithCreationTime(tm time.Time) RefOption {
 	return func(m withMetadata) error {
 		return queueCreatedAt(m.Metadata(), tm)
@@ -1069,6 +1239,15 @@ func initializeMetadata(m withMetadata, parent string, opts ...RefOption) error
 		return err
 	}
 
+	if ref := buildRefOf(opts...); ref != "" {
+		if err := queueStringValue(md, keyBuildRef, ref); err != nil {
+			return err
+		}
+		if err := queueStringValue(md, keyLastUsedBuild, ref); err != nil {
+			return err
+		}
+	}
+
 	for _, opt := range opts {
 		if fn, ok := opt.(func(withMetadata) error); ok {
 			if err := fn(m); err != nil {
@@ -1101,6 +1280,18 @@ func adaptUsageInfo(info *client.UsageInfo) filters.Adaptor {
 			return "", !info.Mutable
 		case "type":
 			return string(info.RecordType), info.RecordType != ""
+		case "cachemount":
+			return info.CacheMountID, info.CacheMountID != ""
+		case "source":
+			return info.SourceOp, info.SourceOp != ""
+		case "buildref":
+			return info.BuildRef, info.BuildRef != ""
+		case "lastbuild":
+			return info.LastUsedBuild, info.LastUsedBuild != ""
+		case "frontend":
+			return info.Frontend, info.Frontend != ""
+		case "age":
+			return ageBucket(info, time.Now()), true
 		case "shared":
 			return "", info.Shared
 		case "private":
@@ -1113,6 +1304,33 @@ func adaptUsageInfo(info *client.UsageInfo) filters.Adaptor {
 	})
 }
 
+// ageBuckets are the values of the "age" filter, by the maximum time since
+// the record was last used.
+var ageBuckets = []struct {
+	name string
+	age  time.Duration
+}{
+	{"hour", time.Hour},
+	{"day", 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+}
+
+// ageBucket returns the age bucket of the record at now, based on the time it
+// was last used or created if it was never used.
+func ageBucket(info *client.UsageInfo, now time.Time) string {
+	tm := info.CreatedAt
+	if info.LastUsedAt != nil {
+		tm = *info.LastUsedAt
+	}
+	for _, b := range ageBuckets {
+		if now.Sub(tm) < b.age {
+			return b.name
+		}
+	}
+	return "older"
+}
+
 type pruneOpt struct {
 	filter       filters.Filter
 	all          bool
@@ -1120,6 +1338,48 @@ type pruneOpt struct {
 	keepDuration time.Duration
 	keepBytes    int64
 	totalSize    int64
+	plan         *prunePlan
+	// freed is increased by the size of every removed or planned record
+	freed *int64
+}
+
+// prunePlan tracks the records selected by a dry-run prune so that they are
+// treated as removed by the following iterations and policies. freed is the
+// size of the records removed or planned by all policies so far, so that a
+// dry run and a real prune count pending space the same way.
+type prunePlan struct {
+	records map[*cacheRecord]struct{}
+	size    int64
+	freed   int64
+}
+
+func (p *prunePlan) add(cr *cacheRecord) {
+	p.records[cr] = struct{}{}
+	if cr.equalImmutable != nil {
+		p.records[cr.equalImmutable.cacheRecord] = struct{}{}
+	}
+}
+
+// activeRefs returns the number of references to the record that are not
+// held by records already in the plan. Records in the plan are reported as
+// referenced so they are not selected again.
+func (p *prunePlan) activeRefs(cr *cacheRecord) int {
+	if p == nil {
+		return len(cr.refs)
+	}
+	if _, ok := p.records[cr]; ok {
+		return 1
+	}
+	n := len(cr.refs)
+	for r := range p.records {
+		if r.parent == nil {
+			continue
+		}
+		if _, ok := cr.refs[r.parent]; ok {
+			n--
+		}
+	}
+	return n
 }
 
 type deleteRecord struct {
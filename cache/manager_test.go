@@ -7,14 +7,19 @@ import (
 	"context"
 	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/content/local"
 	"github.com/containerd/containerd/diff/apply"
+	"github.com/containerd/containerd/diff/walking"
 	"github.com/containerd/containerd/leases"
 	ctdmetadata "github.com/containerd/containerd/metadata"
 	"github.com/containerd/containerd/namespaces"
@@ -24,6 +29,8 @@ import (
 	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/snapshot"
 	containerdsnapshot "github.com/moby/buildkit/snapshot/containerd"
+	"github.com/moby/buildkit/util/compression"
+	"github.com/moby/buildkit/util/disk"
 	"github.com/moby/buildkit/util/leaseutil"
 	digest "github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -125,6 +132,7 @@ func newCacheManager(ctx context.Context, opt cmOpt) (co *cmOut, cleanup func()
 		LeaseManager:   leaseutil.WithNamespace(lm, ns),
 		GarbageCollect: mdb.GarbageCollect,
 		Applier:        apply.NewFileSystemApplier(mdb.ContentStore()),
+		Differ:         walking.NewWalkingDiff(mdb.ContentStore()),
 	})
 	if err != nil {
 		return nil, nil, err
@@ -952,6 +960,520 @@ func TestLazyCommit(t *testing.T) {
 	require.Equal(t, true, errors.Is(err, errNotFound))
 }
 
+func TestPruneDryRun(t *testing.T) {
+	t.Parallel()
+	ctx := namespaces.WithNamespace(context.Background(), "buildkit-test")
+
+	tmpdir, err := ioutil.TempDir("", "cachemanager")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	snapshotter, err := native.NewSnapshotter(filepath.Join(tmpdir, "snapshots"))
+	require.NoError(t, err)
+
+	co, cleanup, err := newCacheManager(ctx, cmOpt{
+		snapshotter:     snapshotter,
+		snapshotterName: "native",
+	})
+	require.NoError(t, err)
+
+	defer cleanup()
+	cm := co.manager
+
+	active, err := cm.New(ctx, nil, nil)
+	require.NoError(t, err)
+	snap, err := active.Commit(ctx)
+	require.NoError(t, err)
+
+	active, err = cm.New(ctx, snap, nil, CachePolicyRetain)
+	require.NoError(t, err)
+	snap2, err := active.Commit(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, snap2.Release(ctx))
+	require.NoError(t, snap.Release(ctx))
+	checkDiskUsage(ctx, t, cm, 0, 2)
+
+	// the parent is only released once the child is removed so it needs to
+	// be reported from the simulated state
+	buf := pruneResultBuffer()
+	err = cm.Prune(ctx, buf.C, client.PruneInfo{DryRun: true})
+	buf.close()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(buf.all))
+
+	checkDiskUsage(ctx, t, cm, 0, 2)
+	dirs, err := ioutil.ReadDir(filepath.Join(tmpdir, "snapshots/snapshots"))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(dirs))
+
+	// records planned by the first policy are not reported again
+	buf = pruneResultBuffer()
+	err = cm.Prune(ctx, buf.C, client.PruneInfo{DryRun: true}, client.PruneInfo{DryRun: true, All: true})
+	buf.close()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(buf.all))
+
+	buf = pruneResultBuffer()
+	err = cm.Prune(ctx, buf.C, client.PruneInfo{})
+	buf.close()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(buf.all))
+	checkDiskUsage(ctx, t, cm, 0, 0)
+}
+
+func TestPruneMinFreeSpacePolicies(t *testing.T) {
+	ctx := namespaces.WithNamespace(context.Background(), "buildkit-test")
+
+	tmpdir, err := ioutil.TempDir("", "cachemanager")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	co, cleanup, err := newCacheManager(ctx, cmOpt{tmpdir: tmpdir})
+	require.NoError(t, err)
+	defer cleanup()
+	cm := co.manager.(*cacheManager)
+	cm.Root = tmpdir
+
+	// removed records are only returned to the disk by GarbageCollect
+	const available = 1 << 30
+	getDiskStat = func(string) (disk.DiskStat, error) {
+		return disk.DiskStat{Available: available}, nil
+	}
+	defer func() {
+		getDiskStat = disk.GetDiskStat
+	}()
+
+	for _, name := range []string{"a", "b", "c"} {
+		active, err := cm.New(ctx, nil, nil, CachePolicyRetain)
+		require.NoError(t, err)
+		m, err := active.Mount(ctx, false, nil)
+		require.NoError(t, err)
+		lm := snapshot.LocalMounter(m)
+		dir, err := lm.Mount()
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(strings.Repeat(name, 1<<20)), 0600))
+		require.NoError(t, lm.Unmount())
+		ref, err := active.Commit(ctx)
+		require.NoError(t, err)
+		require.NoError(t, ref.Release(ctx))
+	}
+	du, err := cm.DiskUsage(ctx, client.DiskUsageInfo{})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(du))
+	var size int64
+	for _, ui := range du {
+		size = ui.Size
+	}
+
+	// one and a half records need to be removed, the second policy has
+	// nothing left to do
+	policy := client.PruneInfo{MinFreeSpace: available + size*3/2}
+
+	buf := pruneResultBuffer()
+	dryRun := policy
+	dryRun.DryRun = true
+	require.NoError(t, cm.Prune(ctx, buf.C, dryRun, dryRun))
+	buf.close()
+	require.Equal(t, 2, len(buf.all))
+	checkDiskUsage(ctx, t, cm, 0, 3)
+
+	buf = pruneResultBuffer()
+	require.NoError(t, cm.Prune(ctx, buf.C, policy, policy))
+	buf.close()
+	require.Equal(t, 2, len(buf.all))
+	checkDiskUsage(ctx, t, cm, 0, 1)
+}
+
+func TestCalculateKeepBytes(t *testing.T) {
+	t.Parallel()
+
+	tmpdir, err := ioutil.TempDir("", "cachemanager")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	cm := &cacheManager{ManagerOpt: ManagerOpt{Root: tmpdir}}
+
+	keep, ok, err := cm.calculateKeepBytes(100, 0, client.PruneInfo{})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(0), keep)
+
+	keep, ok, err = cm.calculateKeepBytes(100, 0, client.PruneInfo{KeepBytes: 50, ReservedSpace: 60})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(60), keep)
+
+	keep, ok, err = cm.calculateKeepBytes(100, 0, client.PruneInfo{KeepBytes: 50, MaxUsedSpace: 40})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(40), keep)
+
+	// enough free space
+	_, ok, err = cm.calculateKeepBytes(100, 0, client.PruneInfo{MinFreeSpace: 1})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	keep, ok, err = cm.calculateKeepBytes(100, 0, client.PruneInfo{MinFreeSpace: 1, MaxUsedSpace: 40})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(40), keep)
+
+	// free space can never be reached, prune down to the reserved space
+	keep, ok, err = cm.calculateKeepBytes(100, 0, client.PruneInfo{MinFreeSpace: math.MaxInt64 / 2, ReservedSpace: 30})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(30), keep)
+
+	cm.Root = ""
+	_, _, err = cm.calculateKeepBytes(100, 0, client.PruneInfo{MinFreeSpace: 1})
+	require.Error(t, err)
+}
+
+func TestUsageRecordFilters(t *testing.T) {
+	t.Parallel()
+	ctx := namespaces.WithNamespace(context.Background(), "buildkit-test")
+
+	tmpdir, err := ioutil.TempDir("", "cachemanager")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	snapshotter, err := native.NewSnapshotter(filepath.Join(tmpdir, "snapshots"))
+	require.NoError(t, err)
+
+	co, cleanup, err := newCacheManager(ctx, cmOpt{
+		snapshotter:     snapshotter,
+		snapshotterName: "native",
+	})
+	require.NoError(t, err)
+
+	defer cleanup()
+	cm := co.manager
+
+	mount, err := cm.New(ctx, nil, nil, WithRecordType(client.UsageRecordTypeCacheMount), WithCacheMountID("gocache"), WithBuildRef("build1"), CachePolicyRetain)
+	require.NoError(t, err)
+	mountID := mount.ID()
+	require.NoError(t, mount.Release(ctx))
+
+	active, err := cm.New(ctx, nil, nil, WithSourceOp("local://context"), WithBuildRef("build2"), WithFrontend("dockerfile.v0"), CachePolicyRetain)
+	require.NoError(t, err)
+	activeID := active.ID()
+	snap, err := active.Commit(ctx)
+	require.NoError(t, err)
+	require.NoError(t, snap.Release(ctx))
+
+	du, err := cm.DiskUsage(ctx, client.DiskUsageInfo{Filter: []string{"cachemount==gocache"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(du))
+	require.Equal(t, mountID, du[0].ID)
+	require.Equal(t, "gocache", du[0].CacheMountID)
+	require.Equal(t, "build1", du[0].BuildRef)
+	require.Equal(t, "build1", du[0].LastUsedBuild)
+
+	du, err = cm.DiskUsage(ctx, client.DiskUsageInfo{Filter: []string{"source==local://context"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(du))
+	require.Equal(t, activeID, du[0].ID)
+	require.Equal(t, "build2", du[0].BuildRef)
+
+	du, err = cm.DiskUsage(ctx, client.DiskUsageInfo{Filter: []string{"buildref==build3"}})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(du))
+
+	du, err = cm.DiskUsage(ctx, client.DiskUsageInfo{Filter: []string{"frontend==dockerfile.v0"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(du))
+	require.Equal(t, activeID, du[0].ID)
+	require.Equal(t, "dockerfile.v0", du[0].Frontend)
+
+	du, err = cm.DiskUsage(ctx, client.DiskUsageInfo{Filter: []string{"age==hour"}})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(du))
+
+	du, err = cm.DiskUsage(ctx, client.DiskUsageInfo{Filter: []string{"age!=hour"}})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(du))
+
+	buf := pruneResultBuffer()
+	err = cm.Prune(ctx, buf.C, client.PruneInfo{All: true, Filter: []string{"cachemount==gocache"}})
+	buf.close()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(buf.all))
+	require.Equal(t, mountID, buf.all[0].ID)
+	require.Equal(t, "gocache", buf.all[0].CacheMountID)
+
+	checkDiskUsage(ctx, t, cm, 0, 1)
+}
+
+func TestAgeBucket(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	at := func(d time.Duration) *time.Time {
+		tm := now.Add(-d)
+		return &tm
+	}
+	for _, tc := range []struct {
+		info     client.UsageInfo
+		expected string
+	}{
+		{client.UsageInfo{CreatedAt: *at(time.Minute)}, "hour"},
+		{client.UsageInfo{CreatedAt: *at(48 * time.Hour), LastUsedAt: at(2 * time.Hour)}, "day"},
+		{client.UsageInfo{CreatedAt: *at(48 * time.Hour)}, "week"},
+		{client.UsageInfo{CreatedAt: *at(10 * 24 * time.Hour)}, "month"},
+		{client.UsageInfo{CreatedAt: *at(60 * 24 * time.Hour)}, "older"},
+	} {
+		require.Equal(t, tc.expected, ageBucket(&tc.info, now))
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	ctx := namespaces.WithNamespace(context.Background(), "buildkit-test")
+
+	tmpdir, err := ioutil.TempDir("", "cachemanager")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	snapshotter, err := native.NewSnapshotter(filepath.Join(tmpdir, "snapshots"))
+	require.NoError(t, err)
+
+	co, cleanup, err := newCacheManager(ctx, cmOpt{
+		snapshotter:     snapshotter,
+		snapshotterName: "native",
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	ctx, done, err := leaseutil.WithLease(ctx, co.lm, leaseutil.MakeTemporary)
+	require.NoError(t, err)
+	defer done(context.TODO())
+
+	cm := co.manager
+
+	base := writeFiles(ctx, t, cm, nil, map[string]string{"foo": "foo0", "bar": "bar0"})
+	defer base.Release(ctx)
+	upper := writeFiles(ctx, t, cm, base, map[string]string{"baz": "baz0"}, "bar")
+	defer upper.Release(ctx)
+	upper2 := writeFiles(ctx, t, cm, upper, map[string]string{"foo": "foo1"})
+	defer upper2.Release(ctx)
+
+	// direct child reuses the blob of the upper ref
+	diff, err := cm.Diff(ctx, base, upper, nil)
+	require.NoError(t, err)
+	defer diff.Release(ctx)
+
+	remote, err := diff.GetRemote(ctx, true, compression.Default, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(remote.Descriptors))
+	require.Equal(t, upper.Info().Blob, remote.Descriptors[0].Digest)
+	require.Equal(t, []string{".wh.bar", "baz"}, blobFileNames(ctx, t, co.cs, remote.Descriptors[0]))
+
+	// otherwise the mounts are compared
+	diff2, err := cm.Diff(ctx, base, upper2, nil)
+	require.NoError(t, err)
+	defer diff2.Release(ctx)
+
+	remote, err = diff2.GetRemote(ctx, true, compression.Default, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(remote.Descriptors))
+	require.Equal(t, []string{".wh.bar", "baz", "foo"}, blobFileNames(ctx, t, co.cs, remote.Descriptors[0]))
+
+	diff3, err := cm.Diff(ctx, nil, upper, nil)
+	require.NoError(t, err)
+	defer diff3.Release(ctx)
+
+	remote, err = diff3.GetRemote(ctx, true, compression.Default, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(remote.Descriptors))
+	require.Equal(t, []string{"baz", "foo"}, blobFileNames(ctx, t, co.cs, remote.Descriptors[0]))
+
+	diff4, err := cm.Diff(ctx, base, nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, diff4)
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	ctx := namespaces.WithNamespace(context.Background(), "buildkit-test")
+
+	tmpdir, err := ioutil.TempDir("", "cachemanager")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	snapshotter, err := native.NewSnapshotter(filepath.Join(tmpdir, "snapshots"))
+	require.NoError(t, err)
+
+	co, cleanup, err := newCacheManager(ctx, cmOpt{
+		snapshotter:     snapshotter,
+		snapshotterName: "native",
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	ctx, done, err := leaseutil.WithLease(ctx, co.lm, leaseutil.MakeTemporary)
+	require.NoError(t, err)
+	defer done(context.TODO())
+
+	cm := co.manager
+
+	a := writeFiles(ctx, t, cm, nil, map[string]string{"foo": "foo0", "bar": "bar0"})
+	defer a.Release(ctx)
+	b := writeFiles(ctx, t, cm, nil, map[string]string{"bar": "bar1", "baz": "baz1"})
+	defer b.Release(ctx)
+	b2 := writeFiles(ctx, t, cm, b, map[string]string{"qux": "qux2"}, "baz")
+	defer b2.Release(ctx)
+
+	merged, err := cm.Merge(ctx, []ImmutableRef{a, nil, b2}, nil)
+	require.NoError(t, err)
+	defer merged.Release(ctx)
+
+	// the layers of the first input are reused, one layer is added for
+	// every layer of the following inputs
+	var layers []ImmutableRef
+	for r := merged; r != nil; r = r.Parent() {
+		layers = append(layers, r)
+	}
+	require.Equal(t, 3, len(layers))
+	require.Equal(t, a.ID(), layers[2].ID())
+
+	dir, release := refDir(ctx, t, merged)
+	defer release()
+	require.Equal(t, map[string]string{"foo": "foo0", "bar": "bar1", "qux": "qux2"}, readFiles(t, dir))
+
+	// the files of the layers are linked, not copied
+	b2Dir, releaseB2 := refDir(ctx, t, b2)
+	defer releaseB2()
+	fi1, err := os.Stat(filepath.Join(dir, "qux"))
+	require.NoError(t, err)
+	fi2, err := os.Stat(filepath.Join(b2Dir, "qux"))
+	require.NoError(t, err)
+	require.True(t, os.SameFile(fi1, fi2))
+
+	// a single input is returned as is
+	single, err := cm.Merge(ctx, []ImmutableRef{nil, a}, nil)
+	require.NoError(t, err)
+	defer single.Release(ctx)
+	require.Equal(t, a.ID(), single.ID())
+
+	scratch, err := cm.Merge(ctx, []ImmutableRef{nil, nil}, nil)
+	require.NoError(t, err)
+	require.Nil(t, scratch)
+}
+
+func TestMergeDiff(t *testing.T) {
+	t.Parallel()
+	ctx := namespaces.WithNamespace(context.Background(), "buildkit-test")
+
+	tmpdir, err := ioutil.TempDir("", "cachemanager")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	snapshotter, err := native.NewSnapshotter(filepath.Join(tmpdir, "snapshots"))
+	require.NoError(t, err)
+
+	co, cleanup, err := newCacheManager(ctx, cmOpt{
+		snapshotter:     snapshotter,
+		snapshotterName: "native",
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	ctx, done, err := leaseutil.WithLease(ctx, co.lm, leaseutil.MakeTemporary)
+	require.NoError(t, err)
+	defer done(context.TODO())
+
+	cm := co.manager
+
+	base := writeFiles(ctx, t, cm, nil, map[string]string{"foo": "foo0", "bar": "bar0"})
+	defer base.Release(ctx)
+	upper := writeFiles(ctx, t, cm, base, map[string]string{"baz": "baz1"}, "bar")
+	defer upper.Release(ctx)
+	other := writeFiles(ctx, t, cm, nil, map[string]string{"bar": "bar2", "x": "x2"})
+	defer other.Release(ctx)
+
+	diff, err := cm.Diff(ctx, base, upper, nil)
+	require.NoError(t, err)
+	defer diff.Release(ctx)
+
+	// the deletions of the diff are applied to the other base
+	merged, err := cm.Merge(ctx, []ImmutableRef{other, diff}, nil)
+	require.NoError(t, err)
+	defer merged.Release(ctx)
+
+	dir, release := refDir(ctx, t, merged)
+	defer release()
+	require.Equal(t, map[string]string{"baz": "baz1", "x": "x2"}, readFiles(t, dir))
+}
+
+func writeFiles(ctx context.Context, t *testing.T, cm Manager, parent ImmutableRef, files map[string]string, remove ...string) ImmutableRef {
+	active, err := cm.New(ctx, parent, nil)
+	require.NoError(t, err)
+	m, err := active.Mount(ctx, false, nil)
+	require.NoError(t, err)
+	lm := snapshot.LocalMounter(m)
+	dir, err := lm.Mount()
+	require.NoError(t, err)
+	for name, data := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0600))
+	}
+	for _, name := range remove {
+		require.NoError(t, os.Remove(filepath.Join(dir, name)))
+	}
+	require.NoError(t, lm.Unmount())
+	snap, err := active.Commit(ctx)
+	require.NoError(t, err)
+	return snap
+}
+
+// refDir returns the directory of a ref of the native snapshotter
+func refDir(ctx context.Context, t *testing.T, ref ImmutableRef) (string, func() error) {
+	m, err := ref.Mount(ctx, true, nil)
+	require.NoError(t, err)
+	mounts, release, err := m.Mount()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(mounts))
+	if release == nil {
+		release = func() error { return nil }
+	}
+	return mounts[0].Source, release
+}
+
+func readFiles(t *testing.T, dir string) map[string]string {
+	fis, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, fi := range fis {
+		dt, err := ioutil.ReadFile(filepath.Join(dir, fi.Name()))
+		require.NoError(t, err)
+		files[fi.Name()] = string(dt)
+	}
+	return files
+}
+
+func blobFileNames(ctx context.Context, t *testing.T, cs content.Store, desc ocispec.Descriptor) []string {
+	ra, err := cs.ReaderAt(ctx, desc)
+	require.NoError(t, err)
+	defer ra.Close()
+
+	gz, err := gzip.NewReader(content.NewReader(ra))
+	require.NoError(t, err)
+	defer gz.Close()
+
+	var names []string
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, h.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func checkDiskUsage(ctx context.Context, t *testing.T, cm Manager, inuse, unused int) {
 	du, err := cm.DiskUsage(ctx, client.DiskUsageInfo{})
 	require.NoError(t, err)
@@ -1031,3 +1553,47 @@ func mapToBlob(m map[string]string) ([]byte, ocispec.Descriptor, error) {
 		},
 	}, nil
 }
+
+func TestLastUsedBuild(t *testing.T) {
+	t.Parallel()
+	ctx := namespaces.WithNamespace(context.Background(), "buildkit-test")
+
+	tmpdir, err := ioutil.TempDir("", "cachemanager")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	snapshotter, err := native.NewSnapshotter(filepath.Join(tmpdir, "snapshots"))
+	require.NoError(t, err)
+
+	co, cleanup, err := newCacheManager(ctx, cmOpt{
+		snapshotter:     snapshotter,
+		snapshotterName: "native",
+	})
+	require.NoError(t, err)
+
+	defer cleanup()
+	cm := co.manager
+
+	active, err := cm.New(ctx, nil, nil, WithBuildRef("build1"), CachePolicyRetain)
+	require.NoError(t, err)
+	id := active.ID()
+
+	// locked refs are not marked as used
+	_, err = cm.Get(ctx, id, WithBuildRef("build2"))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrLocked))
+	require.Equal(t, "build1", GetLastUsedBuild(cm.Metadata(id)))
+
+	require.NoError(t, active.Release(ctx))
+
+	active, err = cm.GetMutable(ctx, id, WithBuildRef("build2"))
+	require.NoError(t, err)
+	require.Equal(t, "build1", GetBuildRef(cm.Metadata(id)))
+	require.Equal(t, "build2", GetLastUsedBuild(cm.Metadata(id)))
+	require.NoError(t, active.Release(ctx))
+
+	snap, err := cm.Get(ctx, id, WithBuildRef("build3"), NoUpdateLastUsed)
+	require.NoError(t, err)
+	require.Equal(t, "build2", GetLastUsedBuild(snap.Metadata()))
+	require.NoError(t, snap.Release(ctx))
+}
@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/moby/buildkit/session"
+	"github.com/pkg/errors"
+)
+
+// Merge returns a ref with the layers of all inputs stacked in order. The
+// layer chain of the first input is used as is. For every layer of the
+// following inputs a new snapshot is created on top of the result so far and
+// the changes of the layer are linked into it, without going through blobs,
+// except for parentless layers with a blob like the result of Diff.
+// Nil inputs are scratch and are skipped. Merge returns nil if all inputs are
+// scratch.
+func (cm *cacheManager) Merge(ctx context.Context, inputs []ImmutableRef, s session.Group, opts ...RefOption) (ir ImmutableRef, rerr error) {
+	var current ImmutableRef
+	defer func() {
+		if rerr != nil && current != nil {
+			current.Release(context.TODO())
+		}
+	}()
+
+	for _, inp := range inputs {
+		if inp == nil {
+			continue
+		}
+		if current == nil {
+			current = inp.Clone()
+			continue
+		}
+
+		sr, ok := inp.(*immutableRef)
+		if !ok {
+			return nil, errors.Errorf("invalid ref for merge %T", inp)
+		}
+		if err := sr.Extract(ctx, s); err != nil {
+			return nil, err
+		}
+		for _, layer := range sr.parentRefChain() {
+			ref, err := cm.applyLayer(ctx, current, layer, s, opts...)
+			current.Release(context.TODO())
+			current = nil
+			if err != nil {
+				return nil, err
+			}
+			current = ref
+		}
+	}
+	return current, nil
+}
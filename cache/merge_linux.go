@@ -0,0 +1,393 @@
+// +build linux
+
+package cache
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/continuity/fs"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/snapshot"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// applyLayer returns a ref on top of parent with the changes that layer made
+// to its own parent. Files are hardlinked from the snapshot of the layer if
+// both snapshots are on the same filesystem and copied otherwise. A layer
+// without parent that has a blob, like the result of Diff, lost the deletions
+// of the blob when it was unpacked onto scratch, so its blob is applied
+// instead.
+func (cm *cacheManager) applyLayer(ctx context.Context, parent ImmutableRef, layer *immutableRef, s session.Group, opts ...RefOption) (_ ImmutableRef, rerr error) {
+	mref, err := cm.New(ctx, parent, s, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rerr != nil {
+			mref.Release(context.TODO())
+		}
+	}()
+
+	dst, err := mref.Mount(ctx, false, s)
+	if err != nil {
+		return nil, err
+	}
+	dstMounts, releaseDst, err := dst.Mount()
+	if err != nil {
+		return nil, err
+	}
+	if releaseDst != nil {
+		defer releaseDst()
+	}
+
+	if layer.parent == nil && getBlob(layer.md) != "" {
+		if err := cm.applyBlob(ctx, layer, dstMounts, s); err != nil {
+			return nil, errors.Wrapf(err, "failed to merge layer %s", layer.ID())
+		}
+		return mref.Commit(ctx)
+	}
+
+	src, err := layer.Mount(ctx, true, s)
+	if err != nil {
+		return nil, err
+	}
+	srcMounts, releaseSrc, err := src.Mount()
+	if err != nil {
+		return nil, err
+	}
+	if releaseSrc != nil {
+		defer releaseSrc()
+	}
+
+	// overlay snapshots store the changes of a layer in their own directory,
+	// which is linked into the upper directory of the new snapshot as is
+	if upper, ok := overlayUpperdir(dstMounts); ok {
+		diffDir, ok := overlayDiffDir(srcMounts)
+		if !ok {
+			return nil, errors.Errorf("invalid mounts for merging overlay layer %s", layer.ID())
+		}
+		if err := linkTree(ctx, diffDir, upper); err != nil {
+			return nil, errors.Wrapf(err, "failed to merge layer %s", layer.ID())
+		}
+		return mref.Commit(ctx)
+	}
+
+	// otherwise the changes are computed by comparing the layer with its
+	// parent
+	dstDir, unmountDst, err := localDir(dstMounts)
+	if err != nil {
+		return nil, err
+	}
+	defer unmountDst()
+
+	srcDir, unmountSrc, err := localDir(srcMounts)
+	if err != nil {
+		return nil, err
+	}
+	defer unmountSrc()
+
+	var lowerDir string
+	if layer.parent != nil {
+		lower, err := layer.parent.Mount(ctx, true, s)
+		if err != nil {
+			return nil, err
+		}
+		lowerMounts, releaseLower, err := lower.Mount()
+		if err != nil {
+			return nil, err
+		}
+		if releaseLower != nil {
+			defer releaseLower()
+		}
+		var unmountLower func() error
+		lowerDir, unmountLower, err = localDir(lowerMounts)
+		if err != nil {
+			return nil, err
+		}
+		defer unmountLower()
+	}
+
+	if err := applyChanges(ctx, lowerDir, srcDir, dstDir); err != nil {
+		return nil, errors.Wrapf(err, "failed to merge layer %s", layer.ID())
+	}
+	return mref.Commit(ctx)
+}
+
+// applyBlob unpacks the blob of the layer, including its whiteouts, onto the
+// mounts.
+func (cm *cacheManager) applyBlob(ctx context.Context, layer *immutableRef, mounts []mount.Mount, s session.Group) error {
+	if cm.Applier == nil {
+		return errors.New("merge requires an applier")
+	}
+	desc, err := layer.ociDesc()
+	if err != nil {
+		return err
+	}
+	// unlazies if needed, otherwise a no-op
+	if err := (lazyRefProvider{
+		ref:     layer,
+		desc:    desc,
+		dh:      layer.descHandlers[desc.Digest],
+		session: s,
+	}).Unlazy(ctx); err != nil {
+		return err
+	}
+	_, err = cm.Applier.Apply(ctx, desc, mounts)
+	return err
+}
+
+func overlayUpperdir(mounts []mount.Mount) (string, bool) {
+	if len(mounts) != 1 || mounts[0].Type != "overlay" {
+		return "", false
+	}
+	for _, o := range mounts[0].Options {
+		if strings.HasPrefix(o, "upperdir=") {
+			return strings.TrimPrefix(o, "upperdir="), true
+		}
+	}
+	return "", false
+}
+
+// overlayDiffDir returns the directory of the topmost layer of read-only
+// overlay snapshot mounts. A snapshot without parents is a bind mount of its
+// directory.
+func overlayDiffDir(mounts []mount.Mount) (string, bool) {
+	if len(mounts) != 1 {
+		return "", false
+	}
+	switch mounts[0].Type {
+	case "bind", "rbind":
+		return mounts[0].Source, true
+	case "overlay":
+		for _, o := range mounts[0].Options {
+			if strings.HasPrefix(o, "lowerdir=") {
+				return strings.SplitN(strings.TrimPrefix(o, "lowerdir="), ":", 2)[0], true
+			}
+		}
+	}
+	return "", false
+}
+
+// localDir returns the directory of the mounts. Bind mounts are accessed
+// directly so files can be hardlinked, other mounts are mounted to a temporary
+// directory.
+func localDir(mounts []mount.Mount) (string, func() error, error) {
+	if len(mounts) == 1 && (mounts[0].Type == "bind" || mounts[0].Type == "rbind") {
+		return mounts[0].Source, func() error { return nil }, nil
+	}
+	lm := snapshot.LocalMounterWithMounts(mounts)
+	dir, err := lm.Mount()
+	if err != nil {
+		return "", nil, err
+	}
+	return dir, lm.Unmount, nil
+}
+
+// linkTree links all files of the src directory into the dst directory.
+// Directories are recreated with the metadata of the source.
+func linkTree(ctx context.Context, src, dst string) error {
+	var dirs []string
+	if err := filepath.Walk(src, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		target := filepath.Join(dst, rel)
+		if fi.IsDir() {
+			dirs = append(dirs, rel)
+			return mkdirAs(p, target, fi)
+		}
+		return linkOrCopy(p, target, fi)
+	}); err != nil {
+		return err
+	}
+	return setDirTimes(src, dst, dirs)
+}
+
+// applyChanges applies the changes from lower to upper to the dst directory.
+func applyChanges(ctx context.Context, lower, upper, dst string) error {
+	var dirs []string
+	if err := fs.Changes(ctx, lower, upper, func(k fs.ChangeKind, p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimPrefix(p, string(os.PathSeparator))
+		if rel == "" {
+			return nil
+		}
+		target := filepath.Join(dst, rel)
+		if k == fs.ChangeKindDelete {
+			return os.RemoveAll(target)
+		}
+		source := filepath.Join(upper, rel)
+		if fi.IsDir() {
+			if st, err := os.Lstat(target); err == nil && !st.IsDir() {
+				if err := os.Remove(target); err != nil {
+					return err
+				}
+			}
+			dirs = append(dirs, rel)
+			return mkdirAs(source, target, fi)
+		}
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		return linkOrCopy(source, target, fi)
+	}); err != nil {
+		return err
+	}
+	return setDirTimes(upper, dst, dirs)
+}
+
+// mkdirAs creates the target directory if it doesn't exist and sets the
+// owner, permissions and extended attributes of the source on it.
+func mkdirAs(source, target string, fi os.FileInfo) error {
+	if err := os.Mkdir(target, fi.Mode().Perm()); err != nil && !os.IsExist(err) {
+		return err
+	}
+	return copyMetadata(source, target, fi)
+}
+
+func linkOrCopy(source, target string, fi os.FileInfo) error {
+	if err := os.Link(source, target); err == nil {
+		return nil
+	}
+	switch {
+	case fi.Mode()&os.ModeSymlink != 0:
+		link, err := os.Readlink(source)
+		if err != nil {
+			return err
+		}
+		if err := os.Symlink(link, target); err != nil {
+			return err
+		}
+	case fi.Mode().IsRegular():
+		if err := copyFileContent(source, target, fi); err != nil {
+			return err
+		}
+	default:
+		st, ok := fi.Sys().(*syscall.Stat_t)
+		if !ok {
+			return errors.Errorf("unsupported file %s", source)
+		}
+		if err := unix.Mknod(target, uint32(st.Mode), int(st.Rdev)); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if err := copyMetadata(source, target, fi); err != nil {
+		return err
+	}
+	return setTimes(target, fi)
+}
+
+func copyFileContent(source, target string, fi os.FileInfo) error {
+	sf, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer sf.Close()
+	tf, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tf, sf); err != nil {
+		tf.Close()
+		return errors.WithStack(err)
+	}
+	return tf.Close()
+}
+
+func copyMetadata(source, target string, fi os.FileInfo) error {
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		if err := os.Lchown(target, int(st.Uid), int(st.Gid)); err != nil {
+			return err
+		}
+	}
+	if fi.Mode()&os.ModeSymlink == 0 {
+		if err := os.Chmod(target, fi.Mode()); err != nil {
+			return err
+		}
+	}
+	return copyXAttrs(source, target)
+}
+
+func copyXAttrs(source, target string) error {
+	sz, err := unix.Llistxattr(source, nil)
+	if err != nil {
+		if err == unix.ENOTSUP || err == unix.EOPNOTSUPP {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to list xattrs of %s", source)
+	}
+	if sz == 0 {
+		return nil
+	}
+	buf := make([]byte, sz)
+	sz, err = unix.Llistxattr(source, buf)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list xattrs of %s", source)
+	}
+	for _, name := range strings.Split(strings.TrimSuffix(string(buf[:sz]), "\x00"), "\x00") {
+		if name == "" {
+			continue
+		}
+		vsz, err := unix.Lgetxattr(source, name, nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get xattr %s of %s", name, source)
+		}
+		val := make([]byte, vsz)
+		if vsz, err = unix.Lgetxattr(source, name, val); err != nil {
+			return errors.Wrapf(err, "failed to get xattr %s of %s", name, source)
+		}
+		if err := unix.Lsetxattr(target, name, val[:vsz], 0); err != nil {
+			return errors.Wrapf(err, "failed to set xattr %s of %s", name, target)
+		}
+	}
+	return nil
+}
+
+func setTimes(target string, fi os.FileInfo) error {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	ts := []unix.Timespec{
+		unix.NsecToTimespec(syscall.TimespecToNsec(st.Atim)),
+		unix.NsecToTimespec(syscall.TimespecToNsec(st.Mtim)),
+	}
+	if err := unix.UtimesNanoAt(unix.AT_FDCWD, target, ts, unix.AT_SYMLINK_NOFOLLOW); err != nil {
+		return errors.Wrapf(err, "failed to set times of %s", target)
+	}
+	return nil
+}
+
+// setDirTimes sets the times of the directories after their content has been
+// written, deepest first.
+func setDirTimes(src, dst string, dirs []string) error {
+	for i := len(dirs) - 1; i >= 0; i-- {
+		fi, err := os.Lstat(filepath.Join(src, dirs[i]))
+		if err != nil {
+			return err
+		}
+		if err := setTimes(filepath.Join(dst, dirs[i]), fi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+// +build !linux
+
+package cache
+
+import (
+	"context"
+
+	"github.com/moby/buildkit/session"
+	"github.com/pkg/errors"
+)
+
+func (cm *cacheManager) applyLayer(ctx context.Context, parent ImmutableRef, layer *immutableRef, s session.Group, opts ...RefOption) (ImmutableRef, error) {
+	return nil, errors.New("merging layers is only supported on linux")
+}
@@ -29,6 +29,11 @@ const keySnapshot = "cache.snapshot"
 const keyBlobOnly = "cache.blobonly"
 const keyMediaType = "cache.mediatype"
 const keyImageRefs = "cache.imageRefs"
+const keyCacheMountID = "cache.cacheMountID"
+const keySourceOp = "cache.sourceOp"
+const keyBuildRef = "cache.buildRef"
+const keyLastUsedBuild = "cache.lastUsedBuild"
+const keyFrontend = "cache.frontend"
 
 // BlobSize is the packed blob size as specified in the oci descriptor
 const keyBlobSize = "cache.blobsize"
@@ -444,6 +449,62 @@ func GetDescription(si *metadata.StorageItem) string {
 	return str
 }
 
+func queueStringValue(si *metadata.StorageItem, key, str string) error {
+	if str == "" {
+		return nil
+	}
+	v, err := metadata.NewValue(str)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s value", key)
+	}
+	si.Queue(func(b *bolt.Bucket) error {
+		return si.SetValue(b, key, v)
+	})
+	return nil
+}
+
+func getStringValue(si *metadata.StorageItem, key string) string {
+	v := si.Get(key)
+	if v == nil {
+		return ""
+	}
+	var str string
+	if err := v.Unmarshal(&str); err != nil {
+		return ""
+	}
+	return str
+}
+
+func GetCacheMountID(si *metadata.StorageItem) string {
+	return getStringValue(si, keyCacheMountID)
+}
+
+func GetSourceOp(si *metadata.StorageItem) string {
+	return getStringValue(si, keySourceOp)
+}
+
+func GetBuildRef(si *metadata.StorageItem) string {
+	return getStringValue(si, keyBuildRef)
+}
+
+func GetLastUsedBuild(si *metadata.StorageItem) string {
+	return getStringValue(si, keyLastUsedBuild)
+}
+
+func GetFrontend(si *metadata.StorageItem) string {
+	return getStringValue(si, keyFrontend)
+}
+
+func setLastUsedBuild(si *metadata.StorageItem, ref string) error {
+	if ref == "" || GetLastUsedBuild(si) == ref {
+		return nil
+	}
+	if err := queueStringValue(si, keyLastUsedBuild, ref); err != nil {
+		return err
+	}
+	return si.Commit()
+}
+
 func queueCreatedAt(si *metadata.StorageItem, tm time.Time) error {
 	v, err := metadata.NewValue(tm.UnixNano())
 	if err != nil {
@@ -694,6 +694,11 @@ func (sr *mutableRef) commit(ctx context.Context) (*immutableRef, error) {
 			return nil, err
 		}
 	}
+	for _, k := range []string{keyCacheMountID, keySourceOp, keyBuildRef, keyLastUsedBuild, keyFrontend} {
+		if err := queueStringValue(md, k, getStringValue(sr.md, k)); err != nil {
+			return nil, err
+		}
+	}
 
 	parentID := ""
 	if rec.parent != nil {
@@ -42,6 +42,9 @@ func oneOffProgress(ctx context.Context, id string) func(err error) error {
 
 type Exporter interface {
 	solver.CacheExporterTarget
+	// AddCacheMount adds the content of a cache mount with the given ID to
+	// the exported cache. The remote must contain a single tar blob.
+	AddCacheMount(id string, remote *solver.Remote) error
 	// Finalize finalizes and return metadata that are returned to the client
 	// e.g. ExporterResponseManifestDesc
 	Finalize(ctx context.Context) (map[string]string, error)
@@ -65,6 +68,14 @@ func NewExporter(ingester content.Ingester, oci bool) Exporter {
 	return &contentCacheExporter{CacheExporterTarget: cc, chains: cc, ingester: ingester, oci: oci}
 }
 
+func (ce *contentCacheExporter) AddCacheMount(id string, remote *solver.Remote) error {
+	if len(remote.Descriptors) != 1 {
+		return errors.Errorf("invalid cache mount %s with %d blobs", id, len(remote.Descriptors))
+	}
+	ce.chains.AddCacheMount(id, remote.Descriptors[0], remote.Provider)
+	return nil
+}
+
 func (ce *contentCacheExporter) Finalize(ctx context.Context) (map[string]string, error) {
 	res := make(map[string]string)
 	config, descs, err := ce.chains.Marshal()
@@ -102,6 +113,19 @@ func (ce *contentCacheExporter) Finalize(ctx context.Context) (map[string]string
 		mfst.Manifests = append(mfst.Manifests, dgstPair.Descriptor)
 	}
 
+	for _, cm := range config.CacheMounts {
+		dgstPair, ok := descs[cm.Blob]
+		if !ok {
+			return nil, errors.Errorf("missing blob %s for cache mount %s", cm.Blob, cm.ID)
+		}
+		cmDone := oneOffProgress(ctx, fmt.Sprintf("writing cache mount %s", cm.ID))
+		if err := contentutil.Copy(ctx, ce.ingester, dgstPair.Provider, dgstPair.Descriptor, logs.LoggerFromContext(ctx)); err != nil {
+			return nil, cmDone(errors.Wrap(err, "error writing cache mount blob"))
+		}
+		cmDone(nil)
+		mfst.Manifests = append(mfst.Manifests, dgstPair.Descriptor)
+	}
+
 	mfst.Manifests = compression.ConvertAllLayerMediaTypes(ce.oci, mfst.Manifests...)
 
 	dt, err := json.Marshal(config)
@@ -0,0 +1,157 @@
+// Package httpcas implements a remote cache backend for generic HTTP
+// content-addressable stores using the Bazel remote cache protocol.
+//
+// Cache blobs and the cache config are written with PUT to /cas/<digest>.
+// The descriptor of the cache manifest is stored in the action cache at
+// /ac/<key> where key is the sha256 of the configured cache name.
+package httpcas
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/moby/buildkit/cache/remotecache"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/auth"
+	"github.com/moby/buildkit/session/secrets"
+	"github.com/moby/buildkit/util/contentutil"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	attrURL           = "url"
+	attrName          = "name"
+	attrAuthSecret    = "auth-secret"
+	attrOCIMediatypes = "oci-mediatypes"
+
+	defaultName = "buildkit"
+)
+
+// ResolveCacheExporterFunc for "http" cache exporter.
+func ResolveCacheExporterFunc(sm *session.Manager) remotecache.ResolveCacheExporterFunc {
+	return func(ctx context.Context, g session.Group, attrs map[string]string) (remotecache.Exporter, error) {
+		s, err := storeFromAttrs(ctx, sm, g, attrs)
+		if err != nil {
+			return nil, err
+		}
+		ociMediatypes := true
+		if v, ok := attrs[attrOCIMediatypes]; ok {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse %s", attrOCIMediatypes)
+			}
+			ociMediatypes = b
+		}
+		return &exporter{
+			Exporter: remotecache.NewExporter(contentutil.FromPusher(s), ociMediatypes),
+			store:    s,
+			name:     cacheName(attrs),
+		}, nil
+	}
+}
+
+// ResolveCacheImporterFunc for "http" cache importer.
+func ResolveCacheImporterFunc(sm *session.Manager) remotecache.ResolveCacheImporterFunc {
+	return func(ctx context.Context, g session.Group, attrs map[string]string) (remotecache.Importer, ocispec.Descriptor, error) {
+		s, err := storeFromAttrs(ctx, sm, g, attrs)
+		if err != nil {
+			return nil, ocispec.Descriptor{}, err
+		}
+		desc, err := s.getDescriptor(ctx, cacheName(attrs))
+		if err != nil {
+			return nil, ocispec.Descriptor{}, err
+		}
+		return remotecache.NewImporter(contentutil.FromFetcher(s)), desc, nil
+	}
+}
+
+type exporter struct {
+	remotecache.Exporter
+	store *store
+	name  string
+}
+
+func (e *exporter) Finalize(ctx context.Context) (map[string]string, error) {
+	res, err := e.Exporter.Finalize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var desc ocispec.Descriptor
+	if err := json.Unmarshal([]byte(res[remotecache.ExporterResponseManifestDesc]), &desc); err != nil {
+		return nil, errors.Wrap(err, "failed to parse cache manifest descriptor")
+	}
+	if err := e.store.putDescriptor(ctx, e.name, desc); err != nil {
+		return nil, errors.Wrap(err, "error writing cache manifest reference")
+	}
+	return res, nil
+}
+
+func cacheName(attrs map[string]string) string {
+	if v := attrs[attrName]; v != "" {
+		return v
+	}
+	return defaultName
+}
+
+func storeFromAttrs(ctx context.Context, sm *session.Manager, g session.Group, attrs map[string]string) (*store, error) {
+	rawURL := attrs[attrURL]
+	if rawURL == "" {
+		return nil, errors.New("http cache exporter/importer requires url")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid url %s", rawURL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("unsupported url scheme %q for http cache", u.Scheme)
+	}
+	header, err := authHeader(ctx, sm, g, u.Host, attrs[attrAuthSecret])
+	if err != nil {
+		return nil, err
+	}
+	return newStore(nil, rawURL, header), nil
+}
+
+// authHeader returns the headers used to authenticate with the cache server.
+// If a secret ID is set the secret is sent as a bearer token, otherwise the
+// credentials for the host are requested from the client session.
+func authHeader(ctx context.Context, sm *session.Manager, g session.Group, host, secretID string) (http.Header, error) {
+	header := http.Header{}
+	if sm == nil || len(session.AllSessionIDs(g)) == 0 {
+		if secretID != "" {
+			return nil, errors.Errorf("http cache secret %s requires session", secretID)
+		}
+		return header, nil
+	}
+
+	if secretID != "" {
+		var dt []byte
+		err := sm.Any(ctx, g, func(ctx context.Context, _ string, c session.Caller) error {
+			var err error
+			dt, err = secrets.GetSecret(ctx, c, secretID)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		header.Set("Authorization", "Bearer "+string(dt))
+		return header, nil
+	}
+
+	_, username, secret, err := auth.CredentialsFunc(sm, g)(host)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case username != "" && secret != "":
+		req := &http.Request{Header: header}
+		req.SetBasicAuth(username, secret)
+	case secret != "":
+		header.Set("Authorization", "Bearer "+secret)
+	}
+	return header, nil
+}
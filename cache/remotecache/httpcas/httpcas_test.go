@@ -0,0 +1,186 @@
+package httpcas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/moby/buildkit/cache/remotecache"
+	v1 "github.com/moby/buildkit/cache/remotecache/v1"
+	"github.com/moby/buildkit/util/contentutil"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+type testServer struct {
+	mu     sync.Mutex
+	blobs  map[string][]byte
+	auth   string
+	failed []string
+}
+
+func newTestServer(auth string) (*testServer, *httptest.Server) {
+	ts := &testServer{blobs: map[string][]byte{}, auth: auth}
+	return ts, httptest.NewServer(ts)
+}
+
+func (ts *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.auth != "" && r.Header.Get("Authorization") != ts.auth {
+		ts.failed = append(ts.failed, r.URL.Path)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/cas/") && !strings.HasPrefix(r.URL.Path, "/ac/") {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		dt, ok := ts.blobs[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			w.Write(dt)
+		}
+	case http.MethodPut:
+		dt, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/cas/") && "/cas/"+digest.FromBytes(dt).Hex() != r.URL.Path {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ts.blobs[r.URL.Path] = dt
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	ts, srv := newTestServer("Bearer foo")
+	defer srv.Close()
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer foo")
+	s := newStore(srv.Client(), srv.URL+"/", header)
+
+	ctx := context.TODO()
+	dt := []byte("layer data")
+	desc := ocispec.Descriptor{
+		Digest: digest.FromBytes(dt),
+		Size:   int64(len(dt)),
+	}
+
+	_, err := content.ReadBlob(ctx, contentutil.FromFetcher(s), desc)
+	require.Error(t, err)
+	require.True(t, errdefs.IsNotFound(err))
+
+	ingester := contentutil.FromPusher(s)
+	err = content.WriteBlob(ctx, ingester, desc.Digest.String(), bytes.NewReader(dt), desc)
+	require.NoError(t, err)
+	require.Equal(t, dt, ts.blobs["/cas/"+desc.Digest.Hex()])
+
+	// writing the same blob again is a no-op
+	err = content.WriteBlob(ctx, ingester, desc.Digest.String(), bytes.NewReader(dt), desc)
+	require.NoError(t, err)
+
+	out, err := content.ReadBlob(ctx, contentutil.FromFetcher(s), desc)
+	require.NoError(t, err)
+	require.Equal(t, dt, out)
+
+	require.Equal(t, 0, len(ts.failed))
+}
+
+func TestStoreDigestMismatch(t *testing.T) {
+	ts, srv := newTestServer("")
+	defer srv.Close()
+
+	s := newStore(srv.Client(), srv.URL, nil)
+
+	desc := ocispec.Descriptor{
+		Digest: digest.FromBytes([]byte("foo")),
+		Size:   3,
+	}
+	err := content.WriteBlob(context.TODO(), contentutil.FromPusher(s), desc.Digest.String(), bytes.NewReader([]byte("bar")), desc)
+	require.Error(t, err)
+	require.Equal(t, 0, len(ts.blobs))
+}
+
+func TestStoreUnauthorized(t *testing.T) {
+	ts, srv := newTestServer("Bearer foo")
+	defer srv.Close()
+
+	s := newStore(srv.Client(), srv.URL, nil)
+
+	_, err := s.getDescriptor(context.TODO(), "buildkit")
+	require.Error(t, err)
+	require.False(t, errdefs.IsNotFound(err))
+	require.Contains(t, err.Error(), "401")
+	require.Equal(t, 1, len(ts.failed))
+}
+
+func TestExportImport(t *testing.T) {
+	ts, srv := newTestServer("")
+	defer srv.Close()
+
+	ctx := context.TODO()
+	attrs := map[string]string{
+		attrURL:  srv.URL,
+		attrName: "myproject",
+	}
+
+	_, _, err := ResolveCacheImporterFunc(nil)(ctx, nil, attrs)
+	require.Error(t, err)
+	require.True(t, errdefs.IsNotFound(err))
+
+	e, err := ResolveCacheExporterFunc(nil)(ctx, nil, attrs)
+	require.NoError(t, err)
+
+	res, err := e.Finalize(ctx)
+	require.NoError(t, err)
+
+	var exported ocispec.Descriptor
+	err = json.Unmarshal([]byte(res[remotecache.ExporterResponseManifestDesc]), &exported)
+	require.NoError(t, err)
+	require.Equal(t, ocispec.MediaTypeImageIndex, exported.MediaType)
+
+	_, ok := ts.blobs["/ac/"+digest.FromString("myproject").Hex()]
+	require.True(t, ok)
+
+	_, desc, err := ResolveCacheImporterFunc(nil)(ctx, nil, attrs)
+	require.NoError(t, err)
+	require.Equal(t, exported.Digest, desc.Digest)
+	require.Equal(t, exported.Size, desc.Size)
+
+	dt, err := content.ReadBlob(ctx, contentutil.FromFetcher(newStore(srv.Client(), srv.URL, nil)), desc)
+	require.NoError(t, err)
+
+	var idx ocispec.Index
+	err = json.Unmarshal(dt, &idx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(idx.Manifests))
+	require.Equal(t, v1.CacheConfigMediaTypeV0, idx.Manifests[0].MediaType)
+}
+
+func TestInvalidURL(t *testing.T) {
+	_, err := ResolveCacheExporterFunc(nil)(context.TODO(), nil, map[string]string{})
+	require.Error(t, err)
+
+	_, err = ResolveCacheExporterFunc(nil)(context.TODO(), nil, map[string]string{attrURL: "ftp://example.com"})
+	require.Error(t, err)
+}
@@ -0,0 +1,272 @@
+package httpcas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// maxActionResultSize limits the size of a descriptor read from the action
+// cache. Entries written by buildkit are a few hundred bytes.
+const maxActionResultSize = 1 << 16
+
+// store is a client for an HTTP content-addressable store speaking the Bazel
+// remote cache protocol: blobs are addressed as /cas/<sha256 hex> and named
+// entries as /ac/<sha256 hex>.
+type store struct {
+	client  *http.Client
+	baseURL string
+	header  http.Header
+}
+
+var _ remotes.Fetcher = &store{}
+var _ remotes.Pusher = &store{}
+
+func newStore(client *http.Client, baseURL string, header http.Header) *store {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if header == nil {
+		header = http.Header{}
+	}
+	return &store{
+		client:  client,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		header:  header,
+	}
+}
+
+func (s *store) casURL(dgst digest.Digest) (string, error) {
+	if err := dgst.Validate(); err != nil {
+		return "", err
+	}
+	if dgst.Algorithm() != digest.SHA256 {
+		return "", errors.Errorf("unsupported digest algorithm %s for %s", dgst.Algorithm(), dgst)
+	}
+	return s.baseURL + "/cas/" + dgst.Hex(), nil
+}
+
+func (s *store) acURL(key string) string {
+	return s.baseURL + "/ac/" + digest.FromString(key).Hex()
+}
+
+func (s *store) newRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	for k, v := range s.header {
+		req.Header[k] = v
+	}
+	return req, nil
+}
+
+func (s *store) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	u, err := s.casURL(desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := checkResponse(resp, desc.Digest.String()); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *store) Push(ctx context.Context, desc ocispec.Descriptor) (content.Writer, error) {
+	u, err := s.casURL(desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodHead, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil, errors.Wrapf(errdefs.ErrAlreadyExists, "blob %s", desc.Digest)
+	}
+
+	pr, pw := io.Pipe()
+	req, err = s.newRequest(ctx, http.MethodPut, u, pr)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = desc.Size
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	respC := make(chan error, 1)
+	go func() {
+		resp, err := s.client.Do(req)
+		if err != nil {
+			pr.CloseWithError(err)
+			respC <- errors.WithStack(err)
+			return
+		}
+		defer resp.Body.Close()
+		respC <- checkResponse(resp, desc.Digest.String())
+	}()
+
+	now := time.Now()
+	return &pushWriter{
+		pw:        pw,
+		respC:     respC,
+		desc:      desc,
+		digester:  digest.Canonical.Digester(),
+		startedAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+func (s *store) getDescriptor(ctx context.Context, key string) (ocispec.Descriptor, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, s.acURL(key), nil)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return ocispec.Descriptor{}, errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp, key); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	dt, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxActionResultSize+1))
+	if err != nil {
+		return ocispec.Descriptor{}, errors.WithStack(err)
+	}
+	if len(dt) > maxActionResultSize {
+		return ocispec.Descriptor{}, errors.Errorf("cache entry %s is too large", key)
+	}
+	var desc ocispec.Descriptor
+	if err := json.Unmarshal(dt, &desc); err != nil {
+		return ocispec.Descriptor{}, errors.Wrapf(err, "failed to parse cache entry %s", key)
+	}
+	if err := desc.Digest.Validate(); err != nil {
+		return ocispec.Descriptor{}, errors.Wrapf(err, "invalid cache entry %s", key)
+	}
+	return desc, nil
+}
+
+func (s *store) putDescriptor(ctx context.Context, key string, desc ocispec.Descriptor) error {
+	dt, err := json.Marshal(desc)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req, err := s.newRequest(ctx, http.MethodPut, s.acURL(key), bytes.NewReader(dt))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp, key)
+}
+
+func checkResponse(resp *http.Response, name string) error {
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound:
+		return errors.Wrapf(errdefs.ErrNotFound, "%s not found in http cache", name)
+	default:
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return errors.Errorf("unexpected status from http cache for %s: %s %s", name, resp.Status, strings.TrimSpace(string(msg)))
+	}
+}
+
+type pushWriter struct {
+	pw       *io.PipeWriter
+	respC    chan error
+	desc     ocispec.Descriptor
+	digester digest.Digester
+	offset   int64
+
+	startedAt time.Time
+	updatedAt time.Time
+}
+
+func (w *pushWriter) Write(p []byte) (int, error) {
+	n, err := w.pw.Write(p)
+	w.digester.Hash().Write(p[:n])
+	w.offset += int64(n)
+	w.updatedAt = time.Now()
+	return n, err
+}
+
+func (w *pushWriter) Close() error {
+	return w.pw.CloseWithError(errors.New("http cache writer closed"))
+}
+
+func (w *pushWriter) Digest() digest.Digest {
+	return w.digester.Digest()
+}
+
+func (w *pushWriter) Commit(ctx context.Context, size int64, expected digest.Digest, opts ...content.Opt) error {
+	if size > 0 && size != w.offset {
+		err := errors.Errorf("unexpected size %d, expected %d", w.offset, size)
+		w.pw.CloseWithError(err)
+		return err
+	}
+	if expected != "" && expected != w.Digest() {
+		err := errors.Errorf("unexpected digest %s, expected %s", w.Digest(), expected)
+		w.pw.CloseWithError(err)
+		return err
+	}
+	if err := w.pw.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	select {
+	case err := <-w.respC:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *pushWriter) Status() (content.Status, error) {
+	return content.Status{
+		Ref:       fmt.Sprintf("httpcas-%s", w.desc.Digest),
+		Offset:    w.offset,
+		Total:     w.desc.Size,
+		StartedAt: w.startedAt,
+		UpdatedAt: w.updatedAt,
+	}, nil
+}
+
+func (w *pushWriter) Truncate(size int64) error {
+	if size != 0 || w.offset != 0 {
+		return errors.Wrap(errdefs.ErrNotImplemented, "cannot truncate http cache upload")
+	}
+	return nil
+}
@@ -85,11 +85,30 @@ func (ci *contentCacheImporter) Resolve(ctx context.Context, desc ocispec.Descri
 		return nil, err
 	}
 
+	var config v1.CacheConfig
+	if err := json.Unmarshal(dt, &config); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	cc := v1.NewCacheChains()
-	if err := v1.Parse(dt, allLayers, cc); err != nil {
+	if err := v1.ParseConfig(config, allLayers, cc); err != nil {
 		return nil, err
 	}
 
+	for _, cm := range config.CacheMounts {
+		l, ok := allLayers[cm.Blob]
+		if !ok {
+			logrus.Warnf("missing blob %s for cache mount %s", cm.Blob, cm.ID)
+			continue
+		}
+		if err := w.ImportCacheMount(ctx, cm.ID, &solver.Remote{
+			Descriptors: []ocispec.Descriptor{l.Descriptor},
+			Provider:    l.Provider,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
 	keysStorage, resultStorage, err := v1.NewCacheKeyStorage(cc, w)
 	if err != nil {
 		return nil, err
@@ -9,6 +9,7 @@ import (
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/solver"
 	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -28,6 +29,10 @@ type exporter struct {
 	chains *v1.CacheChains
 }
 
+func (ce *exporter) AddCacheMount(id string, remote *solver.Remote) error {
+	return errors.Errorf("inline cache exporter does not support exporting cache mounts")
+}
+
 func (ce *exporter) Finalize(ctx context.Context) (map[string]string, error) {
 	return nil, nil
 }
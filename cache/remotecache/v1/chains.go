@@ -15,8 +15,14 @@ func NewCacheChains() *CacheChains {
 }
 
 type CacheChains struct {
-	items   []*item
-	visited map[interface{}]struct{}
+	items       []*item
+	visited     map[interface{}]struct{}
+	cacheMounts []cacheMount
+}
+
+type cacheMount struct {
+	id string
+	DescriptorProviderPair
 }
 
 func (c *CacheChains) Add(dgst digest.Digest) solver.CacheExporterRecord {
@@ -28,6 +34,17 @@ func (c *CacheChains) Add(dgst digest.Digest) solver.CacheExporterRecord {
 	return it
 }
 
+// AddCacheMount adds the content of a cache mount as a single tar blob.
+func (c *CacheChains) AddCacheMount(id string, desc ocispec.Descriptor, provider content.Provider) {
+	c.cacheMounts = append(c.cacheMounts, cacheMount{
+		id: id,
+		DescriptorProviderPair: DescriptorProviderPair{
+			Descriptor: desc,
+			Provider:   provider,
+		},
+	})
+}
+
 func (c *CacheChains) Visit(v interface{}) {
 	c.visited[v] = struct{}{}
 }
@@ -93,6 +110,14 @@ func (c *CacheChains) Marshal() (*CacheConfig, DescriptorProvider, error) {
 	}
 	sortConfig(&cc)
 
+	for _, cm := range c.cacheMounts {
+		st.descriptors[cm.Descriptor.Digest] = cm.DescriptorProviderPair
+		cc.CacheMounts = append(cc.CacheMounts, CacheMount{
+			ID:   cm.id,
+			Blob: cm.Descriptor.Digest,
+		})
+	}
+
 	return &cc, st.descriptors, nil
 }
 
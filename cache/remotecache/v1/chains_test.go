@@ -91,6 +91,29 @@ func TestSimpleMarshal(t *testing.T) {
 	require.Equal(t, len(cfg.Records), 4)
 }
 
+func TestMarshalCacheMounts(t *testing.T) {
+	cc := NewCacheChains()
+
+	foo := cc.Add(outputKey(dgst("foo"), 0))
+	foo.AddResult(time.Now(), &solver.Remote{
+		Descriptors: []ocispec.Descriptor{{
+			Digest: dgst("d0"),
+		}},
+	})
+	cc.AddCacheMount("gomod", ocispec.Descriptor{Digest: dgst("gomod-content")}, nil)
+
+	cfg, descPairs, err := cc.Marshal()
+	require.NoError(t, err)
+
+	require.Equal(t, len(cfg.Layers), 1)
+	require.Equal(t, len(cfg.CacheMounts), 1)
+	require.Equal(t, cfg.CacheMounts[0].ID, "gomod")
+	require.Equal(t, cfg.CacheMounts[0].Blob, dgst("gomod-content"))
+
+	_, ok := descPairs[dgst("gomod-content")]
+	require.True(t, ok)
+}
+
 func dgst(s string) digest.Digest {
 	return digest.FromBytes([]byte(s))
 }
@@ -9,8 +9,9 @@ import (
 const CacheConfigMediaTypeV0 = "application/vnd.buildkit.cacheconfig.v0"
 
 type CacheConfig struct {
-	Layers  []CacheLayer  `json:"layers,omitempty"`
-	Records []CacheRecord `json:"records,omitempty"`
+	Layers      []CacheLayer  `json:"layers,omitempty"`
+	Records     []CacheRecord `json:"records,omitempty"`
+	CacheMounts []CacheMount  `json:"cacheMounts,omitempty"`
 }
 
 type CacheLayer struct {
@@ -33,3 +34,9 @@ type CacheInput struct {
 	Selector  string `json:"selector,omitempty"`
 	LinkIndex int    `json:"link"`
 }
+
+// CacheMount is the content of a cache mount stored as a tar blob
+type CacheMount struct {
+	ID   string        `json:"id"`
+	Blob digest.Digest `json:"blob"`
+}
@@ -22,6 +22,18 @@ type UsageInfo struct {
 	Description string
 	RecordType  UsageRecordType
 	Shared      bool
+
+	// CacheMountID is the ID of the cache mount backed by the record.
+	CacheMountID string
+	// SourceOp is the identifier of the source op that created the record,
+	// e.g. "local://context".
+	SourceOp string
+	// BuildRef is the reference of the build that created the record.
+	BuildRef string
+	// LastUsedBuild is the reference of the last build that used the record.
+	LastUsedBuild string
+	// Frontend is the frontend of the build that created the record.
+	Frontend string
 }
 
 func (c *Client) DiskUsage(ctx context.Context, opts ...DiskUsageOption) ([]*UsageInfo, error) {
@@ -40,17 +52,22 @@ func (c *Client) DiskUsage(ctx context.Context, opts ...DiskUsageOption) ([]*Usa
 
 	for _, d := range resp.Record {
 		du = append(du, &UsageInfo{
-			ID:          d.ID,
-			Mutable:     d.Mutable,
-			InUse:       d.InUse,
-			Size:        d.Size_,
-			Parent:      d.Parent,
-			CreatedAt:   d.CreatedAt,
-			Description: d.Description,
-			UsageCount:  int(d.UsageCount),
-			LastUsedAt:  d.LastUsedAt,
-			RecordType:  UsageRecordType(d.RecordType),
-			Shared:      d.Shared,
+			ID:            d.ID,
+			Mutable:       d.Mutable,
+			InUse:         d.InUse,
+			Size:          d.Size_,
+			Parent:        d.Parent,
+			CreatedAt:     d.CreatedAt,
+			Description:   d.Description,
+			UsageCount:    int(d.UsageCount),
+			LastUsedAt:    d.LastUsedAt,
+			RecordType:    UsageRecordType(d.RecordType),
+			Shared:        d.Shared,
+			CacheMountID:  d.CacheMountID,
+			SourceOp:      d.SourceOp,
+			BuildRef:      d.BuildRef,
+			LastUsedBuild: d.LastUsedBuild,
+			Frontend:      d.Frontend,
 		})
 	}
 
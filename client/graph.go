@@ -3,6 +3,7 @@ package client
 import (
 	"time"
 
+	"github.com/moby/buildkit/solver/pb"
 	digest "github.com/opencontainers/go-digest"
 )
 
@@ -34,10 +35,35 @@ type VertexLog struct {
 	Timestamp time.Time
 }
 
+// VertexWarning is a warning reported by a frontend or the solver for a
+// vertex. Level is one of the gateway client WarnLevel values. SourceInfo and
+// Range point to the location in the frontend source that caused the warning.
+type VertexWarning struct {
+	Vertex     digest.Digest
+	Level      int
+	Short      []byte
+	Detail     [][]byte
+	URL        string
+	SourceInfo *pb.SourceInfo
+	Range      []*pb.Range
+}
+
+// VertexResourceUsage is the resource usage of the process run by a vertex.
+type VertexResourceUsage struct {
+	Vertex       digest.Digest
+	CPUTime      time.Duration
+	MemoryPeak   uint64
+	IOReadBytes  uint64
+	IOWriteBytes uint64
+	PidsPeak     uint64
+}
+
 type SolveStatus struct {
-	Vertexes []*Vertex
-	Statuses []*VertexStatus
-	Logs     []*VertexLog
+	Vertexes  []*Vertex
+	Statuses  []*VertexStatus
+	Logs      []*VertexLog
+	Warnings  []*VertexWarning
+	Resources []*VertexResourceUsage
 }
 
 type SolveResponse struct {
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/pkg/errors"
+)
+
+type BuildHistoryEventType int
+
+const (
+	BuildHistoryStarted BuildHistoryEventType = iota
+	BuildHistoryComplete
+	BuildHistoryDeleted
+)
+
+func (t BuildHistoryEventType) String() string {
+	switch t {
+	case BuildHistoryStarted:
+		return "started"
+	case BuildHistoryComplete:
+		return "complete"
+	case BuildHistoryDeleted:
+		return "deleted"
+	}
+	return "unknown"
+}
+
+type BuildHistoryEvent struct {
+	Type   BuildHistoryEventType
+	Record *BuildHistoryRecord
+}
+
+// BuildHistoryRecord describes a build recorded by the daemon.
+type BuildHistoryRecord struct {
+	Ref               string
+	Frontend          string
+	FrontendAttrs     map[string]string
+	Exporter          string
+	ExporterAttrs     map[string]string
+	Error             string
+	CreatedAt         *time.Time
+	CompletedAt       *time.Time
+	ExporterResponse  map[string]string
+	NumTotalSteps     int
+	NumCachedSteps    int
+	NumCompletedSteps int
+	NumWarnings       int
+	// Pinned records are not removed automatically
+	Pinned bool
+}
+
+// ListenBuildHistory sends the build history records matching the options to
+// ch. Unless WithEarlyExit is set it keeps sending events for new builds until
+// ctx is cancelled.
+func (c *Client) ListenBuildHistory(ctx context.Context, ch chan BuildHistoryEvent, opts ...BuildHistoryOption) error {
+	info := &BuildHistoryInfo{}
+	for _, o := range opts {
+		o.SetBuildHistoryOption(info)
+	}
+
+	cl, err := c.controlClient().ListenBuildHistory(ctx, &controlapi.BuildHistoryRequest{
+		ActiveOnly: info.ActiveOnly,
+		Ref:        info.Ref,
+		EarlyExit:  info.EarlyExit,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to listen build history")
+	}
+
+	for {
+		ev, err := cl.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if ch != nil && ev.Record != nil {
+			ch <- BuildHistoryEvent{
+				Type:   BuildHistoryEventType(ev.Type),
+				Record: fromAPIBuildHistoryRecord(ev.Record),
+			}
+		}
+	}
+}
+
+// BuildHistoryStatus sends the recorded status of a build to ch.
+func (c *Client) BuildHistoryStatus(ctx context.Context, ref string, ch chan *SolveStatus) error {
+	defer close(ch)
+	stream, err := c.controlClient().Status(ctx, &controlapi.StatusRequest{
+		Ref: ref,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to get status")
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrap(err, "failed to receive status")
+		}
+		ch <- fromAPIStatusResponse(resp)
+	}
+}
+
+// PinBuildHistory pins or unpins the record of a completed build.
+func (c *Client) PinBuildHistory(ctx context.Context, ref string, pinned bool) error {
+	_, err := c.controlClient().UpdateBuildHistory(ctx, &controlapi.UpdateBuildHistoryRequest{
+		Ref:    ref,
+		Pinned: pinned,
+	})
+	return errors.Wrapf(err, "failed to update build %s", ref)
+}
+
+// DeleteBuildHistory removes the record of a completed build.
+func (c *Client) DeleteBuildHistory(ctx context.Context, ref string) error {
+	_, err := c.controlClient().UpdateBuildHistory(ctx, &controlapi.UpdateBuildHistoryRequest{
+		Ref:    ref,
+		Delete: true,
+	})
+	return errors.Wrapf(err, "failed to delete build %s", ref)
+}
+
+type BuildHistoryOption interface {
+	SetBuildHistoryOption(*BuildHistoryInfo)
+}
+
+type BuildHistoryInfo struct {
+	ActiveOnly bool
+	Ref        string
+	EarlyExit  bool
+}
+
+type buildHistoryOptionFunc func(*BuildHistoryInfo)
+
+func (f buildHistoryOptionFunc) SetBuildHistoryOption(bi *BuildHistoryInfo) {
+	f(bi)
+}
+
+// WithBuildHistoryRef only returns the record of the build with the given ref.
+func WithBuildHistoryRef(ref string) BuildHistoryOption {
+	return buildHistoryOptionFunc(func(bi *BuildHistoryInfo) {
+		bi.Ref = ref
+	})
+}
+
+// WithActiveOnly only returns builds that are still running.
+var WithActiveOnly = buildHistoryOptionFunc(func(bi *BuildHistoryInfo) {
+	bi.ActiveOnly = true
+})
+
+// WithEarlyExit returns after the existing records instead of waiting for
+// new builds.
+var WithEarlyExit = buildHistoryOptionFunc(func(bi *BuildHistoryInfo) {
+	bi.EarlyExit = true
+})
+
+func fromAPIBuildHistoryRecord(r *controlapi.BuildHistoryRecord) *BuildHistoryRecord {
+	return &BuildHistoryRecord{
+		Ref:               r.Ref,
+		Frontend:          r.Frontend,
+		FrontendAttrs:     r.FrontendAttrs,
+		Exporter:          r.Exporter,
+		ExporterAttrs:     r.ExporterAttrs,
+		Error:             r.Error,
+		CreatedAt:         r.CreatedAt,
+		CompletedAt:       r.CompletedAt,
+		ExporterResponse:  r.ExporterResponse,
+		NumTotalSteps:     int(r.NumTotalSteps),
+		NumCachedSteps:    int(r.NumCachedSteps),
+		NumCompletedSteps: int(r.NumCompletedSteps),
+		NumWarnings:       int(r.NumWarnings),
+		Pinned:            r.Pinned,
+	}
+}
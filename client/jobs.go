@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// JobInfo describes a build that is currently running
+type JobInfo struct {
+	Ref       string
+	SessionID string
+	CreatedAt *time.Time
+	Frontend  string
+	// Vertices are the steps of the build that are currently executing
+	Vertices []*RunningVertex
+}
+
+type RunningVertex struct {
+	Digest  digest.Digest
+	Name    string
+	Started *time.Time
+}
+
+// ListJobs lists the builds that are currently running
+func (c *Client) ListJobs(ctx context.Context) ([]*JobInfo, error) {
+	resp, err := c.controlClient().ListJobs(ctx, &controlapi.ListJobsRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list jobs")
+	}
+
+	var out []*JobInfo
+	for _, j := range resp.Jobs {
+		ji := &JobInfo{
+			Ref:       j.Ref,
+			SessionID: j.SessionID,
+			CreatedAt: j.CreatedAt,
+			Frontend:  j.Frontend,
+		}
+		for _, v := range j.Vertices {
+			ji.Vertices = append(ji.Vertices, &RunningVertex{
+				Digest:  v.Digest,
+				Name:    v.Name,
+				Started: v.Started,
+			})
+		}
+		out = append(out, ji)
+	}
+	return out, nil
+}
+
+// CancelJob cancels the running build with the given ref
+func (c *Client) CancelJob(ctx context.Context, ref string) error {
+	_, err := c.controlClient().CancelJob(ctx, &controlapi.CancelJobRequest{
+		Ref: ref,
+	})
+	return errors.Wrapf(err, "failed to cancel job %s", ref)
+}
@@ -0,0 +1,108 @@
+package llb
+
+import (
+	"context"
+
+	"github.com/moby/buildkit/solver/pb"
+	digest "github.com/opencontainers/go-digest"
+)
+
+type DiffOp struct {
+	MarshalCache
+	lower       Output
+	upper       Output
+	output      Output
+	constraints Constraints
+}
+
+func NewDiff(lower, upper State, c Constraints) *DiffOp {
+	op := &DiffOp{
+		lower:       lower.Output(),
+		upper:       upper.Output(),
+		constraints: c,
+	}
+	op.output = &output{vertex: op}
+	return op
+}
+
+func (m *DiffOp) Validate(ctx context.Context) error {
+	return nil
+}
+
+func (m *DiffOp) Marshal(ctx context.Context, constraints *Constraints) (digest.Digest, []byte, *pb.OpMetadata, []*SourceLocation, error) {
+	if m.Cached(constraints) {
+		return m.Load()
+	}
+	if err := m.Validate(ctx); err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	addCap(&m.constraints, pb.CapDiffOp)
+
+	pop, md := MarshalConstraints(constraints, &m.constraints)
+	pop.Platform = nil // diff op is not platform specific
+
+	op := &pb.DiffOp{}
+
+	op.Lower = &pb.LowerDiffInput{Input: pb.Empty}
+	if m.lower != nil {
+		op.Lower.Input = pb.InputIndex(len(pop.Inputs))
+		pbInput, err := m.lower.ToInput(ctx, constraints)
+		if err != nil {
+			return "", nil, nil, nil, err
+		}
+		pop.Inputs = append(pop.Inputs, pbInput)
+	}
+
+	op.Upper = &pb.UpperDiffInput{Input: pb.Empty}
+	if m.upper != nil {
+		op.Upper.Input = pb.InputIndex(len(pop.Inputs))
+		pbInput, err := m.upper.ToInput(ctx, constraints)
+		if err != nil {
+			return "", nil, nil, nil, err
+		}
+		pop.Inputs = append(pop.Inputs, pbInput)
+	}
+
+	pop.Op = &pb.Op_Diff{Diff: op}
+
+	dt, err := pop.Marshal()
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	m.Store(dt, md, m.constraints.SourceLocations, constraints)
+	return m.Load()
+}
+
+func (m *DiffOp) Output() Output {
+	return m.output
+}
+
+func (m *DiffOp) Inputs() (out []Output) {
+	if m.lower != nil {
+		out = append(out, m.lower)
+	}
+	if m.upper != nil {
+		out = append(out, m.upper)
+	}
+	return out
+}
+
+// Diff returns a state containing only the changes from lower to upper,
+// including deletions of files that exist in lower but not in upper. The
+// result can be merged on top of a different base with Merge. If upper is
+// scratch the result is scratch. If lower is scratch the result contains all
+// files of upper in a single layer.
+func Diff(lower, upper State, opts ...ConstraintsOpt) State {
+	if upper.Output() == nil {
+		return Scratch()
+	}
+
+	var c Constraints
+	for _, o := range opts {
+		o.SetConstraintsOption(&c)
+	}
+	addCap(&c, pb.CapDiffOp)
+	return upper.WithOutput(NewDiff(lower, upper, c).Output())
+}
@@ -0,0 +1,57 @@
+package llb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	lower := Image("foo")
+	upper := lower.File(Mkfile("/a", 0600, []byte("a")))
+
+	def, err := Diff(lower, upper).Marshal(context.TODO())
+	require.NoError(t, err)
+
+	m, arr := parseDef(t, def.Def)
+	require.Equal(t, 4, len(arr))
+
+	dgst, idx := last(t, arr)
+	require.Equal(t, 0, idx)
+
+	diff := m[dgst]
+	op, ok := diff.Op.(*pb.Op_Diff)
+	require.True(t, ok)
+	require.Equal(t, 2, len(diff.Inputs))
+	require.Equal(t, pb.InputIndex(0), op.Diff.Lower.Input)
+	require.Equal(t, pb.InputIndex(1), op.Diff.Upper.Input)
+
+	_, ok = m[diff.Inputs[0].Digest].Op.(*pb.Op_Source)
+	require.True(t, ok)
+	_, ok = m[diff.Inputs[1].Digest].Op.(*pb.Op_File)
+	require.True(t, ok)
+}
+
+func TestDiffScratch(t *testing.T) {
+	t.Parallel()
+
+	upper := Image("foo")
+	def, err := Diff(Scratch(), upper).Marshal(context.TODO())
+	require.NoError(t, err)
+
+	m, arr := parseDef(t, def.Def)
+	require.Equal(t, 3, len(arr))
+
+	dgst, _ := last(t, arr)
+	op, ok := m[dgst].Op.(*pb.Op_Diff)
+	require.True(t, ok)
+	require.Equal(t, 1, len(m[dgst].Inputs))
+	require.Equal(t, pb.Empty, op.Diff.Lower.Input)
+	require.Equal(t, pb.InputIndex(0), op.Diff.Upper.Input)
+
+	require.Nil(t, Diff(upper, Scratch()).Output())
+}
@@ -57,6 +57,7 @@ type ExecOp struct {
 	isValidated bool
 	secrets     []SecretInfo
 	ssh         []SSHInfo
+	devices     []DeviceInfo
 }
 
 func (e *ExecOp) AddMount(target string, source Output, opt ...MountOption) Output {
@@ -204,6 +205,38 @@ func (e *ExecOp) Marshal(ctx context.Context, c *Constraints) (digest.Digest, []
 		meta.ExtraHosts = hosts
 	}
 
+	ulimits, err := getUlimit(e.base)(ctx)
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+	if len(ulimits) > 0 {
+		addCap(&e.constraints, pb.CapExecMetaUlimit)
+		ul := make([]*pb.Ulimit, len(ulimits))
+		for i, u := range ulimits {
+			ul[i] = &pb.Ulimit{
+				Name: string(u.Name),
+				Soft: u.Soft,
+				Hard: u.Hard,
+			}
+		}
+		meta.Ulimit = ul
+	}
+
+	resources, err := getResourceLimits(e.base)(ctx)
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+	if resources != nil {
+		addCap(&e.constraints, pb.CapExecMetaResourceLimits)
+		meta.Resources = &pb.ResourceLimits{
+			Memory:    resources.Memory,
+			CpuShares: resources.CPUShares,
+			CpuQuota:  resources.CPUQuota,
+			CpuPeriod: resources.CPUPeriod,
+			Pids:      resources.Pids,
+		}
+	}
+
 	network, err := getNetwork(e.base)(ctx)
 	if err != nil {
 		return "", nil, nil, nil, err
@@ -227,6 +260,17 @@ func (e *ExecOp) Marshal(ctx context.Context, c *Constraints) (digest.Digest, []
 		addCap(&e.constraints, pb.CapExecMetaSecurity)
 	}
 
+	if len(e.devices) > 0 {
+		addCap(&e.constraints, pb.CapExecDevice)
+		peo.Devices = make([]*pb.Device, len(e.devices))
+		for i, d := range e.devices {
+			peo.Devices[i] = &pb.Device{
+				Path:        d.Path,
+				Permissions: d.Permissions,
+			}
+		}
+	}
+
 	if p := e.proxyEnv; p != nil {
 		peo.Meta.ProxyEnv = &pb.ProxyEnv{
 			HttpProxy:  p.HTTPProxy,
@@ -497,6 +541,12 @@ func AddExtraHost(host string, ip net.IP) RunOption {
 	})
 }
 
+func AddUlimit(name UlimitName, soft int64, hard int64) RunOption {
+	return runOptionFunc(func(ei *ExecInfo) {
+		ei.State = ei.State.AddUlimit(name, soft, hard)
+	})
+}
+
 func With(so ...StateOption) RunOption {
 	return runOptionFunc(func(ei *ExecInfo) {
 		ei.State = ei.State.With(so...)
@@ -612,6 +662,40 @@ func SecretFileOpt(uid, gid, mode int) SecretOption {
 	})
 }
 
+// AddDevice exposes the host device at path to the process. The build daemon
+// needs to allow the device and the build the device entitlement.
+func AddDevice(path string, opts ...DeviceOption) RunOption {
+	return runOptionFunc(func(ei *ExecInfo) {
+		d := &DeviceInfo{Path: path}
+		for _, opt := range opts {
+			opt.SetDeviceOption(d)
+		}
+		ei.Devices = append(ei.Devices, *d)
+	})
+}
+
+type DeviceOption interface {
+	SetDeviceOption(*DeviceInfo)
+}
+
+type deviceOptionFunc func(*DeviceInfo)
+
+func (fn deviceOptionFunc) SetDeviceOption(di *DeviceInfo) {
+	fn(di)
+}
+
+type DeviceInfo struct {
+	Path string
+	// Permissions are a combination of r, w and m, rwm if empty
+	Permissions string
+}
+
+func DevicePermissions(perms string) DeviceOption {
+	return deviceOptionFunc(func(di *DeviceInfo) {
+		di.Permissions = perms
+	})
+}
+
 func ReadonlyRootFS() RunOption {
 	return runOptionFunc(func(ei *ExecInfo) {
 		ei.ReadonlyRootFS = true
@@ -632,6 +716,7 @@ type ExecInfo struct {
 	ProxyEnv       *ProxyEnv
 	Secrets        []SecretInfo
 	SSH            []SSHInfo
+	Devices        []DeviceInfo
 }
 
 type MountInfo struct {
@@ -50,3 +50,56 @@ func TestValidGetMountIndex(t *testing.T) {
 	require.NoError(t, err, "failed to getIndex")
 	require.Equal(t, pb.OutputIndex(1), mountIndex, "unexpected mount index")
 }
+
+func TestExecResourceLimits(t *testing.T) {
+	t.Parallel()
+
+	st := Image("foo").Run(
+		Shlex("args"),
+		AddUlimit(UlimitNofile, 1024, 2048),
+		With(MemoryLimit(512<<20), CPUQuota(50000, 100000)),
+		PidsLimit(100),
+	).Root()
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+
+	m, arr := parseDef(t, def.Def)
+	dgst, idx := last(t, arr)
+	require.Equal(t, 0, idx)
+
+	exec := m[dgst].Op.(*pb.Op_Exec).Exec
+	require.Equal(t, []*pb.Ulimit{{Name: "nofile", Soft: 1024, Hard: 2048}}, exec.Meta.Ulimit)
+	require.Equal(t, &pb.ResourceLimits{
+		Memory:    512 << 20,
+		CpuQuota:  50000,
+		CpuPeriod: 100000,
+		Pids:      100,
+	}, exec.Meta.Resources)
+
+	_, ok := def.Metadata[dgst].Caps[pb.CapExecMetaUlimit]
+	require.True(t, ok)
+	_, ok = def.Metadata[dgst].Caps[pb.CapExecMetaResourceLimits]
+	require.True(t, ok)
+}
+
+func TestExecDevices(t *testing.T) {
+	t.Parallel()
+
+	st := Image("foo").Run(
+		Shlex("args"),
+		AddDevice("/dev/fuse"),
+		AddDevice("/dev/kvm", DevicePermissions("rw")),
+	).Root()
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+
+	m, arr := parseDef(t, def.Def)
+	dgst, idx := last(t, arr)
+	require.Equal(t, 0, idx)
+
+	exec := m[dgst].Op.(*pb.Op_Exec).Exec
+	require.Equal(t, []*pb.Device{{Path: "/dev/fuse"}, {Path: "/dev/kvm", Permissions: "rw"}}, exec.Devices)
+
+	_, ok := def.Metadata[dgst].Caps[pb.CapExecDevice]
+	require.True(t, ok)
+}
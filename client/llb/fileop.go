@@ -433,6 +433,9 @@ type CopyInfo struct {
 	AllowEmptyWildcard  bool
 	ChownOpt            *ChownOpt
 	CreatedTime         *time.Time
+	IncludePatterns     []string
+	ExcludePatterns     []string
+	Parents             bool
 }
 
 func (mi *CopyInfo) SetCopyOption(mi2 *CopyInfo) {
@@ -465,6 +468,9 @@ func (a *fileActionCopy) toProtoAction(ctx context.Context, parent string, base
 		AttemptUnpackDockerCompatibility: a.info.AttemptUnpack,
 		CreateDestPath:                   a.info.CreateDestPath,
 		Timestamp:                        marshalTime(a.info.CreatedTime),
+		IncludePatterns:                  a.info.IncludePatterns,
+		ExcludePatterns:                  a.info.ExcludePatterns,
+		Parents:                          a.info.Parents,
 	}
 	if a.info.Mode != nil {
 		c.Mode = int32(*a.info.Mode)
@@ -661,17 +667,11 @@ func (f *FileOp) Marshal(ctx context.Context, c *Constraints) (digest.Digest, []
 
 	pfo := &pb.FileOp{}
 
-	pop, md := MarshalConstraints(c, &f.constraints)
-	pop.Op = &pb.Op_File{
-		File: pfo,
-	}
-
 	state := newMarshalState(ctx)
 	_, err := state.add(f.action, c)
 	if err != nil {
 		return "", nil, nil, nil, err
 	}
-	pop.Inputs = state.inputs
 
 	for i, st := range state.actions {
 		output := pb.OutputIndex(-1)
@@ -691,6 +691,14 @@ func (f *FileOp) Marshal(ctx context.Context, c *Constraints) (digest.Digest, []
 		if err != nil {
 			return "", nil, nil, nil, err
 		}
+		if cp, ok := action.(*pb.FileAction_Copy); ok {
+			if len(cp.Copy.IncludePatterns) > 0 || len(cp.Copy.ExcludePatterns) > 0 {
+				addCap(&f.constraints, pb.CapFileCopyIncludeExcludePatterns)
+			}
+			if cp.Copy.Parents {
+				addCap(&f.constraints, pb.CapFileCopyParents)
+			}
+		}
 
 		pfo.Actions = append(pfo.Actions, &pb.FileAction{
 			Input:          getIndex(st.input, len(state.inputs), st.inputRelative),
@@ -700,6 +708,12 @@ func (f *FileOp) Marshal(ctx context.Context, c *Constraints) (digest.Digest, []
 		})
 	}
 
+	pop, md := MarshalConstraints(c, &f.constraints)
+	pop.Op = &pb.Op_File{
+		File: pfo,
+	}
+	pop.Inputs = state.inputs
+
 	dt, err := pop.Marshal()
 	if err != nil {
 		return "", nil, nil, nil, err
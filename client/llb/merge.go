@@ -0,0 +1,103 @@
+package llb
+
+import (
+	"context"
+
+	"github.com/moby/buildkit/solver/pb"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+type MergeOp struct {
+	MarshalCache
+	inputs      []Output
+	output      Output
+	constraints Constraints
+}
+
+func NewMerge(inputs []State, c Constraints) *MergeOp {
+	op := &MergeOp{constraints: c}
+
+	var outputs []Output
+	for _, st := range inputs {
+		outputs = append(outputs, st.Output())
+	}
+	op.inputs = outputs
+	op.output = &output{vertex: op}
+	return op
+}
+
+func (m *MergeOp) Validate(ctx context.Context) error {
+	if len(m.inputs) < 2 {
+		return errors.Errorf("merge must have at least 2 inputs")
+	}
+	return nil
+}
+
+func (m *MergeOp) Marshal(ctx context.Context, constraints *Constraints) (digest.Digest, []byte, *pb.OpMetadata, []*SourceLocation, error) {
+	if m.Cached(constraints) {
+		return m.Load()
+	}
+	if err := m.Validate(ctx); err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	addCap(&m.constraints, pb.CapMergeOp)
+
+	pop, md := MarshalConstraints(constraints, &m.constraints)
+	pop.Platform = nil // merge op is not platform specific
+
+	op := &pb.MergeOp{}
+	for _, input := range m.inputs {
+		op.Inputs = append(op.Inputs, &pb.MergeInput{Input: pb.InputIndex(len(pop.Inputs))})
+		pbInput, err := input.ToInput(ctx, constraints)
+		if err != nil {
+			return "", nil, nil, nil, err
+		}
+		pop.Inputs = append(pop.Inputs, pbInput)
+	}
+	pop.Op = &pb.Op_Merge{Merge: op}
+
+	dt, err := pop.Marshal()
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	m.Store(dt, md, m.constraints.SourceLocations, constraints)
+	return m.Load()
+}
+
+func (m *MergeOp) Output() Output {
+	return m.output
+}
+
+func (m *MergeOp) Inputs() []Output {
+	return m.inputs
+}
+
+// Merge returns a state with the files of all inputs layered on top of each
+// other in order. Files in later inputs replace files at the same path in
+// earlier inputs. Unlike copying, each input stays cacheable on its own and
+// the result reuses the layers of the inputs on export. Scratch inputs are
+// ignored and the state of a single remaining input is returned as is.
+func Merge(inputs []State, opts ...ConstraintsOpt) State {
+	var filtered []State
+	for _, input := range inputs {
+		if input.Output() != nil {
+			filtered = append(filtered, input)
+		}
+	}
+	if len(filtered) == 0 {
+		return Scratch()
+	}
+	if len(filtered) == 1 {
+		return filtered[0]
+	}
+
+	var c Constraints
+	for _, o := range opts {
+		o.SetConstraintsOption(&c)
+	}
+	addCap(&c, pb.CapMergeOp)
+	return filtered[0].WithOutput(NewMerge(filtered, c).Output())
+}
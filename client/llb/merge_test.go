@@ -0,0 +1,55 @@
+package llb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	a := Image("foo").File(Mkfile("/a", 0600, []byte("a")))
+	b := Scratch().File(Mkfile("/b", 0600, []byte("b")))
+
+	st := Merge([]State{a, Scratch(), b}).Dir("/x")
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+
+	m, arr := parseDef(t, def.Def)
+	require.Equal(t, 5, len(arr))
+
+	dgst, idx := last(t, arr)
+	require.Equal(t, 0, idx)
+
+	merge := m[dgst]
+	op, ok := merge.Op.(*pb.Op_Merge)
+	require.True(t, ok)
+	require.Equal(t, 2, len(op.Merge.Inputs))
+	require.Equal(t, 2, len(merge.Inputs))
+	for i, inp := range op.Merge.Inputs {
+		require.Equal(t, i, int(inp.Input))
+	}
+
+	_, ok = m[merge.Inputs[0].Digest].Op.(*pb.Op_File)
+	require.True(t, ok)
+	_, ok = m[merge.Inputs[1].Digest].Op.(*pb.Op_File)
+	require.True(t, ok)
+
+	dir, err := st.GetDir(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, "/x", dir)
+}
+
+func TestMergeSingleInput(t *testing.T) {
+	t.Parallel()
+
+	a := Image("foo")
+	st := Merge([]State{Scratch(), a})
+	require.Equal(t, a.Output(), st.Output())
+
+	st = Merge([]State{Scratch(), Scratch()})
+	require.Nil(t, st.Output())
+}
@@ -21,6 +21,8 @@ var (
 	keyUser      = contextKeyT("llb.exec.user")
 	keyHostname  = contextKeyT("llb.exec.hostname")
 	keyExtraHost = contextKeyT("llb.exec.extrahost")
+	keyUlimit    = contextKeyT("llb.exec.ulimit")
+	keyResources = contextKeyT("llb.exec.resources")
 	keyPlatform  = contextKeyT("llb.platform")
 	keyNetwork   = contextKeyT("llb.network")
 	keySecurity  = contextKeyT("llb.security")
@@ -232,6 +234,132 @@ type HostIP struct {
 	IP   net.IP
 }
 
+type UlimitName string
+
+const (
+	UlimitCore       UlimitName = pb.UlimitCore
+	UlimitCPU        UlimitName = pb.UlimitCPU
+	UlimitData       UlimitName = pb.UlimitData
+	UlimitFsize      UlimitName = pb.UlimitFsize
+	UlimitLocks      UlimitName = pb.UlimitLocks
+	UlimitMemlock    UlimitName = pb.UlimitMemlock
+	UlimitMsgqueue   UlimitName = pb.UlimitMsgqueue
+	UlimitNice       UlimitName = pb.UlimitNice
+	UlimitNofile     UlimitName = pb.UlimitNofile
+	UlimitNproc      UlimitName = pb.UlimitNproc
+	UlimitRss        UlimitName = pb.UlimitRss
+	UlimitRtprio     UlimitName = pb.UlimitRtprio
+	UlimitRttime     UlimitName = pb.UlimitRttime
+	UlimitSigpending UlimitName = pb.UlimitSigpending
+	UlimitStack      UlimitName = pb.UlimitStack
+)
+
+type Ulimit struct {
+	Name UlimitName
+	Soft int64
+	Hard int64
+}
+
+func ulimit(name UlimitName, soft int64, hard int64) StateOption {
+	return func(s State) State {
+		return s.withValue(keyUlimit, func(ctx context.Context) (interface{}, error) {
+			v, err := getUlimit(s)(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return append(v, Ulimit{Name: name, Soft: soft, Hard: hard}), nil
+		})
+	}
+}
+
+func getUlimit(s State) func(context.Context) ([]Ulimit, error) {
+	return func(ctx context.Context) ([]Ulimit, error) {
+		v, err := s.getValue(keyUlimit)(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			return v.([]Ulimit), nil
+		}
+		return nil, nil
+	}
+}
+
+// ResourceLimits are the cgroup limits of the processes run from a state.
+// Zero values are not limited.
+type ResourceLimits struct {
+	// Memory is the memory limit in bytes
+	Memory int64
+	// CPUShares is the relative cpu weight
+	CPUShares int64
+	// CPUQuota is the cpu time in microseconds that can be used per CPUPeriod
+	CPUQuota  int64
+	CPUPeriod int64
+	// Pids is the maximum number of processes
+	Pids int64
+}
+
+func resourceLimits(f func(*ResourceLimits)) StateOption {
+	return func(s State) State {
+		return s.withValue(keyResources, func(ctx context.Context) (interface{}, error) {
+			v, err := getResourceLimits(s)(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var r ResourceLimits
+			if v != nil {
+				r = *v
+			}
+			f(&r)
+			return &r, nil
+		})
+	}
+}
+
+func getResourceLimits(s State) func(context.Context) (*ResourceLimits, error) {
+	return func(ctx context.Context) (*ResourceLimits, error) {
+		v, err := s.getValue(keyResources)(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			return v.(*ResourceLimits), nil
+		}
+		return nil, nil
+	}
+}
+
+// MemoryLimit limits the memory of the processes run from the state to the
+// given number of bytes.
+func MemoryLimit(bytes int64) StateOption {
+	return resourceLimits(func(r *ResourceLimits) {
+		r.Memory = bytes
+	})
+}
+
+// CPUShares sets the relative cpu weight of the processes run from the state.
+func CPUShares(shares int64) StateOption {
+	return resourceLimits(func(r *ResourceLimits) {
+		r.CPUShares = shares
+	})
+}
+
+// CPUQuota limits the processes run from the state to quota microseconds of
+// cpu time in every period.
+func CPUQuota(quota, period int64) StateOption {
+	return resourceLimits(func(r *ResourceLimits) {
+		r.CPUQuota = quota
+		r.CPUPeriod = period
+	})
+}
+
+// PidsLimit limits the number of processes run from the state.
+func PidsLimit(pids int64) StateOption {
+	return resourceLimits(func(r *ResourceLimits) {
+		r.Pids = pids
+	})
+}
+
 func Network(v pb.NetMode) StateOption {
 	return func(s State) State {
 		return s.WithValue(keyNetwork, v)
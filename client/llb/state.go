@@ -256,6 +256,7 @@ func (s State) Run(ro ...RunOption) ExecState {
 	}
 	exec.secrets = ei.Secrets
 	exec.ssh = ei.SSH
+	exec.devices = ei.Devices
 
 	return ExecState{
 		State: s.WithOutput(exec.Output()),
@@ -362,6 +363,18 @@ func (s State) AddExtraHost(host string, ip net.IP) State {
 	return extraHost(host, ip)(s)
 }
 
+func (s State) AddUlimit(name UlimitName, soft int64, hard int64) State {
+	return ulimit(name, soft, hard)(s)
+}
+
+func (s State) GetUlimit(ctx context.Context) ([]Ulimit, error) {
+	return getUlimit(s)(ctx)
+}
+
+func (s State) GetResourceLimits(ctx context.Context) (*ResourceLimits, error) {
+	return getResourceLimits(s)(ctx)
+}
+
 func (s State) isFileOpCopyInput() {}
 
 type output struct {
@@ -16,9 +16,14 @@ func (c *Client) Prune(ctx context.Context, ch chan UsageInfo, opts ...PruneOpti
 	}
 
 	req := &controlapi.PruneRequest{
-		Filter:       info.Filter,
-		KeepDuration: int64(info.KeepDuration),
-		KeepBytes:    int64(info.KeepBytes),
+		Filter:        info.Filter,
+		KeepDuration:  int64(info.KeepDuration),
+		KeepBytes:     int64(info.KeepBytes),
+		ReservedSpace: info.ReservedSpace,
+		MaxUsedSpace:  info.MaxUsedSpace,
+		MinFreeSpace:  info.MinFreeSpace,
+		DryRun:        info.DryRun,
+		GcPolicy:      info.gcPolicy,
 	}
 	if info.All {
 		req.All = true
@@ -38,17 +43,22 @@ func (c *Client) Prune(ctx context.Context, ch chan UsageInfo, opts ...PruneOpti
 		}
 		if ch != nil {
 			ch <- UsageInfo{
-				ID:          d.ID,
-				Mutable:     d.Mutable,
-				InUse:       d.InUse,
-				Size:        d.Size_,
-				Parent:      d.Parent,
-				CreatedAt:   d.CreatedAt,
-				Description: d.Description,
-				UsageCount:  int(d.UsageCount),
-				LastUsedAt:  d.LastUsedAt,
-				RecordType:  UsageRecordType(d.RecordType),
-				Shared:      d.Shared,
+				ID:            d.ID,
+				Mutable:       d.Mutable,
+				InUse:         d.InUse,
+				Size:          d.Size_,
+				Parent:        d.Parent,
+				CreatedAt:     d.CreatedAt,
+				Description:   d.Description,
+				UsageCount:    int(d.UsageCount),
+				LastUsedAt:    d.LastUsedAt,
+				RecordType:    UsageRecordType(d.RecordType),
+				Shared:        d.Shared,
+				CacheMountID:  d.CacheMountID,
+				SourceOp:      d.SourceOp,
+				BuildRef:      d.BuildRef,
+				LastUsedBuild: d.LastUsedBuild,
+				Frontend:      d.Frontend,
 			}
 		}
 	}
@@ -63,6 +73,20 @@ type PruneInfo struct {
 	All          bool
 	KeepDuration time.Duration
 	KeepBytes    int64
+
+	// ReservedSpace is the minimum amount of cache that is never pruned.
+	ReservedSpace int64
+	// MaxUsedSpace is the maximum amount of cache kept. It replaces KeepBytes
+	// when both are set.
+	MaxUsedSpace int64
+	// MinFreeSpace is the amount of free disk space to maintain on the
+	// filesystem holding the cache.
+	MinFreeSpace int64
+
+	// DryRun reports the records that would be pruned without deleting them.
+	DryRun bool
+
+	gcPolicy bool
 }
 
 type pruneOptionFunc func(*PruneInfo)
@@ -75,6 +99,16 @@ var PruneAll = pruneOptionFunc(func(pi *PruneInfo) {
 	pi.All = true
 })
 
+var PruneDryRun = pruneOptionFunc(func(pi *PruneInfo) {
+	pi.DryRun = true
+})
+
+// WithGCPolicy makes the daemon apply the garbage collection policy of each
+// worker instead of the limits in the prune options.
+var WithGCPolicy = pruneOptionFunc(func(pi *PruneInfo) {
+	pi.gcPolicy = true
+})
+
 func WithKeepOpt(duration time.Duration, bytes int64) PruneOption {
 	return pruneOptionFunc(func(pi *PruneInfo) {
 		pi.KeepDuration = duration
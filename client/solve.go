@@ -40,6 +40,8 @@ type SolveOpt struct {
 	CacheImports          []CacheOptionsEntry
 	Session               []session.Attachable
 	AllowedEntitlements   []entitlements.Entitlement
+	CgroupParent          string           // overrides the cgroup parent of the worker
+	MaxParallelism        int              // limits the exec steps running at the same time
 	SharedSession         *session.Session // TODO: refactor to better session syncing
 	SessionPreInitialized bool             // TODO: refactor to better session syncing
 }
@@ -209,6 +211,8 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 			FrontendInputs: frontendInputs,
 			Cache:          cacheOpt.options,
 			Entitlements:   opt.AllowedEntitlements,
+			CgroupParent:   opt.CgroupParent,
+			MaxParallelism: int64(opt.MaxParallelism),
 		})
 		if err != nil {
 			return errors.Wrap(err, "failed to solve")
@@ -256,40 +260,9 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 				}
 				return errors.Wrap(err, "failed to receive status")
 			}
-			s := SolveStatus{}
-			for _, v := range resp.Vertexes {
-				s.Vertexes = append(s.Vertexes, &Vertex{
-					Digest:    v.Digest,
-					Inputs:    v.Inputs,
-					Name:      v.Name,
-					Started:   v.Started,
-					Completed: v.Completed,
-					Error:     v.Error,
-					Cached:    v.Cached,
-				})
-			}
-			for _, v := range resp.Statuses {
-				s.Statuses = append(s.Statuses, &VertexStatus{
-					ID:        v.ID,
-					Vertex:    v.Vertex,
-					Name:      v.Name,
-					Total:     v.Total,
-					Current:   v.Current,
-					Timestamp: v.Timestamp,
-					Started:   v.Started,
-					Completed: v.Completed,
-				})
-			}
-			for _, v := range resp.Logs {
-				s.Logs = append(s.Logs, &VertexLog{
-					Vertex:    v.Vertex,
-					Stream:    int(v.Stream),
-					Data:      v.Msg,
-					Timestamp: v.Timestamp,
-				})
-			}
+			s := fromAPIStatusResponse(resp)
 			if statusChan != nil {
-				statusChan <- &s
+				statusChan <- s
 			}
 		}
 	})
@@ -486,3 +459,60 @@ func parseCacheOptions(opt SolveOpt) (*cacheOptions, error) {
 	}
 	return &res, nil
 }
+
+func fromAPIStatusResponse(resp *controlapi.StatusResponse) *SolveStatus {
+	s := &SolveStatus{}
+	for _, v := range resp.Vertexes {
+		s.Vertexes = append(s.Vertexes, &Vertex{
+			Digest:    v.Digest,
+			Inputs:    v.Inputs,
+			Name:      v.Name,
+			Started:   v.Started,
+			Completed: v.Completed,
+			Error:     v.Error,
+			Cached:    v.Cached,
+		})
+	}
+	for _, v := range resp.Statuses {
+		s.Statuses = append(s.Statuses, &VertexStatus{
+			ID:        v.ID,
+			Vertex:    v.Vertex,
+			Name:      v.Name,
+			Total:     v.Total,
+			Current:   v.Current,
+			Timestamp: v.Timestamp,
+			Started:   v.Started,
+			Completed: v.Completed,
+		})
+	}
+	for _, v := range resp.Logs {
+		s.Logs = append(s.Logs, &VertexLog{
+			Vertex:    v.Vertex,
+			Stream:    int(v.Stream),
+			Data:      v.Msg,
+			Timestamp: v.Timestamp,
+		})
+	}
+	for _, v := range resp.Warnings {
+		s.Warnings = append(s.Warnings, &VertexWarning{
+			Vertex:     v.Vertex,
+			Level:      int(v.Level),
+			Short:      v.Short,
+			Detail:     v.Detail,
+			URL:        v.Url,
+			SourceInfo: v.Info,
+			Range:      v.Ranges,
+		})
+	}
+	for _, v := range resp.Resources {
+		s.Resources = append(s.Resources, &VertexResourceUsage{
+			Vertex:       v.Vertex,
+			CPUTime:      time.Duration(v.CpuNanos),
+			MemoryPeak:   v.MemoryPeak,
+			IOReadBytes:  v.IoReadBytes,
+			IOWriteBytes: v.IoWriteBytes,
+			PidsPeak:     v.PidsPeak,
+		})
+	}
+	return s
+}
@@ -60,10 +60,13 @@ func fromAPIGCPolicy(in []*apitypes.GCPolicy) []PruneInfo {
 	out := make([]PruneInfo, 0, len(in))
 	for _, p := range in {
 		out = append(out, PruneInfo{
-			All:          p.All,
-			Filter:       p.Filters,
-			KeepDuration: time.Duration(p.KeepDuration),
-			KeepBytes:    p.KeepBytes,
+			All:           p.All,
+			Filter:        p.Filters,
+			KeepDuration:  time.Duration(p.KeepDuration),
+			KeepBytes:     p.KeepBytes,
+			ReservedSpace: p.ReservedSpace,
+			MaxUsedSpace:  p.MaxUsedSpace,
+			MinFreeSpace:  p.MinFreeSpace,
 		})
 	}
 	return out
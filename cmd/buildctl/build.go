@@ -95,12 +95,24 @@ var buildCommand = cli.Command{
 		},
 		cli.StringSliceFlag{
 			Name:  "allow",
-			Usage: "Allow extra privileged entitlement, e.g. network.host, security.insecure",
+			Usage: "Allow extra privileged entitlement, e.g. network.host, security.insecure, device, cgroup.parent",
+		},
+		cli.StringFlag{
+			Name:  "cgroup-parent",
+			Usage: "Override the cgroup parent of the worker for the exec steps of the build (requires --allow cgroup.parent)",
+		},
+		cli.IntFlag{
+			Name:  "max-parallelism",
+			Usage: "Limit the number of exec steps of the build running at the same time",
 		},
 		cli.StringSliceFlag{
 			Name:  "ssh",
 			Usage: "Allow forwarding SSH agent to the builder. Format default|<id>[=<socket>|<key>[,<key>]]",
 		},
+		cli.BoolFlag{
+			Name:  "debug-on-failure",
+			Usage: "Open an interactive shell in the container of a failed exec step",
+		},
 	},
 }
 
@@ -214,6 +226,8 @@ func buildAction(clicontext *cli.Context) error {
 		CacheImports:        cacheImports,
 		Session:             attachable,
 		AllowedEntitlements: allowed,
+		CgroupParent:        clicontext.String("cgroup-parent"),
+		MaxParallelism:      clicontext.Int("max-parallelism"),
 	}
 
 	solveOpt.FrontendAttrs, err = build.ParseOpt(clicontext.StringSlice("opt"), clicontext.StringSlice("frontend-opt"))
@@ -244,8 +258,16 @@ func buildAction(clicontext *cli.Context) error {
 		}
 	}
 
+	progressMode := clicontext.String("progress")
+	debug := clicontext.Bool("debug-on-failure")
+	if debug && progressMode != "plain" {
+		// the debug shell needs the terminal, the tty progress display would
+		// keep redrawing over it
+		progressMode = "plain"
+	}
+
 	// not using shared context to not disrupt display but let is finish reporting errors
-	pw, err := progresswriter.NewPrinter(context.TODO(), os.Stderr, clicontext.String("progress"))
+	pw, err := progresswriter.NewPrinter(context.TODO(), os.Stderr, progressMode)
 	if err != nil {
 		return err
 	}
@@ -283,7 +305,18 @@ func buildAction(clicontext *cli.Context) error {
 				close(w.Status())
 			}
 		}()
-		resp, err := c.Solve(ctx, def, solveOpt, progresswriter.ResetTime(mw.WithPrefix("", false)).Status())
+		statusCh := progresswriter.ResetTime(mw.WithPrefix("", false)).Status()
+		var (
+			resp *client.SolveResponse
+			err  error
+		)
+		if debug {
+			frontend := solveOpt.Frontend
+			solveOpt.Frontend = ""
+			resp, err = c.Build(ctx, solveOpt, "buildctl", debugOnFailure(def, frontend), statusCh)
+		} else {
+			resp, err = c.Solve(ctx, def, solveOpt, statusCh)
+		}
 		if err != nil {
 			return err
 		}
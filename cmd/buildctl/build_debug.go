@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/containerd/console"
+	"github.com/moby/buildkit/client/llb"
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	gwpb "github.com/moby/buildkit/frontend/gateway/pb"
+	"github.com/moby/buildkit/solver/errdefs"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// debugOnFailure returns a build function that solves the build like a normal
+// build but, if an exec step fails, opens an interactive shell in a container
+// with the mounts of the failed step before returning the error.
+func debugOnFailure(def *llb.Definition, frontend string) gateway.BuildFunc {
+	return func(ctx context.Context, c gateway.Client) (*gateway.Result, error) {
+		req := gateway.SolveRequest{
+			Evaluate: true,
+		}
+		if def != nil {
+			req.Definition = def.ToPB()
+		} else {
+			req.Frontend = frontend
+			req.FrontendOpt = c.BuildOpts().Opts
+			inputs, err := frontendInputs(ctx, c)
+			if err != nil {
+				return nil, err
+			}
+			req.FrontendInputs = inputs
+		}
+		res, err := c.Solve(ctx, req)
+		if err != nil {
+			var se *errdefs.SolveError
+			if errors.As(err, &se) {
+				if err := debugShell(ctx, c, se); err != nil {
+					logrus.Warnf("failed to run debug shell: %v", err)
+				}
+			}
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+// frontendInputs returns the inputs of the build so that they are forwarded
+// to the frontend
+func frontendInputs(ctx context.Context, c gateway.Client) (map[string]*pb.Definition, error) {
+	caps := c.BuildOpts().Caps
+	if err := (&caps).Supports(gwpb.CapFrontendInputs); err != nil {
+		return nil, nil
+	}
+	inputs, err := c.Inputs(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get frontend inputs")
+	}
+	defs := make(map[string]*pb.Definition, len(inputs))
+	for name, st := range inputs {
+		def, err := st.Marshal(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defs[name] = def.ToPB()
+	}
+	return defs, nil
+}
+
+// debugContainerRequest returns the exec step that failed and the request
+// for a container with its mounts
+func debugContainerRequest(se *errdefs.SolveError) (*pb.ExecOp, gateway.NewContainerRequest, error) {
+	if se.Op == nil {
+		return nil, gateway.NewContainerRequest{}, errors.New("no failed step reported")
+	}
+	op, ok := se.Op.Op.(*pb.Op_Exec)
+	if !ok {
+		return nil, gateway.NewContainerRequest{}, errors.Errorf("failed step is not an exec step")
+	}
+	exec := op.Exec
+	if len(se.MountIDs) != len(exec.Mounts) {
+		return nil, gateway.NewContainerRequest{}, errors.Errorf("invalid number of mounts for failed step: %d, expected %d", len(se.MountIDs), len(exec.Mounts))
+	}
+
+	var mounts []gateway.Mount
+	for i, m := range exec.Mounts {
+		mounts = append(mounts, gateway.Mount{
+			Selector:  m.Selector,
+			Dest:      m.Dest,
+			ResultID:  se.MountIDs[i],
+			Readonly:  m.Readonly,
+			MountType: m.MountType,
+			CacheOpt:  m.CacheOpt,
+			SecretOpt: m.SecretOpt,
+			SSHOpt:    m.SSHOpt,
+		})
+	}
+	return exec, gateway.NewContainerRequest{
+		Mounts:      mounts,
+		NetMode:     exec.Network,
+		Platform:    se.Op.Platform,
+		Constraints: se.Op.Constraints,
+	}, nil
+}
+
+func debugShell(ctx context.Context, c gateway.Client, se *errdefs.SolveError) error {
+	exec, req, err := debugContainerRequest(se)
+	if err != nil {
+		return err
+	}
+
+	in, closeTerminal, err := openTerminal()
+	if err != nil {
+		return err
+	}
+	defer closeTerminal()
+	con, err := console.ConsoleFromFile(in)
+	if err != nil {
+		return errors.Wrap(err, "debug shell requires a terminal")
+	}
+
+	ctr, err := c.NewContainer(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer ctr.Release(ctx)
+
+	meta := exec.Meta
+	fmt.Fprintf(os.Stderr, "\nStarting debug shell for failed step: %s\n", strings.Join(meta.Args, " "))
+	fmt.Fprintf(os.Stderr, "Exit the shell to continue.\n\n")
+
+	if err := con.SetRaw(); err != nil {
+		return errors.Wrap(err, "failed to set terminal to raw mode")
+	}
+	defer con.Reset()
+
+	proc, err := ctr.Start(ctx, gateway.StartRequest{
+		Args:         []string{"/bin/sh"},
+		Env:          meta.Env,
+		User:         meta.User,
+		Cwd:          meta.Cwd,
+		Tty:          true,
+		Stdin:        ioutil.NopCloser(in),
+		Stdout:       nopWriteCloser{os.Stdout},
+		Stderr:       nopWriteCloser{os.Stderr},
+		SecurityMode: exec.Security,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	resizeProcess(ctx, con, proc)
+	monitorResize(ctx, con, proc)
+
+	return proc.Wait()
+}
+
+// openTerminal returns stdin if it is a terminal. Otherwise, for example when
+// the LLB definition was read from stdin, the controlling terminal is opened.
+func openTerminal() (*os.File, func(), error) {
+	if _, err := console.ConsoleFromFile(os.Stdin); err == nil {
+		return os.Stdin, func() {}, nil
+	}
+	f, err := os.Open("/dev/tty")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "debug shell requires a terminal")
+	}
+	return f, func() { f.Close() }, nil
+}
+
+func resizeProcess(ctx context.Context, con console.Console, proc gateway.ContainerProcess) {
+	size, err := con.Size()
+	if err != nil {
+		return
+	}
+	if err := proc.Resize(ctx, gateway.WinSize{
+		Rows: uint32(size.Height),
+		Cols: uint32(size.Width),
+	}); err != nil {
+		logrus.Debugf("failed to resize debug shell: %v", err)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/buildkit/client/llb"
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	gwpb "github.com/moby/buildkit/frontend/gateway/pb"
+	"github.com/moby/buildkit/solver/errdefs"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugContainerRequest(t *testing.T) {
+	exec := &pb.ExecOp{
+		Meta: &pb.Meta{Args: []string{"/bin/sh", "-c", "false"}},
+		Mounts: []*pb.Mount{
+			{Dest: "/", Input: 0, Output: 0},
+			{Dest: "/cache", Input: -1, Output: -1, MountType: pb.MountType_CACHE, CacheOpt: &pb.CacheOpt{ID: "foo"}},
+			{Dest: "/src", Input: 1, Output: -1, Selector: "/dir", Readonly: true},
+		},
+		Network: pb.NetMode_NONE,
+	}
+	platform := &pb.Platform{OS: "linux", Architecture: "arm64"}
+	se := &errdefs.SolveError{
+		Solve: errdefs.Solve{
+			MountIDs: []string{"root", "cache", "src"},
+			Op: &pb.Op{
+				Op:       &pb.Op_Exec{Exec: exec},
+				Platform: platform,
+			},
+		},
+		Err: errors.New("failed"),
+	}
+
+	op, req, err := debugContainerRequest(se)
+	require.NoError(t, err)
+	require.Equal(t, exec, op)
+	require.Equal(t, pb.NetMode_NONE, req.NetMode)
+	require.Equal(t, platform, req.Platform)
+	require.Equal(t, []gateway.Mount{
+		{Dest: "/", ResultID: "root"},
+		{Dest: "/cache", ResultID: "cache", MountType: pb.MountType_CACHE, CacheOpt: &pb.CacheOpt{ID: "foo"}},
+		{Dest: "/src", ResultID: "src", Selector: "/dir", Readonly: true},
+	}, req.Mounts)
+
+	se.MountIDs = se.MountIDs[:2]
+	_, _, err = debugContainerRequest(se)
+	require.Error(t, err)
+
+	se.Op = &pb.Op{Op: &pb.Op_File{File: &pb.FileOp{}}}
+	_, _, err = debugContainerRequest(se)
+	require.Error(t, err)
+
+	se.Op = nil
+	_, _, err = debugContainerRequest(se)
+	require.Error(t, err)
+}
+
+type inputsClient struct {
+	gateway.Client
+	inputs map[string]llb.State
+	req    gateway.SolveRequest
+}
+
+func (c *inputsClient) BuildOpts() gateway.BuildOpts {
+	return gateway.BuildOpts{
+		Opts: map[string]string{"context:base": "input:base"},
+		Caps: gwpb.Caps.CapSet(gwpb.Caps.All()),
+	}
+}
+
+func (c *inputsClient) Inputs(ctx context.Context) (map[string]llb.State, error) {
+	return c.inputs, nil
+}
+
+func (c *inputsClient) Solve(ctx context.Context, req gateway.SolveRequest) (*gateway.Result, error) {
+	c.req = req
+	return &gateway.Result{}, nil
+}
+
+func TestDebugOnFailureInputs(t *testing.T) {
+	ctx := context.TODO()
+	c := &inputsClient{inputs: map[string]llb.State{"base": llb.Image("alpine")}}
+
+	_, err := debugOnFailure(nil, "dockerfile.v0")(ctx, c)
+	require.NoError(t, err)
+	require.Equal(t, "dockerfile.v0", c.req.Frontend)
+	require.Equal(t, map[string]string{"context:base": "input:base"}, c.req.FrontendOpt)
+
+	def, err := llb.Image("alpine").Marshal(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]*pb.Definition{"base": def.ToPB()}, c.req.FrontendInputs)
+}
@@ -0,0 +1,29 @@
+// +build !windows
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/containerd/console"
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+)
+
+func monitorResize(ctx context.Context, con console.Console, proc gateway.ContainerProcess) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGWINCH)
+	go func() {
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ch:
+				resizeProcess(ctx, con, proc)
+			}
+		}
+	}()
+}
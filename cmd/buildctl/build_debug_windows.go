@@ -0,0 +1,11 @@
+package main
+
+import (
+	"context"
+
+	"github.com/containerd/console"
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+)
+
+func monitorResize(ctx context.Context, con console.Console, proc gateway.ContainerProcess) {
+}
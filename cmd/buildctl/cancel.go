@@ -0,0 +1,25 @@
+package main
+
+import (
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var cancelCommand = cli.Command{
+	Name:      "cancel",
+	Usage:     "cancel a running build",
+	ArgsUsage: "<ref>",
+	Action:    cancel,
+}
+
+func cancel(clicontext *cli.Context) error {
+	if clicontext.NArg() != 1 {
+		return errors.New("cancel requires exactly one build ref")
+	}
+	c, err := bccommon.ResolveClient(clicontext)
+	if err != nil {
+		return err
+	}
+	return c.CancelJob(bccommon.CommandContext(clicontext), clicontext.Args().First())
+}
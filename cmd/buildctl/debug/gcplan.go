@@ -0,0 +1,58 @@
+package debug
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/moby/buildkit/client"
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/tonistiigi/units"
+	"github.com/urfave/cli"
+)
+
+var GCPlanCommand = cli.Command{
+	Name:   "gc-plan",
+	Usage:  "show the records the next garbage collection run would delete",
+	Action: gcPlan,
+}
+
+func gcPlan(clicontext *cli.Context) error {
+	c, err := bccommon.ResolveClient(clicontext)
+	if err != nil {
+		return err
+	}
+
+	ch := make(chan client.UsageInfo)
+	printed := make(chan struct{})
+
+	tw := tabwriter.NewWriter(os.Stdout, 1, 8, 1, '\t', 0)
+	fmt.Fprintln(tw, "ID\tTYPE\tSIZE\tLAST ACCESSED\tDESCRIPTION")
+	total := int64(0)
+
+	go func() {
+		defer close(printed)
+		for du := range ch {
+			total += du.Size
+			id := du.ID
+			if du.Mutable {
+				id += "*"
+			}
+			lastUsed := ""
+			if du.LastUsedAt != nil {
+				lastUsed = du.LastUsedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%.2f\t%s\t%s\n", id, du.RecordType, units.Bytes(du.Size), lastUsed, du.Description)
+		}
+	}()
+
+	err = c.Prune(commandContext(clicontext), ch, client.WithGCPolicy, client.PruneDryRun)
+	close(ch)
+	<-printed
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(tw, "Total:\t%.2f\n", units.Bytes(total))
+	return tw.Flush()
+}
@@ -0,0 +1,86 @@
+package debug
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/urfave/cli"
+)
+
+var JobsCommand = cli.Command{
+	Name:   "jobs",
+	Usage:  "list running builds",
+	Action: listJobs,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "verbose, v",
+			Usage: "Verbose output",
+		},
+	},
+}
+
+func listJobs(clicontext *cli.Context) error {
+	c, err := bccommon.ResolveClient(clicontext)
+	if err != nil {
+		return err
+	}
+
+	jobs, err := c.ListJobs(commandContext(clicontext))
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 1, 8, 1, '\t', 0)
+
+	if clicontext.Bool("verbose") {
+		printJobsVerbose(tw, jobs)
+	} else {
+		printJobsTable(tw, jobs)
+	}
+	return nil
+}
+
+func printJobsVerbose(tw *tabwriter.Writer, jobs []*client.JobInfo) {
+	now := time.Now()
+	for _, j := range jobs {
+		fmt.Fprintf(tw, "Ref:\t%s\n", j.Ref)
+		fmt.Fprintf(tw, "Session:\t%s\n", j.SessionID)
+		if j.Frontend != "" {
+			fmt.Fprintf(tw, "Frontend:\t%s\n", j.Frontend)
+		}
+		if j.CreatedAt != nil {
+			fmt.Fprintf(tw, "Created At:\t%s\n", j.CreatedAt)
+		}
+		if len(j.Vertices) > 0 {
+			fmt.Fprintf(tw, "Running:\n")
+			for _, v := range j.Vertices {
+				var d time.Duration
+				if v.Started != nil {
+					d = now.Sub(*v.Started).Round(100 * time.Millisecond)
+				}
+				fmt.Fprintf(tw, "\t%s\t%s\t%s\n", v.Digest, d, v.Name)
+			}
+		}
+		fmt.Fprintf(tw, "\n")
+	}
+
+	tw.Flush()
+}
+
+func printJobsTable(tw *tabwriter.Writer, jobs []*client.JobInfo) {
+	fmt.Fprintln(tw, "REF\tFRONTEND\tDURATION\tRUNNING STEPS")
+
+	now := time.Now()
+	for _, j := range jobs {
+		var d time.Duration
+		if j.CreatedAt != nil {
+			d = now.Sub(*j.CreatedAt).Round(time.Second)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", j.Ref, j.Frontend, d, len(j.Vertices))
+	}
+
+	tw.Flush()
+}
@@ -73,6 +73,15 @@ func printWorkersVerbose(tw *tabwriter.Writer, winfo []*client.WorkerInfo) {
 			if rule.KeepBytes > 0 {
 				fmt.Fprintf(tw, "\tKeep Bytes:\t%g\n", units.Bytes(rule.KeepBytes))
 			}
+			if rule.ReservedSpace > 0 {
+				fmt.Fprintf(tw, "\tReserved Space:\t%g\n", units.Bytes(rule.ReservedSpace))
+			}
+			if rule.MaxUsedSpace > 0 {
+				fmt.Fprintf(tw, "\tMax Used Space:\t%g\n", units.Bytes(rule.MaxUsedSpace))
+			}
+			if rule.MinFreeSpace > 0 {
+				fmt.Fprintf(tw, "\tMin Free Space:\t%g\n", units.Bytes(rule.MinFreeSpace))
+			}
 		}
 		fmt.Fprintf(tw, "\n")
 	}
@@ -1,13 +1,16 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"text/tabwriter"
 
 	"github.com/moby/buildkit/client"
 	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/pkg/errors"
 	"github.com/tonistiigi/units"
 	"github.com/urfave/cli"
 )
@@ -25,10 +28,23 @@ var diskUsageCommand = cli.Command{
 			Name:  "verbose, v",
 			Usage: "Verbose output",
 		},
+		cli.BoolFlag{
+			Name:  "tree",
+			Usage: "Show records as a tree of parent/child chains",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "Output format: table, json",
+			Value: "table",
+		},
 	},
 }
 
 func diskUsage(clicontext *cli.Context) error {
+	if clicontext.Bool("verbose") && clicontext.Bool("tree") {
+		return errors.New("--verbose and --tree can't be used together")
+	}
+
 	c, err := bccommon.ResolveClient(clicontext)
 	if err != nil {
 		return err
@@ -39,10 +55,22 @@ func diskUsage(clicontext *cli.Context) error {
 		return err
 	}
 
+	switch format := clicontext.String("format"); format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(du)
+	case "table", "":
+	default:
+		return errors.Errorf("invalid format %q", format)
+	}
+
 	tw := tabwriter.NewWriter(os.Stdout, 1, 8, 1, '\t', 0)
 
 	if clicontext.Bool("verbose") {
 		printVerbose(tw, du)
+	} else if clicontext.Bool("tree") {
+		printTree(tw, du)
 	} else {
 		printTable(tw, du)
 	}
@@ -79,6 +107,21 @@ func printVerbose(tw *tabwriter.Writer, du []*client.UsageInfo) {
 		if di.RecordType != "" {
 			printKV(tw, "Type", di.RecordType)
 		}
+		if di.CacheMountID != "" {
+			printKV(tw, "Cache mount", di.CacheMountID)
+		}
+		if di.SourceOp != "" {
+			printKV(tw, "Source", di.SourceOp)
+		}
+		if di.BuildRef != "" {
+			printKV(tw, "Build", di.BuildRef)
+		}
+		if di.LastUsedBuild != "" {
+			printKV(tw, "Last used build", di.LastUsedBuild)
+		}
+		if di.Frontend != "" {
+			printKV(tw, "Frontend", di.Frontend)
+		}
 
 		fmt.Fprintf(tw, "\n")
 	}
@@ -96,6 +139,56 @@ func printTable(tw *tabwriter.Writer, du []*client.UsageInfo) {
 	tw.Flush()
 }
 
+func printTree(tw *tabwriter.Writer, du []*client.UsageInfo) {
+	ids := make(map[string]struct{}, len(du))
+	for _, di := range du {
+		ids[di.ID] = struct{}{}
+	}
+	children := map[string][]*client.UsageInfo{}
+	var roots []*client.UsageInfo
+	for _, di := range du {
+		if _, ok := ids[di.Parent]; ok {
+			children[di.Parent] = append(children[di.Parent], di)
+		} else {
+			roots = append(roots, di)
+		}
+	}
+
+	fmt.Fprintln(tw, "ID\tRECLAIMABLE\tSIZE\tDESCRIPTION")
+
+	var printNode func(di *client.UsageInfo, prefix string, last, root bool)
+	printNode = func(di *client.UsageInfo, prefix string, last, root bool) {
+		branch, indent := "", ""
+		if !root {
+			branch, indent = "├── ", "│   "
+			if last {
+				branch, indent = "└── ", "    "
+			}
+		}
+		id := di.ID
+		if di.Mutable {
+			id += "*"
+		}
+		size := fmt.Sprintf("%.2f", units.Bytes(di.Size))
+		if di.Shared {
+			size += "*"
+		}
+		fmt.Fprintf(tw, "%s%s%s\t%-11v\t%s\t%s\n", prefix, branch, id, !di.InUse, size, di.Description)
+		c := children[di.ID]
+		sort.Slice(c, func(i, j int) bool {
+			return c[i].CreatedAt.Before(c[j].CreatedAt)
+		})
+		for i, ch := range c {
+			printNode(ch, prefix+indent, i == len(c)-1, false)
+		}
+	}
+	for _, di := range roots {
+		printNode(di, "", true, true)
+	}
+
+	tw.Flush()
+}
+
 func printTableHeader(tw *tabwriter.Writer) {
 	fmt.Fprintln(tw, "ID\tRECLAIMABLE\tSIZE\tLAST ACCESSED")
 }
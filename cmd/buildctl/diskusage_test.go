@@ -11,4 +11,8 @@ func testDiskUsage(t *testing.T, sb integration.Sandbox) {
 	cmd := sb.Cmd("du")
 	err := cmd.Run()
 	assert.NoError(t, err)
+
+	cmd = sb.Cmd("du --verbose --tree")
+	err = cmd.Run()
+	assert.Error(t, err)
 }
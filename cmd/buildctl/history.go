@@ -0,0 +1,17 @@
+package main
+
+import (
+	"github.com/moby/buildkit/cmd/buildctl/history"
+	"github.com/urfave/cli"
+)
+
+var historyCommand = cli.Command{
+	Name:  "history",
+	Usage: "inspect the builds recorded by the daemon",
+	Subcommands: []cli.Command{
+		history.LsCommand,
+		history.InspectCommand,
+		history.LogsCommand,
+		history.RmCommand,
+	},
+}
@@ -0,0 +1,91 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var InspectCommand = cli.Command{
+	Name:      "inspect",
+	Usage:     "show the details of a recorded build",
+	ArgsUsage: "REF",
+	Action:    inspect,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "output format (text, json)",
+			Value: "text",
+		},
+	},
+}
+
+func inspect(clicontext *cli.Context) error {
+	if clicontext.NArg() != 1 {
+		return errors.Errorf("inspect requires exactly one build ref")
+	}
+	ev, err := getRecord(clicontext, clicontext.Args().First())
+	if err != nil {
+		return err
+	}
+	rec := ev.Record
+
+	switch clicontext.String("format") {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rec)
+	case "text", "":
+	default:
+		return errors.Errorf("invalid format %q", clicontext.String("format"))
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 1, 8, 1, '\t', 0)
+	fmt.Fprintf(tw, "Ref:\t%s\n", rec.Ref)
+	fmt.Fprintf(tw, "Status:\t%s\n", recordStatus(*ev))
+	if rec.Error != "" {
+		fmt.Fprintf(tw, "Error:\t%s\n", rec.Error)
+	}
+	if rec.CreatedAt != nil {
+		fmt.Fprintf(tw, "Created At:\t%s\n", rec.CreatedAt)
+	}
+	if rec.CompletedAt != nil {
+		fmt.Fprintf(tw, "Completed At:\t%s\n", rec.CompletedAt)
+	}
+	fmt.Fprintf(tw, "Duration:\t%s\n", duration(rec))
+	fmt.Fprintf(tw, "Steps:\t%d total, %d completed, %d cached\n", rec.NumTotalSteps, rec.NumCompletedSteps, rec.NumCachedSteps)
+	if rec.NumWarnings > 0 {
+		fmt.Fprintf(tw, "Warnings:\t%d\n", rec.NumWarnings)
+	}
+	fmt.Fprintf(tw, "Pinned:\t%v\n", rec.Pinned)
+	if rec.Frontend != "" {
+		fmt.Fprintf(tw, "Frontend:\t%s\n", rec.Frontend)
+	}
+	printMap(tw, "Frontend Attrs", rec.FrontendAttrs)
+	if rec.Exporter != "" {
+		fmt.Fprintf(tw, "Exporter:\t%s\n", rec.Exporter)
+	}
+	printMap(tw, "Exporter Attrs", rec.ExporterAttrs)
+	printMap(tw, "Exporter Response", rec.ExporterResponse)
+	return tw.Flush()
+}
+
+func printMap(tw *tabwriter.Writer, name string, m map[string]string) {
+	if len(m) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Fprintf(tw, "%s:\n", name)
+	for _, k := range keys {
+		fmt.Fprintf(tw, "\t%s:\t%s\n", k, m[k])
+	}
+}
@@ -0,0 +1,47 @@
+package history
+
+import (
+	"context"
+	"os"
+
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/moby/buildkit/util/progress/progresswriter"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var LogsCommand = cli.Command{
+	Name:      "logs",
+	Usage:     "print the progress and logs of a recorded build",
+	ArgsUsage: "REF",
+	Action:    logs,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "progress",
+			Usage: "Set type of progress (auto, plain, tty)",
+			Value: "plain",
+		},
+	},
+}
+
+func logs(clicontext *cli.Context) error {
+	if clicontext.NArg() != 1 {
+		return errors.Errorf("logs requires exactly one build ref")
+	}
+	c, err := bccommon.ResolveClient(clicontext)
+	if err != nil {
+		return err
+	}
+
+	pw, err := progresswriter.NewPrinter(context.TODO(), os.Stderr, clicontext.String("progress"))
+	if err != nil {
+		return err
+	}
+
+	err = c.BuildHistoryStatus(bccommon.CommandContext(clicontext), clicontext.Args().First(), pw.Status())
+	<-pw.Done()
+	if err != nil {
+		return err
+	}
+	return pw.Err()
+}
@@ -0,0 +1,137 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+)
+
+var LsCommand = cli.Command{
+	Name:   "ls",
+	Usage:  "list recorded builds",
+	Action: ls,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "active",
+			Usage: "only list builds that are still running",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "output format (table, json)",
+			Value: "table",
+		},
+	},
+}
+
+func ls(clicontext *cli.Context) error {
+	opts := []client.BuildHistoryOption{client.WithEarlyExit}
+	if clicontext.Bool("active") {
+		opts = append(opts, client.WithActiveOnly)
+	}
+	events, err := listEvents(clicontext, opts...)
+	if err != nil {
+		return err
+	}
+
+	switch clicontext.String("format") {
+	case "json":
+		recs := make([]*client.BuildHistoryRecord, 0, len(events))
+		for _, ev := range events {
+			recs = append(recs, ev.Record)
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(recs)
+	case "table", "":
+	default:
+		return errors.Errorf("invalid format %q", clicontext.String("format"))
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 1, 8, 1, '\t', 0)
+	fmt.Fprintln(tw, "REF\tSTATUS\tCREATED\tDURATION\tSTEPS\tFRONTEND")
+	for _, ev := range events {
+		rec := ev.Record
+		created := ""
+		if rec.CreatedAt != nil {
+			created = rec.CreatedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d/%d\t%s\n", rec.Ref, recordStatus(ev), created, duration(rec), rec.NumCompletedSteps, rec.NumTotalSteps, rec.Frontend)
+	}
+	return tw.Flush()
+}
+
+func listEvents(clicontext *cli.Context, opts ...client.BuildHistoryOption) ([]client.BuildHistoryEvent, error) {
+	c, err := bccommon.ResolveClient(clicontext)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan client.BuildHistoryEvent)
+	var events []client.BuildHistoryEvent
+
+	eg, ctx := errgroup.WithContext(bccommon.CommandContext(clicontext))
+	eg.Go(func() error {
+		defer close(ch)
+		return c.ListenBuildHistory(ctx, ch, opts...)
+	})
+	eg.Go(func() error {
+		for ev := range ch {
+			events = append(events, ev)
+		}
+		return nil
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i].Record.CreatedAt, events[j].Record.CreatedAt
+		if a == nil || b == nil {
+			return a == nil
+		}
+		return a.Before(*b)
+	})
+	return events, nil
+}
+
+func getRecord(clicontext *cli.Context, ref string) (*client.BuildHistoryEvent, error) {
+	events, err := listEvents(clicontext, client.WithEarlyExit, client.WithBuildHistoryRef(ref))
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, errors.Errorf("no build with ref %s", ref)
+	}
+	return &events[0], nil
+}
+
+func recordStatus(ev client.BuildHistoryEvent) string {
+	switch {
+	case ev.Type == client.BuildHistoryStarted:
+		return "running"
+	case ev.Record.Error != "":
+		return "error"
+	default:
+		return "completed"
+	}
+}
+
+func duration(rec *client.BuildHistoryRecord) string {
+	if rec.CreatedAt == nil {
+		return ""
+	}
+	end := time.Now()
+	if rec.CompletedAt != nil {
+		end = *rec.CompletedAt
+	}
+	return end.Sub(*rec.CreatedAt).Round(100 * time.Millisecond).String()
+}
@@ -0,0 +1,53 @@
+package history
+
+import (
+	"github.com/moby/buildkit/client"
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var RmCommand = cli.Command{
+	Name:      "rm",
+	Usage:     "remove recorded builds",
+	ArgsUsage: "REF...",
+	Action:    rm,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "remove all completed builds that are not pinned",
+		},
+	},
+}
+
+func rm(clicontext *cli.Context) error {
+	refs := []string(clicontext.Args())
+	if clicontext.Bool("all") {
+		if len(refs) > 0 {
+			return errors.Errorf("--all can't be combined with build refs")
+		}
+		events, err := listEvents(clicontext, client.WithEarlyExit)
+		if err != nil {
+			return err
+		}
+		for _, ev := range events {
+			if ev.Type == client.BuildHistoryComplete && !ev.Record.Pinned {
+				refs = append(refs, ev.Record.Ref)
+			}
+		}
+	} else if len(refs) == 0 {
+		return errors.Errorf("rm requires at least one build ref")
+	}
+
+	c, err := bccommon.ResolveClient(clicontext)
+	if err != nil {
+		return err
+	}
+	ctx := bccommon.CommandContext(clicontext)
+	for _, ref := range refs {
+		if err := c.DeleteBuildHistory(ctx, ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -85,6 +85,8 @@ func main() {
 		pruneCommand,
 		buildCommand,
 		debugCommand,
+		historyCommand,
+		cancelCommand,
 		dialStdioCommand,
 	}
 
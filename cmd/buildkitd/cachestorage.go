@@ -0,0 +1,154 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+	"github.com/moby/buildkit/cmd/buildkitd/config"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/bboltcachestorage"
+	"github.com/moby/buildkit/solver/sqlcachestorage"
+	"github.com/moby/buildkit/worker"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+const defaultCacheStorage = "bolt"
+
+type cacheStorageBackend struct {
+	// file is the default file name in the root directory
+	file string
+	open func(path string) (solver.CacheKeyStorage, error)
+}
+
+var cacheStorageBackends = map[string]cacheStorageBackend{
+	"bolt": {
+		file: "cache.db",
+		open: func(p string) (solver.CacheKeyStorage, error) {
+			return bboltcachestorage.NewStore(p)
+		},
+	},
+	"sqlite": {
+		file: "cache.sqlite",
+		open: func(p string) (solver.CacheKeyStorage, error) {
+			return sqlcachestorage.NewStore(p)
+		},
+	},
+}
+
+func cacheStoragePath(cfg *config.Config, typ string) (string, error) {
+	b, ok := cacheStorageBackends[typ]
+	if !ok {
+		return "", errors.Errorf("unknown cache storage type %q", typ)
+	}
+	if cfg.CacheStorage.Path != "" && cfg.CacheStorage.Type == typ {
+		return cfg.CacheStorage.Path, nil
+	}
+	return filepath.Join(cfg.Root, b.file), nil
+}
+
+func newCacheKeyStorage(cfg *config.Config) (solver.CacheKeyStorage, error) {
+	typ := cfg.CacheStorage.Type
+	if typ == "" {
+		typ = defaultCacheStorage
+	}
+	p, err := cacheStoragePath(cfg, typ)
+	if err != nil {
+		return nil, err
+	}
+	return cacheStorageBackends[typ].open(p)
+}
+
+const defaultCacheResultStorage = "worker"
+
+var cacheResultStorageBackends = map[string]func(wc *worker.Controller) solver.CacheResultStorage{
+	"worker": worker.NewCacheResultStorage,
+}
+
+func newCacheResultStorage(cfg *config.Config, wc *worker.Controller) (solver.CacheResultStorage, error) {
+	typ := cfg.CacheStorage.Results
+	if typ == "" {
+		typ = defaultCacheResultStorage
+	}
+	fn, ok := cacheResultStorageBackends[typ]
+	if !ok {
+		return nil, errors.Errorf("unknown cache result storage type %q", typ)
+	}
+	return fn(wc), nil
+}
+
+var migrateCacheStorageCommand = cli.Command{
+	Name:  "migrate-cache-storage",
+	Usage: "copy the solver cache index from the bolt storage to the sqlite storage",
+	Description: `Reads the bolt cache index and writes all keys, results and links to the
+sqlite cache index. buildkitd must not be running. Set "type" in the
+[cachestorage] section of the config to "sqlite" afterwards.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "from",
+			Usage: "path to the bolt cache index (default: <root>/cache.db)",
+		},
+		cli.StringFlag{
+			Name:  "to",
+			Usage: "path to the sqlite cache index (default: <root>/cache.sqlite or path from config)",
+		},
+	},
+	Action: migrateCacheStorage,
+}
+
+func migrateCacheStorage(c *cli.Context) error {
+	cfg, _, err := LoadFile(c.GlobalString("config"))
+	if err != nil {
+		return err
+	}
+	setDefaultConfig(&cfg)
+	if c.GlobalIsSet("root") {
+		cfg.Root = c.GlobalString("root")
+	}
+
+	lockPath := filepath.Join(cfg.Root, "buildkitd.lock")
+	lock := flock.New(lockPath)
+	locked, err := lock.TryLock()
+	if err != nil {
+		return errors.Wrapf(err, "could not lock %s", lockPath)
+	}
+	if !locked {
+		return errors.Errorf("could not lock %s, buildkitd running?", lockPath)
+	}
+	defer func() {
+		lock.Unlock()
+		os.RemoveAll(lockPath)
+	}()
+
+	from := c.String("from")
+	if from == "" {
+		if from, err = cacheStoragePath(&cfg, "bolt"); err != nil {
+			return err
+		}
+	}
+	to := c.String("to")
+	if to == "" {
+		if to, err = cacheStoragePath(&cfg, "sqlite"); err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Stat(from); err != nil {
+		return errors.Wrapf(err, "failed to open %s", from)
+	}
+	src, err := bboltcachestorage.NewStore(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := sqlcachestorage.NewStore(to)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	logrus.Infof("migrating cache index from %s to %s", from, to)
+	return dst.Migrate(src)
+}
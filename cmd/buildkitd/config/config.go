@@ -9,7 +9,7 @@ type Config struct {
 	// Root is the path to a directory where buildkit will store persistent data
 	Root string `toml:"root"`
 
-	//Entitlements e.g. security.insecure, network.host
+	//Entitlements e.g. security.insecure, network.host, device
 	Entitlements []string `toml:"insecure-entitlements"`
 	// GRPC configuration settings
 	GRPC GRPCConfig `toml:"grpc"`
@@ -22,6 +22,12 @@ type Config struct {
 	Registries map[string]RegistryConfig `toml:"registry"`
 
 	DNS *DNSConfig `toml:"dns"`
+
+	CacheStorage CacheStorageConfig `toml:"cachestorage"`
+
+	History *HistoryConfig `toml:"history"`
+
+	Limits *LimitsConfig `toml:"limits"`
 }
 
 type GRPCConfig struct {
@@ -82,6 +88,10 @@ type OCIConfig struct {
 	// For use in storing the OCI worker binary name that will replace buildkit-runc
 	Binary               string `toml:"binary"`
 	ProxySnapshotterPath string `toml:"proxySnapshotterPath"`
+	// CgroupParent is the cgroup the containers of the builds are created under
+	CgroupParent string `toml:"cgroupParent"`
+	// MaxParallelism limits the exec steps running at the same time
+	MaxParallelism int `toml:"maxParallelism"`
 
 	// StargzSnapshotterConfig is configuration for stargz snapshotter.
 	// Decoding this is delayed in order to remove the dependency from this
@@ -97,7 +107,9 @@ type ContainerdConfig struct {
 	Namespace string            `toml:"namespace"`
 	GCConfig
 	NetworkConfig
-	Snapshotter string `toml:"snapshotter"`
+	Snapshotter    string `toml:"snapshotter"`
+	CgroupParent   string `toml:"cgroupParent"`
+	MaxParallelism int    `toml:"maxParallelism"`
 }
 
 type GCPolicy struct {
@@ -105,6 +117,13 @@ type GCPolicy struct {
 	KeepBytes    int64    `toml:"keepBytes"`
 	KeepDuration int64    `toml:"keepDuration"`
 	Filters      []string `toml:"filters"`
+
+	// ReservedSpace is the minimum amount of cache never pruned by the policy.
+	ReservedSpace DiskSpace `toml:"reservedSpace"`
+	// MaxUsedSpace is the maximum amount of cache kept by the policy.
+	MaxUsedSpace DiskSpace `toml:"maxUsedSpace"`
+	// MinFreeSpace is the amount of free disk space the policy maintains.
+	MinFreeSpace DiskSpace `toml:"minFreeSpace"`
 }
 
 type DNSConfig struct {
@@ -112,3 +131,47 @@ type DNSConfig struct {
 	Options       []string `toml:"options"`
 	SearchDomains []string `toml:"searchDomains"`
 }
+
+// CacheStorageConfig selects the storage for the solver cache index
+type CacheStorageConfig struct {
+	// Type is "bolt" (default) or "sqlite"
+	Type string `toml:"type"`
+	// Path defaults to cache.db for bolt and cache.sqlite for sqlite in the root directory
+	Path string `toml:"path"`
+	// Results selects the storage that loads the results of cache records,
+	// "worker" (default) keeps them as refs of the workers
+	Results string `toml:"results"`
+}
+
+// HistoryConfig limits the records kept in the build history
+type HistoryConfig struct {
+	// MaxAge is the number of seconds a completed build is kept
+	MaxAge int64 `toml:"maxAge"`
+	// MaxEntries is the number of completed builds that are kept
+	MaxEntries int64 `toml:"maxEntries"`
+}
+
+// LimitsConfig sets the resource limits of the processes run by build steps
+type LimitsConfig struct {
+	// Default limits are used when a build step doesn't request a limit
+	Default ResourceLimitsConfig `toml:"default"`
+	// Max limits can't be exceeded by a build step
+	Max ResourceLimitsConfig `toml:"max"`
+	// Devices are the host devices build steps are allowed to use, as paths
+	// or patterns like /dev/dri/*
+	Devices []string `toml:"devices"`
+}
+
+type ResourceLimitsConfig struct {
+	// Memory is the memory limit in bytes
+	Memory int64 `toml:"memory"`
+	// CPUShares is the relative cpu weight
+	CPUShares int64 `toml:"cpuShares"`
+	// CPUQuota is the cpu time in microseconds per CPUPeriod
+	CPUQuota  int64 `toml:"cpuQuota"`
+	CPUPeriod int64 `toml:"cpuPeriod"`
+	// Pids is the maximum number of processes
+	Pids int64 `toml:"pids"`
+	// Ulimits are in the "name=soft[:hard]" format
+	Ulimits []string `toml:"ulimits"`
+}
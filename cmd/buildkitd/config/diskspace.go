@@ -0,0 +1,65 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DiskSpace is an amount of disk space given either in bytes, with an
+// optional unit suffix ("512MB", "10GiB"), or as a percentage of the
+// filesystem holding the buildkit root ("10%").
+type DiskSpace struct {
+	Bytes      int64
+	Percentage int64
+}
+
+var diskSpaceUnits = []struct {
+	suffix string
+	mult   int64
+}{
+	{"KiB", 1 << 10},
+	{"MiB", 1 << 20},
+	{"GiB", 1 << 30},
+	{"TiB", 1 << 40},
+	{"KB", 1e3},
+	{"MB", 1e6},
+	{"GB", 1e9},
+	{"TB", 1e12},
+	{"B", 1},
+}
+
+func (d *DiskSpace) UnmarshalText(textb []byte) error {
+	text := strings.TrimSpace(string(textb))
+	if strings.HasSuffix(text, "%") {
+		p, err := strconv.ParseInt(strings.TrimSpace(strings.TrimSuffix(text, "%")), 10, 64)
+		if err != nil || p < 0 || p > 100 {
+			return errors.Errorf("invalid disk space percentage %q", text)
+		}
+		*d = DiskSpace{Percentage: p}
+		return nil
+	}
+	mult := int64(1)
+	for _, u := range diskSpaceUnits {
+		if strings.HasSuffix(text, u.suffix) {
+			text = strings.TrimSpace(strings.TrimSuffix(text, u.suffix))
+			mult = u.mult
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil || v < 0 {
+		return errors.Errorf("invalid disk space %q", string(textb))
+	}
+	*d = DiskSpace{Bytes: int64(v * float64(mult))}
+	return nil
+}
+
+// AsBytes returns the disk space in bytes for a filesystem of the given size.
+func (d DiskSpace) AsBytes(total int64) int64 {
+	if d.Bytes != 0 {
+		return d.Bytes
+	}
+	return total * d.Percentage / 100
+}
@@ -39,6 +39,8 @@ foo="bar"
 namespace="non-default"
 platforms=["linux/amd64"]
 address="containerd.sock"
+cgroupParent="/builds"
+maxParallelism=4
 [[worker.containerd.gcpolicy]]
 all=true
 filters=["foo==bar"]
@@ -47,6 +49,10 @@ keepDuration=3600
 [[worker.containerd.gcpolicy]]
 keepBytes=40
 keepDuration=7200
+[[worker.containerd.gcpolicy]]
+reservedSpace="2GB"
+maxUsedSpace=1048576
+minFreeSpace="20%"
 
 [registry."docker.io"]
 mirrors=["hub.docker.io"]
@@ -62,6 +68,24 @@ cert="cert.pem"
 nameservers=["1.1.1.1","8.8.8.8"]
 options=["edns0"]
 searchDomains=["example.com"]
+
+[cachestorage]
+type="sqlite"
+path="/foo/cache.sqlite"
+results="worker"
+
+[history]
+maxAge=3600
+maxEntries=20
+
+[limits]
+devices=["/dev/fuse"]
+[limits.default]
+memory=1073741824
+pids=100
+ulimits=["nofile=1024:2048"]
+[limits.max]
+cpuQuota=200000
 `
 
 	cfg, md, err := Load(bytes.NewBuffer([]byte(testConfig)))
@@ -92,10 +116,12 @@ searchDomains=["example.com"]
 	require.Nil(t, cfg.Workers.Containerd.Enabled)
 	require.Equal(t, 1, len(cfg.Workers.Containerd.Platforms))
 	require.Equal(t, "containerd.sock", cfg.Workers.Containerd.Address)
+	require.Equal(t, "/builds", cfg.Workers.Containerd.CgroupParent)
+	require.Equal(t, 4, cfg.Workers.Containerd.MaxParallelism)
 
 	require.Equal(t, 0, len(cfg.Workers.OCI.GCPolicy))
 	require.Equal(t, "non-default", cfg.Workers.Containerd.Namespace)
-	require.Equal(t, 2, len(cfg.Workers.Containerd.GCPolicy))
+	require.Equal(t, 3, len(cfg.Workers.Containerd.GCPolicy))
 
 	require.Nil(t, cfg.Workers.Containerd.GC)
 	require.Equal(t, true, cfg.Workers.Containerd.GCPolicy[0].All)
@@ -106,6 +132,9 @@ searchDomains=["example.com"]
 	require.Equal(t, int64(7200), cfg.Workers.Containerd.GCPolicy[1].KeepDuration)
 	require.Equal(t, 1, len(cfg.Workers.Containerd.GCPolicy[0].Filters))
 	require.Equal(t, 0, len(cfg.Workers.Containerd.GCPolicy[1].Filters))
+	require.Equal(t, int64(2e9), cfg.Workers.Containerd.GCPolicy[2].ReservedSpace.AsBytes(100))
+	require.Equal(t, int64(1048576), cfg.Workers.Containerd.GCPolicy[2].MaxUsedSpace.AsBytes(100))
+	require.Equal(t, int64(20), cfg.Workers.Containerd.GCPolicy[2].MinFreeSpace.AsBytes(100))
 
 	require.Equal(t, *cfg.Registries["docker.io"].PlainHTTP, true)
 	require.Equal(t, *cfg.Registries["docker.io"].Insecure, true)
@@ -119,4 +148,19 @@ searchDomains=["example.com"]
 	require.Equal(t, cfg.DNS.Nameservers, []string{"1.1.1.1", "8.8.8.8"})
 	require.Equal(t, cfg.DNS.SearchDomains, []string{"example.com"})
 	require.Equal(t, cfg.DNS.Options, []string{"edns0"})
+
+	require.Equal(t, "sqlite", cfg.CacheStorage.Type)
+	require.Equal(t, "/foo/cache.sqlite", cfg.CacheStorage.Path)
+	require.Equal(t, "worker", cfg.CacheStorage.Results)
+
+	require.NotNil(t, cfg.History)
+	require.Equal(t, int64(3600), cfg.History.MaxAge)
+	require.Equal(t, int64(20), cfg.History.MaxEntries)
+
+	require.NotNil(t, cfg.Limits)
+	require.Equal(t, int64(1073741824), cfg.Limits.Default.Memory)
+	require.Equal(t, int64(100), cfg.Limits.Default.Pids)
+	require.Equal(t, []string{"nofile=1024:2048"}, cfg.Limits.Default.Ulimits)
+	require.Equal(t, int64(200000), cfg.Limits.Max.CPUQuota)
+	require.Equal(t, []string{"/dev/fuse"}, cfg.Limits.Devices)
 }
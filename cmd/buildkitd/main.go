@@ -27,23 +27,26 @@ import (
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/moby/buildkit/cache/remotecache"
+	httpremotecache "github.com/moby/buildkit/cache/remotecache/httpcas"
 	inlineremotecache "github.com/moby/buildkit/cache/remotecache/inline"
 	localremotecache "github.com/moby/buildkit/cache/remotecache/local"
 	registryremotecache "github.com/moby/buildkit/cache/remotecache/registry"
 	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/cmd/buildkitd/config"
 	"github.com/moby/buildkit/control"
+	"github.com/moby/buildkit/control/history"
 	"github.com/moby/buildkit/executor/oci"
 	"github.com/moby/buildkit/frontend"
 	dockerfile "github.com/moby/buildkit/frontend/dockerfile/builder"
 	"github.com/moby/buildkit/frontend/gateway"
 	"github.com/moby/buildkit/frontend/gateway/forwarder"
 	"github.com/moby/buildkit/session"
-	"github.com/moby/buildkit/solver/bboltcachestorage"
+	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/apicaps"
 	"github.com/moby/buildkit/util/appcontext"
 	"github.com/moby/buildkit/util/appdefaults"
 	"github.com/moby/buildkit/util/archutil"
+	"github.com/moby/buildkit/util/disk"
 	"github.com/moby/buildkit/util/grpcerrors"
 	"github.com/moby/buildkit/util/profiler"
 	"github.com/moby/buildkit/util/resolver"
@@ -54,6 +57,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
+	bolt "go.etcd.io/bbolt"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 )
@@ -173,11 +177,15 @@ func main() {
 		},
 		cli.StringSliceFlag{
 			Name:  "allow-insecure-entitlement",
-			Usage: "allows insecure entitlements e.g. network.host, security.insecure",
+			Usage: "allows insecure entitlements e.g. network.host, security.insecure, device, cgroup.parent",
 		},
 	)
 	app.Flags = append(app.Flags, appFlags...)
 
+	app.Commands = []cli.Command{
+		migrateCacheStorageCommand,
+	}
+
 	app.Action = func(c *cli.Context) error {
 		// TODO: On Windows this always returns -1. The actual "are you admin" check is very Windows-specific.
 		// See https://github.com/golang/go/issues/28804#issuecomment-505326268 for the "short" version.
@@ -253,6 +261,10 @@ func main() {
 					cfg.Entitlements = append(cfg.Entitlements, e)
 				case "network.host":
 					cfg.Entitlements = append(cfg.Entitlements, e)
+				case "device":
+					cfg.Entitlements = append(cfg.Entitlements, e)
+				case "cgroup.parent":
+					cfg.Entitlements = append(cfg.Entitlements, e)
 				default:
 					return fmt.Errorf("invalid entitlement : %v", e)
 				}
@@ -598,7 +610,17 @@ func newController(c *cli.Context, cfg *config.Config, md *toml.MetaData) (*cont
 	frontends["dockerfile.v0"] = forwarder.NewGatewayForwarder(wc, dockerfile.Build)
 	frontends["gateway.v0"] = gateway.NewGatewayFrontend(wc)
 
-	cacheStorage, err := bboltcachestorage.NewStore(filepath.Join(cfg.Root, "cache.db"))
+	cacheStorage, err := newCacheKeyStorage(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheResultStorage, err := newCacheResultStorage(cfg, wc)
+	if err != nil {
+		return nil, err
+	}
+
+	historyStore, err := newHistoryStore(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -614,10 +636,12 @@ func newController(c *cli.Context, cfg *config.Config, md *toml.MetaData) (*cont
 		"registry": registryremotecache.ResolveCacheExporterFunc(sessionManager, resolverFn),
 		"local":    localremotecache.ResolveCacheExporterFunc(sessionManager),
 		"inline":   inlineremotecache.ResolveCacheExporterFunc(),
+		"http":     httpremotecache.ResolveCacheExporterFunc(sessionManager),
 	}
 	remoteCacheImporterFuncs := map[string]remotecache.ResolveCacheImporterFunc{
 		"registry": registryremotecache.ResolveCacheImporterFunc(sessionManager, w.ContentStore(), resolverFn),
 		"local":    localremotecache.ResolveCacheImporterFunc(sessionManager),
+		"http":     httpremotecache.ResolveCacheImporterFunc(sessionManager),
 	}
 	return control.NewController(control.Opt{
 		SessionManager:            sessionManager,
@@ -626,10 +650,25 @@ func newController(c *cli.Context, cfg *config.Config, md *toml.MetaData) (*cont
 		ResolveCacheExporterFuncs: remoteCacheExporterFuncs,
 		ResolveCacheImporterFuncs: remoteCacheImporterFuncs,
 		CacheKeyStorage:           cacheStorage,
+		CacheResultStorage:        cacheResultStorage,
 		Entitlements:              cfg.Entitlements,
+		HistoryStore:              historyStore,
 	})
 }
 
+func newHistoryStore(cfg *config.Config) (*history.Store, error) {
+	db, err := bolt.Open(filepath.Join(cfg.Root, "history.db"), 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	opt := history.Opt{DB: db}
+	if cfg.History != nil {
+		opt.MaxAge = time.Duration(cfg.History.MaxAge) * time.Second
+		opt.MaxEntries = int(cfg.History.MaxEntries)
+	}
+	return history.New(opt)
+}
+
 func resolverFunc(cfg *config.Config) docker.RegistryHosts {
 	return resolver.NewRegistryConfig(cfg.Registries)
 }
@@ -703,18 +742,68 @@ func getGCPolicy(cfg config.GCConfig, root string) []client.PruneInfo {
 	if len(cfg.GCPolicy) == 0 {
 		cfg.GCPolicy = config.DefaultGCPolicy(root, cfg.GCKeepStorage)
 	}
+	var total int64
+	if dstat, err := disk.GetDiskStat(root); err == nil {
+		total = dstat.Total
+	} else {
+		logrus.Warnf("failed to stat %s, disk space percentages in gc policy are ignored: %v", root, err)
+	}
 	out := make([]client.PruneInfo, 0, len(cfg.GCPolicy))
 	for _, rule := range cfg.GCPolicy {
 		out = append(out, client.PruneInfo{
-			Filter:       rule.Filters,
-			All:          rule.All,
-			KeepBytes:    rule.KeepBytes,
-			KeepDuration: time.Duration(rule.KeepDuration) * time.Second,
+			Filter:        rule.Filters,
+			All:           rule.All,
+			KeepBytes:     rule.KeepBytes,
+			KeepDuration:  time.Duration(rule.KeepDuration) * time.Second,
+			ReservedSpace: rule.ReservedSpace.AsBytes(total),
+			MaxUsedSpace:  rule.MaxUsedSpace.AsBytes(total),
+			MinFreeSpace:  rule.MinFreeSpace.AsBytes(total),
 		})
 	}
 	return out
 }
 
+func getLimits(cfg *config.LimitsConfig) (*oci.Limits, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	limits := &oci.Limits{
+		Default: resourceLimits(cfg.Default),
+		Max:     resourceLimits(cfg.Max),
+		Devices: cfg.Devices,
+	}
+	var err error
+	if limits.DefaultUlimits, err = parseUlimits(cfg.Default.Ulimits); err != nil {
+		return nil, err
+	}
+	if limits.MaxUlimits, err = parseUlimits(cfg.Max.Ulimits); err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+func resourceLimits(cfg config.ResourceLimitsConfig) pb.ResourceLimits {
+	return pb.ResourceLimits{
+		Memory:    cfg.Memory,
+		CpuShares: cfg.CPUShares,
+		CpuQuota:  cfg.CPUQuota,
+		CpuPeriod: cfg.CPUPeriod,
+		Pids:      cfg.Pids,
+	}
+}
+
+func parseUlimits(in []string) ([]*pb.Ulimit, error) {
+	var out []*pb.Ulimit
+	for _, v := range in {
+		u, err := pb.ParseUlimit(v)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	return out, nil
+}
+
 func getDNSConfig(cfg *config.DNSConfig) *oci.DNSConfig {
 	var dns *oci.DNSConfig
 	if cfg != nil {
@@ -2,6 +2,7 @@ package control
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/moby/buildkit/cache/remotecache"
 	"github.com/moby/buildkit/client"
 	controlgateway "github.com/moby/buildkit/control/gateway"
+	"github.com/moby/buildkit/control/history"
 	"github.com/moby/buildkit/exporter"
 	"github.com/moby/buildkit/frontend"
 	"github.com/moby/buildkit/session"
@@ -32,9 +34,12 @@ type Opt struct {
 	WorkerController          *worker.Controller
 	Frontends                 map[string]frontend.Frontend
 	CacheKeyStorage           solver.CacheKeyStorage
+	CacheResultStorage        solver.CacheResultStorage
 	ResolveCacheExporterFuncs map[string]remotecache.ResolveCacheExporterFunc
 	ResolveCacheImporterFuncs map[string]remotecache.ResolveCacheImporterFunc
 	Entitlements              []string
+	// HistoryStore records completed builds if set
+	HistoryStore *history.Store
 }
 
 type Controller struct { // TODO: ControlService
@@ -45,10 +50,17 @@ type Controller struct { // TODO: ControlService
 	gatewayForwarder *controlgateway.GatewayForwarder
 	throttledGC      func()
 	gcmu             sync.Mutex
+
+	jobsMu sync.Mutex
+	jobs   map[string]*activeJob
 }
 
 func NewController(opt Opt) (*Controller, error) {
-	cache := solver.NewCacheManager("local", opt.CacheKeyStorage, worker.NewCacheResultStorage(opt.WorkerController))
+	results := opt.CacheResultStorage
+	if results == nil {
+		results = worker.NewCacheResultStorage(opt.WorkerController)
+	}
+	cache := solver.NewCacheManager("local", opt.CacheKeyStorage, results)
 
 	gatewayForwarder := controlgateway.NewGatewayForwarder()
 
@@ -62,6 +74,7 @@ func NewController(opt Opt) (*Controller, error) {
 		solver:           solver,
 		cache:            cache,
 		gatewayForwarder: gatewayForwarder,
+		jobs:             map[string]*activeJob{},
 	}
 	c.throttledGC = throttle.After(time.Minute, c.gc)
 
@@ -95,17 +108,22 @@ func (c *Controller) DiskUsage(ctx context.Context, r *controlapi.DiskUsageReque
 		for _, r := range du {
 			resp.Record = append(resp.Record, &controlapi.UsageRecord{
 				// TODO: add worker info
-				ID:          r.ID,
-				Mutable:     r.Mutable,
-				InUse:       r.InUse,
-				Size_:       r.Size,
-				Parent:      r.Parent,
-				UsageCount:  int64(r.UsageCount),
-				Description: r.Description,
-				CreatedAt:   r.CreatedAt,
-				LastUsedAt:  r.LastUsedAt,
-				RecordType:  string(r.RecordType),
-				Shared:      r.Shared,
+				ID:            r.ID,
+				Mutable:       r.Mutable,
+				InUse:         r.InUse,
+				Size_:         r.Size,
+				Parent:        r.Parent,
+				UsageCount:    int64(r.UsageCount),
+				Description:   r.Description,
+				CreatedAt:     r.CreatedAt,
+				LastUsedAt:    r.LastUsedAt,
+				RecordType:    string(r.RecordType),
+				Shared:        r.Shared,
+				CacheMountID:  r.CacheMountID,
+				SourceOp:      r.SourceOp,
+				BuildRef:      r.BuildRef,
+				LastUsedBuild: r.LastUsedBuild,
+				Frontend:      r.Frontend,
 			})
 		}
 	}
@@ -141,11 +159,27 @@ func (c *Controller) Prune(req *controlapi.PruneRequest, stream controlapi.Contr
 	for _, w := range workers {
 		func(w worker.Worker) {
 			eg.Go(func() error {
+				if req.GcPolicy {
+					policy := w.GCPolicy()
+					if len(policy) == 0 {
+						return nil
+					}
+					opts := make([]client.PruneInfo, 0, len(policy))
+					for _, p := range policy {
+						p.DryRun = req.DryRun
+						opts = append(opts, p)
+					}
+					return w.Prune(ctx, ch, opts...)
+				}
 				return w.Prune(ctx, ch, client.PruneInfo{
-					Filter:       req.Filter,
-					All:          req.All,
-					KeepDuration: time.Duration(req.KeepDuration),
-					KeepBytes:    req.KeepBytes,
+					Filter:        req.Filter,
+					All:           req.All,
+					KeepDuration:  time.Duration(req.KeepDuration),
+					KeepBytes:     req.KeepBytes,
+					ReservedSpace: req.ReservedSpace,
+					MaxUsedSpace:  req.MaxUsedSpace,
+					MinFreeSpace:  req.MinFreeSpace,
+					DryRun:        req.DryRun,
 				})
 			})
 		}(w)
@@ -160,20 +194,25 @@ func (c *Controller) Prune(req *controlapi.PruneRequest, stream controlapi.Contr
 
 	eg2.Go(func() error {
 		for r := range ch {
-			didPrune = true
+			didPrune = !req.DryRun
 			if err := stream.Send(&controlapi.UsageRecord{
 				// TODO: add worker info
-				ID:          r.ID,
-				Mutable:     r.Mutable,
-				InUse:       r.InUse,
-				Size_:       r.Size,
-				Parent:      r.Parent,
-				UsageCount:  int64(r.UsageCount),
-				Description: r.Description,
-				CreatedAt:   r.CreatedAt,
-				LastUsedAt:  r.LastUsedAt,
-				RecordType:  string(r.RecordType),
-				Shared:      r.Shared,
+				ID:            r.ID,
+				Mutable:       r.Mutable,
+				InUse:         r.InUse,
+				Size_:         r.Size,
+				Parent:        r.Parent,
+				UsageCount:    int64(r.UsageCount),
+				Description:   r.Description,
+				CreatedAt:     r.CreatedAt,
+				LastUsedAt:    r.LastUsedAt,
+				RecordType:    string(r.RecordType),
+				Shared:        r.Shared,
+				CacheMountID:  r.CacheMountID,
+				SourceOp:      r.SourceOp,
+				BuildRef:      r.BuildRef,
+				LastUsedBuild: r.LastUsedBuild,
+				Frontend:      r.Frontend,
 			}); err != nil {
 				return err
 			}
@@ -221,6 +260,16 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 		return nil, err
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if req.Ref != "" {
+		release, err := c.registerJob(req, cancel)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
 	defer func() {
 		time.AfterFunc(time.Second, c.throttledGC)
 	}()
@@ -246,6 +295,7 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 	var (
 		cacheExporter   remotecache.Exporter
 		cacheExportMode solver.CacheExportMode
+		cacheMounts     []string
 		cacheImports    []frontend.CacheOptionsEntry
 	)
 	if len(req.Cache.Exports) > 1 {
@@ -264,6 +314,7 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 			return nil, err
 		}
 		cacheExportMode = parseCacheExportMode(e.Attrs["mode"])
+		cacheMounts = parseCacheMounts(e.Attrs["cache-mounts"])
 	}
 	for _, im := range req.Cache.Imports {
 		cacheImports = append(cacheImports, frontend.CacheOptionsEntry{
@@ -272,6 +323,14 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 		})
 	}
 
+	var rec *historyRecorder
+	if c.opt.HistoryStore != nil {
+		rec, err = c.recordHistory(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	resp, err := c.solver.Solve(ctx, req.Ref, req.Session, frontend.SolveRequest{
 		Frontend:       req.Frontend,
 		Definition:     req.Definition,
@@ -282,7 +341,14 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 		Exporter:        expi,
 		CacheExporter:   cacheExporter,
 		CacheExportMode: cacheExportMode,
-	}, req.Entitlements)
+		CacheMounts:     cacheMounts,
+	}, req.Entitlements, llbsolver.JobOpt{
+		CgroupParent:   req.CgroupParent,
+		MaxParallelism: int(req.MaxParallelism),
+	})
+	if rec != nil {
+		rec.complete(resp, err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -292,6 +358,13 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 }
 
 func (c *Controller) Status(req *controlapi.StatusRequest, stream controlapi.Control_StatusServer) error {
+	if c.opt.HistoryStore != nil {
+		// completed builds are replayed from the history
+		if rec, err := c.opt.HistoryStore.Get(req.Ref); err == nil && rec.CompletedAt != nil {
+			return c.opt.HistoryStore.Status(stream.Context(), req.Ref, stream.Send)
+		}
+	}
+
 	ch := make(chan *client.SolveStatus, 8)
 
 	eg, ctx := errgroup.WithContext(stream.Context())
@@ -305,56 +378,8 @@ func (c *Controller) Status(req *controlapi.StatusRequest, stream controlapi.Con
 			if !ok {
 				return nil
 			}
-			logSize := 0
-			retry := false
-			for {
-				sr := controlapi.StatusResponse{}
-				for _, v := range ss.Vertexes {
-					sr.Vertexes = append(sr.Vertexes, &controlapi.Vertex{
-						Digest:    v.Digest,
-						Inputs:    v.Inputs,
-						Name:      v.Name,
-						Started:   v.Started,
-						Completed: v.Completed,
-						Error:     v.Error,
-						Cached:    v.Cached,
-					})
-				}
-				for _, v := range ss.Statuses {
-					sr.Statuses = append(sr.Statuses, &controlapi.VertexStatus{
-						ID:        v.ID,
-						Vertex:    v.Vertex,
-						Name:      v.Name,
-						Current:   v.Current,
-						Total:     v.Total,
-						Timestamp: v.Timestamp,
-						Started:   v.Started,
-						Completed: v.Completed,
-					})
-				}
-				for i, v := range ss.Logs {
-					sr.Logs = append(sr.Logs, &controlapi.VertexLog{
-						Vertex:    v.Vertex,
-						Stream:    int64(v.Stream),
-						Msg:       v.Data,
-						Timestamp: v.Timestamp,
-					})
-					logSize += len(v.Data)
-					// avoid logs growing big and split apart if they do
-					if logSize > 1024*1024 {
-						ss.Vertexes = nil
-						ss.Statuses = nil
-						ss.Logs = ss.Logs[i+1:]
-						retry = true
-						break
-					}
-				}
-				if err := stream.SendMsg(&sr); err != nil {
-					return err
-				}
-				if !retry {
-					break
-				}
+			if err := sendStatus(ss, stream.Send); err != nil {
+				return err
 			}
 		}
 	})
@@ -362,6 +387,85 @@ func (c *Controller) Status(req *controlapi.StatusRequest, stream controlapi.Con
 	return eg.Wait()
 }
 
+// sendStatus converts a status update to responses for the API. Updates with
+// large logs are split into multiple responses.
+func sendStatus(ss *client.SolveStatus, send func(*controlapi.StatusResponse) error) error {
+	for {
+		logSize := 0
+		retry := false
+		sr := controlapi.StatusResponse{}
+		for _, v := range ss.Vertexes {
+			sr.Vertexes = append(sr.Vertexes, &controlapi.Vertex{
+				Digest:    v.Digest,
+				Inputs:    v.Inputs,
+				Name:      v.Name,
+				Started:   v.Started,
+				Completed: v.Completed,
+				Error:     v.Error,
+				Cached:    v.Cached,
+			})
+		}
+		for _, v := range ss.Statuses {
+			sr.Statuses = append(sr.Statuses, &controlapi.VertexStatus{
+				ID:        v.ID,
+				Vertex:    v.Vertex,
+				Name:      v.Name,
+				Current:   v.Current,
+				Total:     v.Total,
+				Timestamp: v.Timestamp,
+				Started:   v.Started,
+				Completed: v.Completed,
+			})
+		}
+		for _, v := range ss.Warnings {
+			sr.Warnings = append(sr.Warnings, &controlapi.VertexWarning{
+				Vertex: v.Vertex,
+				Level:  int64(v.Level),
+				Short:  v.Short,
+				Detail: v.Detail,
+				Url:    v.URL,
+				Info:   v.SourceInfo,
+				Ranges: v.Range,
+			})
+		}
+		for _, v := range ss.Resources {
+			sr.Resources = append(sr.Resources, &controlapi.VertexResourceUsage{
+				Vertex:       v.Vertex,
+				CpuNanos:     int64(v.CPUTime),
+				MemoryPeak:   v.MemoryPeak,
+				IoReadBytes:  v.IOReadBytes,
+				IoWriteBytes: v.IOWriteBytes,
+				PidsPeak:     v.PidsPeak,
+			})
+		}
+		for i, v := range ss.Logs {
+			sr.Logs = append(sr.Logs, &controlapi.VertexLog{
+				Vertex:    v.Vertex,
+				Stream:    int64(v.Stream),
+				Msg:       v.Data,
+				Timestamp: v.Timestamp,
+			})
+			logSize += len(v.Data)
+			// avoid logs growing big and split apart if they do
+			if logSize > 1024*1024 {
+				ss.Vertexes = nil
+				ss.Statuses = nil
+				ss.Warnings = nil
+				ss.Resources = nil
+				ss.Logs = ss.Logs[i+1:]
+				retry = true
+				break
+			}
+		}
+		if err := send(&sr); err != nil {
+			return err
+		}
+		if !retry {
+			return nil
+		}
+	}
+}
+
 func (c *Controller) Session(stream controlapi.Control_SessionServer) error {
 	logrus.Debugf("session started")
 	conn, closeCh, opts := grpchijack.Hijack(stream)
@@ -451,14 +555,27 @@ func parseCacheExportMode(mode string) solver.CacheExportMode {
 	return solver.CacheExportModeMin
 }
 
+func parseCacheMounts(v string) []string {
+	var ids []string
+	for _, id := range strings.Split(v, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func toPBGCPolicy(in []client.PruneInfo) []*apitypes.GCPolicy {
 	policy := make([]*apitypes.GCPolicy, 0, len(in))
 	for _, p := range in {
 		policy = append(policy, &apitypes.GCPolicy{
-			All:          p.All,
-			KeepBytes:    p.KeepBytes,
-			KeepDuration: int64(p.KeepDuration),
-			Filters:      p.Filter,
+			All:           p.All,
+			KeepBytes:     p.KeepBytes,
+			KeepDuration:  int64(p.KeepDuration),
+			Filters:       p.Filter,
+			ReservedSpace: p.ReservedSpace,
+			MaxUsedSpace:  p.MaxUsedSpace,
+			MinFreeSpace:  p.MinFreeSpace,
 		})
 	}
 	return policy
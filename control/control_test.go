@@ -0,0 +1,50 @@
+package control
+
+import (
+	"bytes"
+	"testing"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendStatusSplitLogs(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("a"), 600*1024)
+	ss := &client.SolveStatus{
+		Vertexes: []*client.Vertex{{Digest: "sha256:foo"}},
+		Logs: []*client.VertexLog{
+			{Vertex: "sha256:foo", Data: data},
+			{Vertex: "sha256:foo", Data: data},
+			{Vertex: "sha256:foo", Data: data},
+		},
+		Warnings: []*client.VertexWarning{
+			{Vertex: "sha256:foo", Short: []byte("warning")},
+		},
+		Resources: []*client.VertexResourceUsage{
+			{Vertex: "sha256:foo", MemoryPeak: 1024},
+		},
+	}
+
+	var responses []*controlapi.StatusResponse
+	err := sendStatus(ss, func(sr *controlapi.StatusResponse) error {
+		responses = append(responses, sr)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(responses))
+
+	require.Equal(t, 1, len(responses[0].Vertexes))
+	require.Equal(t, 2, len(responses[0].Logs))
+	require.Equal(t, 1, len(responses[0].Warnings))
+	require.Equal(t, "warning", string(responses[0].Warnings[0].Short))
+	require.Equal(t, 1, len(responses[0].Resources))
+	require.Equal(t, uint64(1024), responses[0].Resources[0].MemoryPeak)
+
+	require.Equal(t, 0, len(responses[1].Vertexes))
+	require.Equal(t, 1, len(responses[1].Logs))
+	require.Equal(t, 0, len(responses[1].Warnings))
+	require.Equal(t, 0, len(responses[1].Resources))
+}
@@ -0,0 +1,113 @@
+package control
+
+import (
+	"context"
+	"time"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/control/history"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c *Controller) ListenBuildHistory(req *controlapi.BuildHistoryRequest, stream controlapi.Control_ListenBuildHistoryServer) error {
+	if c.opt.HistoryStore == nil {
+		return status.Errorf(codes.Unimplemented, "build history is not enabled")
+	}
+	return c.opt.HistoryStore.Listen(stream.Context(), req, stream.Send)
+}
+
+func (c *Controller) UpdateBuildHistory(ctx context.Context, req *controlapi.UpdateBuildHistoryRequest) (*controlapi.UpdateBuildHistoryResponse, error) {
+	if c.opt.HistoryStore == nil {
+		return nil, status.Errorf(codes.Unimplemented, "build history is not enabled")
+	}
+	if err := c.opt.HistoryStore.Update(req); err != nil {
+		if errors.Is(err, history.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	return &controlapi.UpdateBuildHistoryResponse{}, nil
+}
+
+// historyRecorder stores the status of a build in the history while the
+// build is running.
+type historyRecorder struct {
+	store    *history.Store
+	rec      *controlapi.BuildHistoryRecord
+	vertexes map[digest.Digest]*client.Vertex
+	done     chan struct{}
+}
+
+func (c *Controller) recordHistory(ctx context.Context, req *controlapi.SolveRequest) (*historyRecorder, error) {
+	now := time.Now()
+	r := &historyRecorder{
+		store: c.opt.HistoryStore,
+		rec: &controlapi.BuildHistoryRecord{
+			Ref:           req.Ref,
+			Frontend:      req.Frontend,
+			FrontendAttrs: req.FrontendAttrs,
+			Exporter:      req.Exporter,
+			ExporterAttrs: req.ExporterAttrs,
+			CreatedAt:     &now,
+		},
+		vertexes: map[digest.Digest]*client.Vertex{},
+		done:     make(chan struct{}),
+	}
+	if err := r.store.Start(r.rec); err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *client.SolveStatus, 8)
+	go func() {
+		if err := c.solver.Status(ctx, req.Ref, ch); err != nil {
+			logrus.Debugf("failed to record status of build %s: %v", req.Ref, err)
+		}
+	}()
+	go func() {
+		defer close(r.done)
+		for ss := range ch {
+			for _, v := range ss.Vertexes {
+				r.vertexes[v.Digest] = v
+			}
+			r.rec.NumWarnings += int32(len(ss.Warnings))
+			if err := sendStatus(ss, func(sr *controlapi.StatusResponse) error {
+				return r.store.AddStatus(req.Ref, sr)
+			}); err != nil {
+				logrus.Warnf("failed to record status of build %s: %v", req.Ref, err)
+			}
+		}
+	}()
+	return r, nil
+}
+
+// complete waits for the status stream of the build to finish and stores the
+// result of the build.
+func (r *historyRecorder) complete(resp *client.SolveResponse, err error) {
+	<-r.done
+
+	now := time.Now()
+	r.rec.CompletedAt = &now
+	if err != nil {
+		r.rec.Error = err.Error()
+	}
+	if resp != nil {
+		r.rec.ExporterResponse = resp.ExporterResponse
+	}
+	for _, v := range r.vertexes {
+		r.rec.NumTotalSteps++
+		if v.Cached {
+			r.rec.NumCachedSteps++
+		}
+		if v.Completed != nil {
+			r.rec.NumCompletedSteps++
+		}
+	}
+	if err := r.store.Complete(r.rec); err != nil {
+		logrus.Warnf("failed to record build %s: %v", r.rec.Ref, err)
+	}
+}
@@ -0,0 +1,540 @@
+package history
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	recordsBucket = "_records"
+	statusBucket  = "_status"
+
+	defaultMaxAge     = 48 * time.Hour
+	defaultMaxEntries = 50
+
+	// maxStatusSize limits the size of the stored status of a single build.
+	// Logs above the limit are dropped, vertex updates are still stored.
+	maxStatusSize = 32 * 1024 * 1024
+
+	// Status updates of active builds are buffered and written together once
+	// the buffer reaches statusFlushSize or statusFlushInterval has passed
+	// since the last write, and when the build completes.
+	statusFlushSize     = 1024 * 1024
+	statusFlushInterval = time.Second
+)
+
+// ErrNotFound is returned when there is no record for a build ref.
+var ErrNotFound = errors.New("build history record not found")
+
+type Opt struct {
+	DB *bolt.DB
+	// MaxAge is the time after completion when a record is removed
+	MaxAge time.Duration
+	// MaxEntries is the maximum number of completed records that are kept
+	MaxEntries int
+}
+
+// Store records the builds of the daemon together with their status stream.
+// Records of completed builds are removed when they are older than MaxAge or
+// when there are more than MaxEntries of them, unless they are pinned.
+type Store struct {
+	opt Opt
+
+	mu          sync.Mutex
+	active      map[string]*activeBuild
+	subscribers map[*subscriber]struct{}
+}
+
+type activeBuild struct {
+	rec        *controlapi.BuildHistoryRecord
+	statusSize int
+	seq        uint64
+
+	// pending are the buffered status updates that are not written yet
+	pending     []pendingStatus
+	pendingSize int
+	flushedAt   time.Time
+}
+
+type pendingStatus struct {
+	seq uint64
+	dt  []byte
+}
+
+func New(opt Opt) (*Store, error) {
+	if opt.MaxAge == 0 {
+		opt.MaxAge = defaultMaxAge
+	}
+	if opt.MaxEntries == 0 {
+		opt.MaxEntries = defaultMaxEntries
+	}
+	s := &Store{
+		opt:         opt,
+		active:      map[string]*activeBuild{},
+		subscribers: map[*subscriber]struct{}{},
+	}
+	if err := opt.DB.Update(func(tx *bolt.Tx) error {
+		for _, b := range []string{recordsBucket, statusBucket} {
+			if _, err := tx.CreateBucketIfNotExists([]byte(b)); err != nil {
+				return err
+			}
+		}
+		// builds that were running when the daemon stopped never complete
+		b := tx.Bucket([]byte(recordsBucket))
+		now := time.Now()
+		return b.ForEach(func(k, v []byte) error {
+			var rec controlapi.BuildHistoryRecord
+			if err := rec.Unmarshal(v); err != nil {
+				logrus.Warnf("invalid build history record %s: %v", k, err)
+				return nil
+			}
+			if rec.CompletedAt != nil {
+				return nil
+			}
+			rec.CompletedAt = &now
+			rec.Error = "build was interrupted"
+			dt, err := rec.Marshal()
+			if err != nil {
+				return err
+			}
+			return b.Put(k, dt)
+		})
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to initialize build history")
+	}
+	return s, nil
+}
+
+// Start adds a record for a build that has started.
+func (s *Store) Start(rec *controlapi.BuildHistoryRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.active[rec.Ref]; ok {
+		return errors.Errorf("build %s is already active", rec.Ref)
+	}
+	if err := s.opt.DB.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket([]byte(statusBucket)).DeleteBucket([]byte(rec.Ref)); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+			return err
+		}
+		return putRecord(tx, rec)
+	}); err != nil {
+		return err
+	}
+	r := *rec
+	s.active[rec.Ref] = &activeBuild{rec: &r, flushedAt: time.Now()}
+	s.publish(&controlapi.BuildHistoryEvent{
+		Type:   controlapi.BuildHistoryEventType_STARTED,
+		Record: rec,
+	})
+	return nil
+}
+
+// AddStatus stores a status update of an active build. Updates are buffered
+// and written in batches.
+func (s *Store) AddStatus(ref string, sr *controlapi.StatusResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ab, ok := s.active[ref]
+	if !ok {
+		return errors.Errorf("build %s is not active", ref)
+	}
+	if ab.statusSize+sr.Size() > maxStatusSize && len(sr.Logs) > 0 {
+		sr = &controlapi.StatusResponse{
+			Vertexes: sr.Vertexes,
+			Statuses: sr.Statuses,
+		}
+		if len(sr.Vertexes) == 0 && len(sr.Statuses) == 0 {
+			return nil
+		}
+	}
+	dt, err := sr.Marshal()
+	if err != nil {
+		return err
+	}
+	ab.seq++
+	ab.statusSize += len(dt)
+	ab.pending = append(ab.pending, pendingStatus{seq: ab.seq, dt: dt})
+	ab.pendingSize += len(dt)
+	if ab.pendingSize < statusFlushSize && time.Since(ab.flushedAt) < statusFlushInterval {
+		return nil
+	}
+	if err := s.opt.DB.Update(func(tx *bolt.Tx) error {
+		return putPendingStatus(tx, ref, ab)
+	}); err != nil {
+		return err
+	}
+	ab.flushedAt = time.Now()
+	return nil
+}
+
+// putPendingStatus writes the buffered status updates of an active build.
+func putPendingStatus(tx *bolt.Tx, ref string, ab *activeBuild) error {
+	if len(ab.pending) == 0 {
+		return nil
+	}
+	b, err := tx.Bucket([]byte(statusBucket)).CreateBucketIfNotExists([]byte(ref))
+	if err != nil {
+		return err
+	}
+	for _, st := range ab.pending {
+		if err := b.Put(seqKey(st.seq), st.dt); err != nil {
+			return err
+		}
+	}
+	ab.pending = nil
+	ab.pendingSize = 0
+	return nil
+}
+
+// Complete updates the record of an active build after it has finished and
+// removes old records.
+func (s *Store) Complete(rec *controlapi.BuildHistoryRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rec.CompletedAt == nil {
+		now := time.Now()
+		rec.CompletedAt = &now
+	}
+	if err := s.opt.DB.Update(func(tx *bolt.Tx) error {
+		if ab, ok := s.active[rec.Ref]; ok {
+			if err := putPendingStatus(tx, rec.Ref, ab); err != nil {
+				return err
+			}
+		}
+		return putRecord(tx, rec)
+	}); err != nil {
+		return err
+	}
+	delete(s.active, rec.Ref)
+	s.publish(&controlapi.BuildHistoryEvent{
+		Type:   controlapi.BuildHistoryEventType_COMPLETE,
+		Record: rec,
+	})
+	return s.gc()
+}
+
+// Get returns the record for a build.
+func (s *Store) Get(ref string) (*controlapi.BuildHistoryRecord, error) {
+	var rec *controlapi.BuildHistoryRecord
+	if err := s.opt.DB.View(func(tx *bolt.Tx) error {
+		var err error
+		rec, err = getRecord(tx, ref)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// Update pins, unpins or deletes the record of a completed build.
+func (s *Store) Update(req *controlapi.UpdateBuildHistoryRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.active[req.Ref]; ok {
+		return errors.Errorf("build %s is still active", req.Ref)
+	}
+
+	var rec *controlapi.BuildHistoryRecord
+	if err := s.opt.DB.Update(func(tx *bolt.Tx) error {
+		var err error
+		rec, err = getRecord(tx, req.Ref)
+		if err != nil {
+			return err
+		}
+		if req.Delete {
+			return deleteRecord(tx, req.Ref)
+		}
+		rec.Pinned = req.Pinned
+		return putRecord(tx, rec)
+	}); err != nil {
+		return err
+	}
+	if req.Delete {
+		s.publish(&controlapi.BuildHistoryEvent{
+			Type:   controlapi.BuildHistoryEventType_DELETED,
+			Record: rec,
+		})
+	}
+	return nil
+}
+
+// Status calls f for every stored status update of a build in order.
+func (s *Store) Status(ctx context.Context, ref string, f func(*controlapi.StatusResponse) error) error {
+	updates, err := s.statusUpdates(ref)
+	if err != nil {
+		return err
+	}
+	for _, dt := range updates {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var sr controlapi.StatusResponse
+		if err := sr.Unmarshal(dt); err != nil {
+			return errors.Wrapf(err, "invalid status for build %s", ref)
+		}
+		if err := f(&sr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statusUpdates returns the written status updates of a build followed by
+// the buffered ones if the build is active.
+func (s *Store) statusUpdates(ref string) ([][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var updates [][]byte
+	if err := s.opt.DB.View(func(tx *bolt.Tx) error {
+		if _, err := getRecord(tx, ref); err != nil {
+			return err
+		}
+		b := tx.Bucket([]byte(statusBucket)).Bucket([]byte(ref))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			updates = append(updates, append([]byte(nil), v...))
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	if ab, ok := s.active[ref]; ok {
+		for _, st := range ab.pending {
+			updates = append(updates, st.dt)
+		}
+	}
+	return updates, nil
+}
+
+// Listen calls f for the existing records matching the request and then for
+// every new event until ctx is done, unless req.EarlyExit is set.
+func (s *Store) Listen(ctx context.Context, req *controlapi.BuildHistoryRequest, f func(*controlapi.BuildHistoryEvent) error) error {
+	s.mu.Lock()
+	var events []*controlapi.BuildHistoryEvent
+	if err := s.opt.DB.View(func(tx *bolt.Tx) error {
+		recs, err := listRecords(tx)
+		if err != nil {
+			return err
+		}
+		for _, rec := range recs {
+			typ := controlapi.BuildHistoryEventType_COMPLETE
+			if _, ok := s.active[rec.Ref]; ok {
+				typ = controlapi.BuildHistoryEventType_STARTED
+				r := *s.active[rec.Ref].rec
+				rec = &r
+			}
+			events = append(events, &controlapi.BuildHistoryEvent{
+				Type:   typ,
+				Record: rec,
+			})
+		}
+		return nil
+	}); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	var sub *subscriber
+	if !req.EarlyExit {
+		sub = newSubscriber()
+		s.subscribers[sub] = struct{}{}
+		defer func() {
+			s.mu.Lock()
+			delete(s.subscribers, sub)
+			s.mu.Unlock()
+		}()
+	}
+	s.mu.Unlock()
+
+	for _, ev := range events {
+		if !match(req, ev) {
+			continue
+		}
+		if err := f(ev); err != nil {
+			return err
+		}
+	}
+	if sub == nil {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.notify:
+		}
+		for _, ev := range sub.pop() {
+			if !match(req, ev) {
+				continue
+			}
+			if err := f(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// gc removes completed records that are too old or above the maximum
+// number of entries. Called with s.mu held.
+func (s *Store) gc() error {
+	var deleted []*controlapi.BuildHistoryRecord
+	if err := s.opt.DB.Update(func(tx *bolt.Tx) error {
+		recs, err := listRecords(tx)
+		if err != nil {
+			return err
+		}
+		var completed []*controlapi.BuildHistoryRecord
+		for _, rec := range recs {
+			if _, ok := s.active[rec.Ref]; ok || rec.CompletedAt == nil || rec.Pinned {
+				continue
+			}
+			completed = append(completed, rec)
+		}
+		// newest first
+		sort.Slice(completed, func(i, j int) bool {
+			return completed[i].CompletedAt.After(*completed[j].CompletedAt)
+		})
+		cutoff := time.Now().Add(-s.opt.MaxAge)
+		for i, rec := range completed {
+			if i < s.opt.MaxEntries && rec.CompletedAt.After(cutoff) {
+				continue
+			}
+			if err := deleteRecord(tx, rec.Ref); err != nil {
+				return err
+			}
+			deleted = append(deleted, rec)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, rec := range deleted {
+		s.publish(&controlapi.BuildHistoryEvent{
+			Type:   controlapi.BuildHistoryEventType_DELETED,
+			Record: rec,
+		})
+	}
+	return nil
+}
+
+// called with s.mu held
+func (s *Store) publish(ev *controlapi.BuildHistoryEvent) {
+	// the caller may keep updating its record
+	rec := *ev.Record
+	ev.Record = &rec
+	for sub := range s.subscribers {
+		sub.push(ev)
+	}
+}
+
+func match(req *controlapi.BuildHistoryRequest, ev *controlapi.BuildHistoryEvent) bool {
+	if req.Ref != "" && req.Ref != ev.Record.Ref {
+		return false
+	}
+	if req.ActiveOnly && ev.Type != controlapi.BuildHistoryEventType_STARTED {
+		return false
+	}
+	return true
+}
+
+func putRecord(tx *bolt.Tx, rec *controlapi.BuildHistoryRecord) error {
+	dt, err := rec.Marshal()
+	if err != nil {
+		return err
+	}
+	return tx.Bucket([]byte(recordsBucket)).Put([]byte(rec.Ref), dt)
+}
+
+func getRecord(tx *bolt.Tx, ref string) (*controlapi.BuildHistoryRecord, error) {
+	dt := tx.Bucket([]byte(recordsBucket)).Get([]byte(ref))
+	if dt == nil {
+		return nil, errors.Wrapf(ErrNotFound, "build %s", ref)
+	}
+	var rec controlapi.BuildHistoryRecord
+	if err := rec.Unmarshal(dt); err != nil {
+		return nil, errors.Wrapf(err, "invalid build history record %s", ref)
+	}
+	return &rec, nil
+}
+
+func deleteRecord(tx *bolt.Tx, ref string) error {
+	if err := tx.Bucket([]byte(recordsBucket)).Delete([]byte(ref)); err != nil {
+		return err
+	}
+	if err := tx.Bucket([]byte(statusBucket)).DeleteBucket([]byte(ref)); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+		return err
+	}
+	return nil
+}
+
+// listRecords returns all records sorted by creation time.
+func listRecords(tx *bolt.Tx) ([]*controlapi.BuildHistoryRecord, error) {
+	var recs []*controlapi.BuildHistoryRecord
+	if err := tx.Bucket([]byte(recordsBucket)).ForEach(func(k, v []byte) error {
+		var rec controlapi.BuildHistoryRecord
+		if err := rec.Unmarshal(v); err != nil {
+			logrus.Warnf("invalid build history record %s: %v", k, err)
+			return nil
+		}
+		recs = append(recs, &rec)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(recs, func(i, j int) bool {
+		if recs[i].CreatedAt == nil || recs[j].CreatedAt == nil {
+			return recs[i].CreatedAt == nil
+		}
+		return recs[i].CreatedAt.Before(*recs[j].CreatedAt)
+	})
+	return recs, nil
+}
+
+func seqKey(seq uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, seq)
+	return b
+}
+
+type subscriber struct {
+	mu     sync.Mutex
+	queue  []*controlapi.BuildHistoryEvent
+	notify chan struct{}
+}
+
+func newSubscriber() *subscriber {
+	return &subscriber{notify: make(chan struct{}, 1)}
+}
+
+func (s *subscriber) push(ev *controlapi.BuildHistoryEvent) {
+	s.mu.Lock()
+	s.queue = append(s.queue, ev)
+	s.mu.Unlock()
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscriber) pop() []*controlapi.BuildHistoryEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q := s.queue
+	s.queue = nil
+	return q
+}
@@ -0,0 +1,232 @@
+package history
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func newTestStore(t *testing.T, dir string, opt Opt) (*Store, func()) {
+	db, err := bolt.Open(filepath.Join(dir, "history.db"), 0600, nil)
+	require.NoError(t, err)
+	opt.DB = db
+	s, err := New(opt)
+	require.NoError(t, err)
+	return s, func() { db.Close() }
+}
+
+func TestHistoryRecord(t *testing.T) {
+	t.Parallel()
+
+	tmpdir, err := ioutil.TempDir("", "history")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	s, closeDB := newTestStore(t, tmpdir, Opt{})
+	defer closeDB()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan *controlapi.BuildHistoryEvent, 10)
+	listenDone := make(chan error)
+	go func() {
+		listenDone <- s.Listen(ctx, &controlapi.BuildHistoryRequest{Ref: "ref1"}, func(ev *controlapi.BuildHistoryEvent) error {
+			events <- ev
+			return nil
+		})
+	}()
+
+	now := time.Now()
+	rec := &controlapi.BuildHistoryRecord{
+		Ref:           "ref1",
+		Frontend:      "dockerfile.v0",
+		FrontendAttrs: map[string]string{"target": "foo"},
+		CreatedAt:     &now,
+	}
+	// wait for the listener to subscribe
+	for i := 0; i < 100; i++ {
+		s.mu.Lock()
+		n := len(s.subscribers)
+		s.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, s.Start(rec))
+	require.Error(t, s.Start(rec))
+
+	require.NoError(t, s.AddStatus("ref1", &controlapi.StatusResponse{
+		Vertexes: []*controlapi.Vertex{{Name: "step1"}},
+	}))
+	require.NoError(t, s.AddStatus("ref1", &controlapi.StatusResponse{
+		Logs: []*controlapi.VertexLog{{Msg: []byte("hello")}},
+	}))
+	require.Error(t, s.AddStatus("ref2", &controlapi.StatusResponse{}))
+
+	// active records can't be removed
+	require.Error(t, s.Update(&controlapi.UpdateBuildHistoryRequest{Ref: "ref1", Delete: true}))
+
+	rec.Error = "failed"
+	rec.NumTotalSteps = 1
+	require.NoError(t, s.Complete(rec))
+
+	ev := <-events
+	require.Equal(t, controlapi.BuildHistoryEventType_STARTED, ev.Type)
+	require.Equal(t, "", ev.Record.Error)
+	ev = <-events
+	require.Equal(t, controlapi.BuildHistoryEventType_COMPLETE, ev.Type)
+	require.Equal(t, "failed", ev.Record.Error)
+	require.NotNil(t, ev.Record.CompletedAt)
+
+	got, err := s.Get("ref1")
+	require.NoError(t, err)
+	require.Equal(t, "dockerfile.v0", got.Frontend)
+	require.Equal(t, "foo", got.FrontendAttrs["target"])
+	require.Equal(t, int32(1), got.NumTotalSteps)
+
+	var statuses []*controlapi.StatusResponse
+	require.NoError(t, s.Status(ctx, "ref1", func(sr *controlapi.StatusResponse) error {
+		statuses = append(statuses, sr)
+		return nil
+	}))
+	require.Equal(t, 2, len(statuses))
+	require.Equal(t, "step1", statuses[0].Vertexes[0].Name)
+	require.Equal(t, "hello", string(statuses[1].Logs[0].Msg))
+
+	require.NoError(t, s.Update(&controlapi.UpdateBuildHistoryRequest{Ref: "ref1", Delete: true}))
+	ev = <-events
+	require.Equal(t, controlapi.BuildHistoryEventType_DELETED, ev.Type)
+
+	_, err = s.Get("ref1")
+	require.True(t, errors.Is(err, ErrNotFound))
+	err = s.Update(&controlapi.UpdateBuildHistoryRequest{Ref: "ref1", Delete: true})
+	require.True(t, errors.Is(err, ErrNotFound))
+
+	cancel()
+	require.NoError(t, <-listenDone)
+}
+
+func TestHistoryGC(t *testing.T) {
+	t.Parallel()
+
+	tmpdir, err := ioutil.TempDir("", "history")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	s, closeDB := newTestStore(t, tmpdir, Opt{MaxEntries: 2, MaxAge: time.Hour})
+	defer closeDB()
+
+	old := time.Now().Add(-2 * time.Hour)
+	for _, ref := range []string{"old", "pinned"} {
+		rec := &controlapi.BuildHistoryRecord{Ref: ref, CreatedAt: &old}
+		require.NoError(t, s.Start(rec))
+		rec.CompletedAt = &old
+		rec.Pinned = ref == "pinned"
+		require.NoError(t, s.Complete(rec))
+	}
+	_, err = s.Get("old")
+	require.True(t, errors.Is(err, ErrNotFound))
+
+	for _, ref := range []string{"ref1", "ref2", "ref3"} {
+		now := time.Now()
+		rec := &controlapi.BuildHistoryRecord{Ref: ref, CreatedAt: &now}
+		require.NoError(t, s.Start(rec))
+		require.NoError(t, s.Complete(rec))
+	}
+
+	active := &controlapi.BuildHistoryRecord{Ref: "active"}
+	require.NoError(t, s.Start(active))
+
+	var refs []string
+	var types []controlapi.BuildHistoryEventType
+	require.NoError(t, s.Listen(context.TODO(), &controlapi.BuildHistoryRequest{EarlyExit: true}, func(ev *controlapi.BuildHistoryEvent) error {
+		refs = append(refs, ev.Record.Ref)
+		types = append(types, ev.Type)
+		return nil
+	}))
+	require.Equal(t, []string{"active", "pinned", "ref2", "ref3"}, refs)
+	require.Equal(t, controlapi.BuildHistoryEventType_STARTED, types[0])
+
+	refs = nil
+	require.NoError(t, s.Listen(context.TODO(), &controlapi.BuildHistoryRequest{EarlyExit: true, ActiveOnly: true}, func(ev *controlapi.BuildHistoryEvent) error {
+		refs = append(refs, ev.Record.Ref)
+		return nil
+	}))
+	require.Equal(t, []string{"active"}, refs)
+
+	// builds running when the daemon stops are marked as interrupted
+	closeDB()
+	s, closeDB = newTestStore(t, tmpdir, Opt{})
+	defer closeDB()
+
+	rec, err := s.Get("active")
+	require.NoError(t, err)
+	require.NotNil(t, rec.CompletedAt)
+	require.NotEqual(t, "", rec.Error)
+}
+
+func TestHistoryStatusBuffered(t *testing.T) {
+	t.Parallel()
+
+	tmpdir, err := ioutil.TempDir("", "history")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	s, closeDB := newTestStore(t, tmpdir, Opt{})
+	defer closeDB()
+
+	rec := &controlapi.BuildHistoryRecord{Ref: "ref1"}
+	require.NoError(t, s.Start(rec))
+	for _, name := range []string{"step1", "step2"} {
+		require.NoError(t, s.AddStatus("ref1", &controlapi.StatusResponse{
+			Vertexes: []*controlapi.Vertex{{Name: name}},
+		}))
+	}
+
+	names := func() []string {
+		var names []string
+		require.NoError(t, s.Status(context.TODO(), "ref1", func(sr *controlapi.StatusResponse) error {
+			names = append(names, sr.Vertexes[0].Name)
+			return nil
+		}))
+		return names
+	}
+
+	// buffered updates are not written yet but are part of the status
+	require.NoError(t, s.opt.DB.View(func(tx *bolt.Tx) error {
+		require.Nil(t, tx.Bucket([]byte(statusBucket)).Bucket([]byte("ref1")))
+		return nil
+	}))
+	require.Equal(t, []string{"step1", "step2"}, names())
+
+	// a large update flushes the buffer
+	require.NoError(t, s.AddStatus("ref1", &controlapi.StatusResponse{
+		Vertexes: []*controlapi.Vertex{{Name: "step3"}},
+		Logs:     []*controlapi.VertexLog{{Msg: make([]byte, statusFlushSize)}},
+	}))
+	require.NoError(t, s.AddStatus("ref1", &controlapi.StatusResponse{
+		Vertexes: []*controlapi.Vertex{{Name: "step4"}},
+	}))
+	require.NoError(t, s.opt.DB.View(func(tx *bolt.Tx) error {
+		require.Equal(t, 3, tx.Bucket([]byte(statusBucket)).Bucket([]byte("ref1")).Stats().KeyN)
+		return nil
+	}))
+	require.Equal(t, []string{"step1", "step2", "step3", "step4"}, names())
+
+	// completing the build writes the rest
+	require.NoError(t, s.Complete(rec))
+	closeDB()
+	s, closeDB = newTestStore(t, tmpdir, Opt{})
+	defer closeDB()
+	require.Equal(t, []string{"step1", "step2", "step3", "step4"}, names())
+}
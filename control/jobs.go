@@ -0,0 +1,77 @@
+package control
+
+import (
+	"context"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// activeJob is a build started with Solve that can be cancelled with
+// CancelJob.
+type activeJob struct {
+	frontend  string
+	sessionID string
+	cancel    func()
+}
+
+func (c *Controller) registerJob(req *controlapi.SolveRequest, cancel func()) (func(), error) {
+	c.jobsMu.Lock()
+	defer c.jobsMu.Unlock()
+	if _, ok := c.jobs[req.Ref]; ok {
+		return nil, errors.Errorf("job ID %s exists", req.Ref)
+	}
+	c.jobs[req.Ref] = &activeJob{
+		frontend:  req.Frontend,
+		sessionID: req.Session,
+		cancel:    cancel,
+	}
+	return func() {
+		c.jobsMu.Lock()
+		delete(c.jobs, req.Ref)
+		c.jobsMu.Unlock()
+	}, nil
+}
+
+func (c *Controller) ListJobs(ctx context.Context, req *controlapi.ListJobsRequest) (*controlapi.ListJobsResponse, error) {
+	c.jobsMu.Lock()
+	defer c.jobsMu.Unlock()
+
+	resp := &controlapi.ListJobsResponse{}
+	for _, j := range c.solver.Jobs() {
+		aj, ok := c.jobs[j.ID]
+		if !ok {
+			continue
+		}
+		createdAt := j.CreatedAt
+		job := &controlapi.Job{
+			Ref:       j.ID,
+			SessionID: aj.sessionID,
+			CreatedAt: &createdAt,
+			Frontend:  aj.frontend,
+		}
+		for _, v := range j.Running {
+			started := v.Started
+			job.Vertices = append(job.Vertices, &controlapi.RunningVertex{
+				Digest:  v.Digest,
+				Name:    v.Name,
+				Started: &started,
+			})
+		}
+		resp.Jobs = append(resp.Jobs, job)
+	}
+	return resp, nil
+}
+
+func (c *Controller) CancelJob(ctx context.Context, req *controlapi.CancelJobRequest) (*controlapi.CancelJobResponse, error) {
+	c.jobsMu.Lock()
+	j, ok := c.jobs[req.Ref]
+	c.jobsMu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no such job %s", req.Ref)
+	}
+	j.cancel()
+	return &controlapi.CancelJobResponse{}, nil
+}
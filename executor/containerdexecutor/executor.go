@@ -6,7 +6,6 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -19,6 +18,7 @@ import (
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/moby/buildkit/executor"
 	"github.com/moby/buildkit/executor/oci"
+	"github.com/moby/buildkit/executor/resources"
 	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/snapshot"
 	"github.com/moby/buildkit/solver/errdefs"
@@ -35,12 +35,13 @@ type containerdExecutor struct {
 	networkProviders map[pb.NetMode]network.Provider
 	cgroupParent     string
 	dnsConfig        *oci.DNSConfig
+	limits           *oci.Limits
 	running          map[string]chan error
 	mu               sync.Mutex
 }
 
 // New creates a new executor backed by connection to containerd API
-func New(client *containerd.Client, root, cgroup string, networkProviders map[pb.NetMode]network.Provider, dnsConfig *oci.DNSConfig) executor.Executor {
+func New(client *containerd.Client, root, cgroup string, networkProviders map[pb.NetMode]network.Provider, dnsConfig *oci.DNSConfig, limits *oci.Limits) executor.Executor {
 	// clean up old hosts/resolv.conf file. ignore errors
 	os.RemoveAll(filepath.Join(root, "hosts"))
 	os.RemoveAll(filepath.Join(root, "resolv.conf"))
@@ -51,6 +52,7 @@ func New(client *containerd.Client, root, cgroup string, networkProviders map[pb
 		networkProviders: networkProviders,
 		cgroupParent:     cgroup,
 		dnsConfig:        dnsConfig,
+		limits:           limits,
 		running:          make(map[string]chan error),
 	}
 }
@@ -157,17 +159,19 @@ func (w *containerdExecutor) Run(ctx context.Context, id string, root executor.M
 		opts = append(opts, containerdoci.WithRootFSReadonly())
 	}
 
-	if w.cgroupParent != "" {
-		var cgroupsPath string
-		lastSeparator := w.cgroupParent[len(w.cgroupParent)-1:]
-		if strings.Contains(w.cgroupParent, ".slice") && lastSeparator == ":" {
-			cgroupsPath = w.cgroupParent + id
-		} else {
-			cgroupsPath = filepath.Join("/", w.cgroupParent, "buildkit", id)
-		}
-		opts = append(opts, containerdoci.WithCgroup(cgroupsPath))
+	cgroupParent := w.cgroupParent
+	if meta.CgroupParent != "" {
+		cgroupParent = meta.CgroupParent
+	}
+	if cgroupParent != "" {
+		opts = append(opts, containerdoci.WithCgroup(oci.CgroupPath(cgroupParent, meta.BuildRef, id)))
+		// runs after the container has been deleted
+		defer oci.AcquireBuildCgroup(cgroupParent, meta.BuildRef)()
 	}
 	processMode := oci.ProcessSandbox // FIXME(AkihiroSuda)
+	if err := w.limits.Apply(&meta); err != nil {
+		return err
+	}
 	spec, cleanup, err := oci.GenerateSpec(ctx, meta, mounts, id, resolvConf, hostsFile, namespace, processMode, nil, opts...)
 	if err != nil {
 		return err
@@ -209,6 +213,15 @@ func (w *containerdExecutor) Run(ctx context.Context, id string, root executor.M
 		}
 	}()
 
+	if process.ResourceUsage != nil {
+		// the cgroup of the task is kept until the task is deleted so the
+		// final sample includes all of the usage
+		m := resources.Start(int(task.Pid()))
+		defer func() {
+			process.ResourceUsage(m.Stop())
+		}()
+	}
+
 	err = w.runProcess(ctx, task, process.Resize, func() {
 		startedOnce.Do(func() {
 			if started != nil {
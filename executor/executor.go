@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"net"
+	"time"
 
 	"github.com/moby/buildkit/snapshot"
 	"github.com/moby/buildkit/solver/pb"
@@ -20,6 +21,11 @@ type Meta struct {
 	ExtraHosts     []HostIP
 	NetMode        pb.NetMode
 	SecurityMode   pb.SecurityMode
+	Ulimit         []*pb.Ulimit
+	ResourceLimits *pb.ResourceLimits
+	Devices        []*pb.Device
+	CgroupParent   string // overrides the cgroup parent of the executor
+	BuildRef       string
 }
 
 type Mountable interface {
@@ -43,6 +49,20 @@ type ProcessInfo struct {
 	Stdin          io.ReadCloser
 	Stdout, Stderr io.WriteCloser
 	Resize         <-chan WinSize
+	// ResourceUsage is called with the resources used by the container after
+	// the process started with Run has exited. Executors that can't measure
+	// the usage don't call it.
+	ResourceUsage func(ResourceUsage)
+}
+
+// ResourceUsage describes the resources used by a container, as measured by
+// its cgroup.
+type ResourceUsage struct {
+	CPUTime      time.Duration
+	MemoryPeak   uint64
+	IOReadBytes  uint64
+	IOWriteBytes uint64
+	PidsPeak     uint64
 }
 
 type Executor interface {
@@ -0,0 +1,98 @@
+package oci
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+var reBuildCgroupName = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// buildCgroupName returns the name of the cgroup that groups the containers
+// of a build. Build refs are chosen by the client, so a ref that isn't a plain
+// name is replaced by its digest to keep the cgroup under its parent.
+func buildCgroupName(buildRef string) string {
+	if reBuildCgroupName.MatchString(buildRef) {
+		return buildRef
+	}
+	return digest.FromString(buildRef).Encoded()
+}
+
+// systemdCgroupParent splits a systemd cgroup parent in the "slice:prefix:"
+// form.
+func systemdCgroupParent(parent string) (slice, prefix string, ok bool) {
+	parts := strings.Split(parent, ":")
+	if len(parts) != 3 || parts[2] != "" || (parts[0] != "" && !strings.HasSuffix(parts[0], ".slice")) {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// CgroupPath returns the cgroup of the container id under parent. The
+// cgroups of the containers of a build are grouped under
+// <parent>/buildkit/<build>. With a systemd parent in the "slice:prefix:"
+// form they are grouped in the <slice>-buildkit-<build>.slice child slice.
+func CgroupPath(parent, buildRef, id string) string {
+	if slice, prefix, ok := systemdCgroupParent(parent); ok {
+		if buildRef != "" {
+			slice = buildSlice(slice, buildCgroupName(buildRef))
+		}
+		return slice + ":" + prefix + ":" + id
+	}
+	return path.Join(buildCgroupPath(parent, buildRef), id)
+}
+
+func buildSlice(slice, name string) string {
+	switch slice {
+	case "":
+		// same default as runc
+		slice = "system.slice"
+	case "-.slice":
+		return "buildkit-" + name + ".slice"
+	}
+	return strings.TrimSuffix(slice, ".slice") + "-buildkit-" + name + ".slice"
+}
+
+func buildCgroupPath(parent, buildRef string) string {
+	if buildRef == "" {
+		return path.Join("/", parent, "buildkit")
+	}
+	return path.Join("/", parent, "buildkit", buildCgroupName(buildRef))
+}
+
+var buildCgroups = struct {
+	mu   sync.Mutex
+	refs map[string]int
+}{refs: map[string]int{}}
+
+// AcquireBuildCgroup marks a container of the build as created under parent.
+// The returned func must be called after the container has been deleted and
+// removes the cgroup of the build once none of its containers are left.
+// Slices of a systemd parent are left to systemd.
+func AcquireBuildCgroup(parent, buildRef string) func() {
+	if _, _, ok := systemdCgroupParent(parent); ok || buildRef == "" {
+		return func() {}
+	}
+	dir := buildCgroupPath(parent, buildRef)
+
+	buildCgroups.mu.Lock()
+	buildCgroups.refs[dir]++
+	buildCgroups.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			buildCgroups.mu.Lock()
+			defer buildCgroups.mu.Unlock()
+			buildCgroups.refs[dir]--
+			if buildCgroups.refs[dir] > 0 {
+				return
+			}
+			delete(buildCgroups.refs, dir)
+			removeCgroup(dir)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package oci
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+// removeCgroup removes the empty cgroup dir from every hierarchy
+func removeCgroup(dir string) {
+	var roots []string
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err == nil {
+		roots = []string{cgroupRoot}
+	} else {
+		fis, err := ioutil.ReadDir(cgroupRoot)
+		if err != nil {
+			return
+		}
+		for _, fi := range fis {
+			if fi.IsDir() {
+				roots = append(roots, filepath.Join(cgroupRoot, fi.Name()))
+			}
+		}
+	}
+	for _, root := range roots {
+		if err := os.Remove(filepath.Join(root, dir)); err != nil && !os.IsNotExist(err) {
+			logrus.Debugf("failed to remove cgroup %s: %v", filepath.Join(root, dir), err)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+// +build !linux
+
+package oci
+
+func removeCgroup(dir string) {}
@@ -0,0 +1,31 @@
+package oci
+
+import (
+	"path"
+
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+)
+
+// checkDevices returns an error if a device isn't allowed by the allow-list
+// of the limits. No device is allowed without limits.
+func (l *Limits) checkDevices(devices []*pb.Device) error {
+	for _, d := range devices {
+		if err := pb.ValidateDevice(d); err != nil {
+			return err
+		}
+		if l == nil || !deviceAllowed(l.Devices, d.Path) {
+			return errors.Errorf("device %s is not allowed by the build daemon configuration", d.Path)
+		}
+	}
+	return nil
+}
+
+func deviceAllowed(allowed []string, p string) bool {
+	for _, pattern := range allowed {
+		if ok, err := path.Match(pattern, p); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package oci
+
+import (
+	"github.com/moby/buildkit/executor"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+)
+
+const defaultCPUPeriod = 100000
+
+// Limits are the defaults and maximums for the resource limits of the
+// processes run by an executor. Zero values are not limited.
+type Limits struct {
+	Default        pb.ResourceLimits
+	Max            pb.ResourceLimits
+	DefaultUlimits []*pb.Ulimit
+	MaxUlimits     []*pb.Ulimit
+	// Devices are the host device paths, or path.Match patterns, that build
+	// steps may request
+	Devices []string
+}
+
+// Apply sets the limits of meta from the limits requested by the build step,
+// falling back to the defaults. Unlimited values are capped to the maximums
+// and an error is returned if a requested limit exceeds a maximum or if a
+// requested device isn't allowed.
+func (l *Limits) Apply(meta *executor.Meta) error {
+	if err := l.checkDevices(meta.Devices); err != nil {
+		return err
+	}
+	if l == nil {
+		return nil
+	}
+
+	var req pb.ResourceLimits
+	if meta.ResourceLimits != nil {
+		req = *meta.ResourceLimits
+	}
+	var r pb.ResourceLimits
+	var err error
+	if r.Memory, err = limitValue("memory", req.Memory, l.Default.Memory, l.Max.Memory); err != nil {
+		return err
+	}
+	if r.CpuShares, err = limitValue("cpu shares", req.CpuShares, l.Default.CpuShares, l.Max.CpuShares); err != nil {
+		return err
+	}
+	if r.Pids, err = limitValue("pids", req.Pids, l.Default.Pids, l.Max.Pids); err != nil {
+		return err
+	}
+	if r.CpuQuota, r.CpuPeriod, err = cpuQuota(req, l.Default, l.Max); err != nil {
+		return err
+	}
+	if r != (pb.ResourceLimits{}) {
+		meta.ResourceLimits = &r
+	} else {
+		meta.ResourceLimits = nil
+	}
+
+	ulimits := make([]*pb.Ulimit, 0, len(meta.Ulimit)+len(l.DefaultUlimits))
+	requested := map[string]struct{}{}
+	for _, u := range meta.Ulimit {
+		requested[u.Name] = struct{}{}
+		ulimits = append(ulimits, u)
+	}
+	for _, u := range l.DefaultUlimits {
+		if _, ok := requested[u.Name]; !ok {
+			ulimits = append(ulimits, u)
+		}
+	}
+	// maximums only cap the ulimits that are requested or defaulted, other
+	// resources keep the limits of the daemon
+	for i, u := range ulimits {
+		for _, max := range l.MaxUlimits {
+			if u.Name != max.Name || max.Hard == -1 {
+				continue
+			}
+			if _, ok := requested[u.Name]; ok && (u.Hard == -1 || u.Hard > max.Hard) {
+				return errors.Errorf("ulimit %s %d exceeds the maximum %d", u.Name, u.Hard, max.Hard)
+			}
+			if u.Hard == -1 || u.Hard > max.Hard {
+				ulimits[i] = &pb.Ulimit{Name: u.Name, Soft: max.Soft, Hard: max.Hard}
+			}
+		}
+	}
+	if len(ulimits) > 0 {
+		meta.Ulimit = ulimits
+	}
+	return nil
+}
+
+func limitValue(name string, req, def, max int64) (int64, error) {
+	v := req
+	if v == 0 {
+		v = def
+	}
+	if max > 0 {
+		if v > max && req != 0 {
+			return 0, errors.Errorf("%s limit %d exceeds the maximum %d", name, v, max)
+		}
+		if v == 0 || v > max {
+			v = max
+		}
+	}
+	return v, nil
+}
+
+func cpuQuota(req, def, max pb.ResourceLimits) (int64, int64, error) {
+	quota, period := req.CpuQuota, req.CpuPeriod
+	explicit := quota != 0
+	if !explicit {
+		quota, period = def.CpuQuota, def.CpuPeriod
+	}
+	if max.CpuQuota == 0 {
+		return quota, period, nil
+	}
+	if period == 0 {
+		period = defaultCPUPeriod
+	}
+	maxPeriod := max.CpuPeriod
+	if maxPeriod == 0 {
+		maxPeriod = defaultCPUPeriod
+	}
+	// compare the fraction of cpu time quota/period with the maximum
+	if quota == 0 || quota*maxPeriod > max.CpuQuota*period {
+		if explicit {
+			return 0, 0, errors.Errorf("cpu quota %d/%d exceeds the maximum %d/%d", quota, period, max.CpuQuota, maxPeriod)
+		}
+		return max.CpuQuota, maxPeriod, nil
+	}
+	return quota, period, nil
+}
@@ -0,0 +1,76 @@
+package oci
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/executor"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitsApply(t *testing.T) {
+	var l *Limits
+	meta := executor.Meta{}
+	require.NoError(t, l.Apply(&meta))
+	require.Nil(t, meta.ResourceLimits)
+
+	l = &Limits{
+		Default:        pb.ResourceLimits{Memory: 1 << 30, Pids: 100},
+		Max:            pb.ResourceLimits{Memory: 4 << 30, CpuQuota: 200000},
+		DefaultUlimits: []*pb.Ulimit{{Name: "nofile", Soft: 1024, Hard: 1024}},
+		MaxUlimits:     []*pb.Ulimit{{Name: "nofile", Soft: 4096, Hard: 4096}, {Name: "nproc", Soft: 500, Hard: 1000}},
+	}
+
+	meta = executor.Meta{}
+	require.NoError(t, l.Apply(&meta))
+	require.Equal(t, &pb.ResourceLimits{Memory: 1 << 30, Pids: 100, CpuQuota: 200000, CpuPeriod: defaultCPUPeriod}, meta.ResourceLimits)
+	require.Equal(t, []*pb.Ulimit{{Name: "nofile", Soft: 1024, Hard: 1024}}, meta.Ulimit)
+
+	meta = executor.Meta{
+		ResourceLimits: &pb.ResourceLimits{Memory: 2 << 30, CpuQuota: 50000, CpuPeriod: 50000},
+		Ulimit:         []*pb.Ulimit{{Name: "nofile", Soft: 2048, Hard: 4096}},
+	}
+	require.NoError(t, l.Apply(&meta))
+	require.Equal(t, &pb.ResourceLimits{Memory: 2 << 30, Pids: 100, CpuQuota: 50000, CpuPeriod: 50000}, meta.ResourceLimits)
+	require.Equal(t, []*pb.Ulimit{{Name: "nofile", Soft: 2048, Hard: 4096}}, meta.Ulimit)
+
+	// defaults above the maximum are capped
+	meta = executor.Meta{}
+	require.NoError(t, (&Limits{
+		DefaultUlimits: []*pb.Ulimit{{Name: "nproc", Soft: -1, Hard: -1}},
+		MaxUlimits:     l.MaxUlimits,
+	}).Apply(&meta))
+	require.Equal(t, []*pb.Ulimit{{Name: "nproc", Soft: 500, Hard: 1000}}, meta.Ulimit)
+
+	// maximums alone don't set ulimits
+	meta = executor.Meta{}
+	require.NoError(t, (&Limits{MaxUlimits: l.MaxUlimits}).Apply(&meta))
+	require.Nil(t, meta.Ulimit)
+
+	meta = executor.Meta{ResourceLimits: &pb.ResourceLimits{Memory: 8 << 30}}
+	require.Error(t, l.Apply(&meta))
+
+	meta = executor.Meta{ResourceLimits: &pb.ResourceLimits{CpuQuota: 300000}}
+	require.Error(t, l.Apply(&meta))
+
+	meta = executor.Meta{Ulimit: []*pb.Ulimit{{Name: "nofile", Soft: -1, Hard: -1}}}
+	require.Error(t, l.Apply(&meta))
+}
+
+func TestLimitsApplyDevices(t *testing.T) {
+	var l *Limits
+	meta := executor.Meta{Devices: []*pb.Device{{Path: "/dev/fuse"}}}
+	require.Error(t, l.Apply(&meta))
+
+	l = &Limits{Devices: []string{"/dev/fuse", "/dev/dri/*"}}
+	require.NoError(t, l.Apply(&meta))
+
+	meta = executor.Meta{Devices: []*pb.Device{{Path: "/dev/dri/card0", Permissions: "rw"}}}
+	require.NoError(t, l.Apply(&meta))
+
+	meta = executor.Meta{Devices: []*pb.Device{{Path: "/dev/kvm"}}}
+	require.Error(t, l.Apply(&meta))
+
+	meta = executor.Meta{Devices: []*pb.Device{{Path: "/dev/fuse", Permissions: "rx"}}}
+	require.Error(t, l.Apply(&meta))
+}
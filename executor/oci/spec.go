@@ -70,6 +70,18 @@ func GenerateSpec(ctx context.Context, meta executor.Meta, mounts []executor.Mou
 		return nil, nil, err
 	}
 
+	if resourceOpts, err := generateResourceOpts(meta.ResourceLimits); err == nil {
+		opts = append(opts, resourceOpts...)
+	} else {
+		return nil, nil, err
+	}
+
+	if deviceOpts, err := generateDeviceOpts(meta.Devices); err == nil {
+		opts = append(opts, deviceOpts...)
+	} else {
+		return nil, nil, err
+	}
+
 	hostname := defaultHostname
 	if meta.Hostname != "" {
 		hostname = meta.Hostname
@@ -94,6 +106,9 @@ func GenerateSpec(ctx context.Context, meta executor.Meta, mounts []executor.Mou
 	}
 
 	s.Process.Rlimits = nil // reset open files limit
+	if s.Process.Rlimits, err = generateRlimits(meta.Ulimit); err != nil {
+		return nil, nil, err
+	}
 
 	sm := &submounts{}
 
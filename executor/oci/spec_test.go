@@ -0,0 +1,39 @@
+package oci
+
+import (
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCgroupPath(t *testing.T) {
+	require.Equal(t, "/builds/buildkit/ref1/abc", CgroupPath("builds", "ref1", "abc"))
+	require.Equal(t, "/builds/buildkit/ref1/abc", CgroupPath("/builds/", "ref1", "abc"))
+	require.Equal(t, "/builds/buildkit/abc", CgroupPath("/builds", "", "abc"))
+
+	// refs are set by the client and can't escape the parent
+	escaped := digest.FromString("../../x").Encoded()
+	require.Equal(t, "/builds/buildkit/"+escaped+"/abc", CgroupPath("/builds", "../../x", "abc"))
+	require.Equal(t, "/builds/buildkit/"+digest.FromString("..").Encoded()+"/abc", CgroupPath("/builds", "..", "abc"))
+
+	require.Equal(t, "system-buildkit-ref1.slice:buildkit:abc", CgroupPath("system.slice:buildkit:", "ref1", "abc"))
+	require.Equal(t, "system-buildkit-ref1.slice:buildkit:abc", CgroupPath(":buildkit:", "ref1", "abc"))
+	require.Equal(t, "buildkit-ref1.slice:buildkit:abc", CgroupPath("-.slice:buildkit:", "ref1", "abc"))
+	require.Equal(t, "system-buildkit-"+escaped+".slice:buildkit:abc", CgroupPath("system.slice:buildkit:", "../../x", "abc"))
+	require.Equal(t, "system.slice:buildkit:abc", CgroupPath("system.slice:buildkit:", "", "abc"))
+}
+
+func TestAcquireBuildCgroup(t *testing.T) {
+	release1 := AcquireBuildCgroup("/builds", "ref1")
+	release2 := AcquireBuildCgroup("/builds", "ref1")
+	release1()
+	release1()
+	require.Equal(t, 1, buildCgroups.refs["/builds/buildkit/ref1"])
+	release2()
+	_, ok := buildCgroups.refs["/builds/buildkit/ref1"]
+	require.False(t, ok)
+
+	AcquireBuildCgroup("system.slice:buildkit:", "ref1")
+	require.Equal(t, 0, len(buildCgroups.refs))
+}
@@ -4,6 +4,7 @@ package oci
 
 import (
 	"context"
+	"strings"
 
 	"github.com/containerd/containerd/containers"
 	"github.com/containerd/containerd/oci"
@@ -69,3 +70,72 @@ func withDefaultProfile() oci.SpecOpts {
 		return err
 	}
 }
+
+func generateResourceOpts(r *pb.ResourceLimits) ([]oci.SpecOpts, error) {
+	if r == nil {
+		return nil, nil
+	}
+	return []oci.SpecOpts{withResourceLimits(r)}, nil
+}
+
+func generateDeviceOpts(devices []*pb.Device) ([]oci.SpecOpts, error) {
+	opts := make([]oci.SpecOpts, 0, len(devices))
+	for _, d := range devices {
+		opts = append(opts, oci.WithLinuxDevice(d.Path, pb.DevicePermissions(d)))
+	}
+	return opts, nil
+}
+
+func withResourceLimits(r *pb.ResourceLimits) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		if s.Linux == nil {
+			s.Linux = &specs.Linux{}
+		}
+		if s.Linux.Resources == nil {
+			s.Linux.Resources = &specs.LinuxResources{}
+		}
+		res := s.Linux.Resources
+		if r.Memory > 0 {
+			limit := r.Memory
+			res.Memory = &specs.LinuxMemory{Limit: &limit}
+		}
+		if r.CpuShares > 0 || r.CpuQuota > 0 {
+			res.CPU = &specs.LinuxCPU{}
+			if r.CpuShares > 0 {
+				shares := uint64(r.CpuShares)
+				res.CPU.Shares = &shares
+			}
+			if r.CpuQuota > 0 {
+				quota := r.CpuQuota
+				period := uint64(r.CpuPeriod)
+				if period == 0 {
+					period = defaultCPUPeriod
+				}
+				res.CPU.Quota = &quota
+				res.CPU.Period = &period
+			}
+		}
+		if r.Pids > 0 {
+			res.Pids = &specs.LinuxPids{Limit: r.Pids}
+		}
+		return nil
+	}
+}
+
+func generateRlimits(ulimits []*pb.Ulimit) ([]specs.POSIXRlimit, error) {
+	if len(ulimits) == 0 {
+		return nil, nil
+	}
+	rlimits := make([]specs.POSIXRlimit, 0, len(ulimits))
+	for _, u := range ulimits {
+		if err := pb.ValidateUlimit(u); err != nil {
+			return nil, err
+		}
+		rlimits = append(rlimits, specs.POSIXRlimit{
+			Type: "RLIMIT_" + strings.ToUpper(u.Name),
+			Hard: uint64(u.Hard),
+			Soft: uint64(u.Soft),
+		})
+	}
+	return rlimits, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/containerd/containerd/oci"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/moby/buildkit/solver/pb"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 )
 
@@ -35,3 +36,24 @@ func generateIDmapOpts(idmap *idtools.IdentityMapping) ([]oci.SpecOpts, error) {
 	}
 	return nil, errors.New("no support for IdentityMapping on Windows")
 }
+
+func generateResourceOpts(r *pb.ResourceLimits) ([]oci.SpecOpts, error) {
+	if r == nil {
+		return nil, nil
+	}
+	return nil, errors.New("no support for resource limits on Windows")
+}
+
+func generateDeviceOpts(devices []*pb.Device) ([]oci.SpecOpts, error) {
+	if len(devices) == 0 {
+		return nil, nil
+	}
+	return nil, errors.New("no support for devices on Windows")
+}
+
+func generateRlimits(ulimits []*pb.Ulimit) ([]specs.POSIXRlimit, error) {
+	if len(ulimits) == 0 {
+		return nil, nil
+	}
+	return nil, errors.New("no support for ulimit on Windows")
+}
@@ -0,0 +1,110 @@
+// Package resources measures the resources used by the containers started by
+// the executors.
+package resources
+
+import (
+	"time"
+
+	"github.com/moby/buildkit/executor"
+	"github.com/sirupsen/logrus"
+)
+
+const sampleInterval = 100 * time.Millisecond
+
+// Monitor samples the resource usage of the cgroup of a process until it is
+// stopped.
+type Monitor struct {
+	s       *sampler
+	usage   executor.ResourceUsage
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// Start begins sampling the cgroup of the process with the given pid. If the
+// cgroup can't be found, the monitor reports no usage.
+func Start(pid int) *Monitor {
+	s, err := newSampler(pid)
+	if err != nil {
+		logrus.Debugf("not measuring resource usage of process %d: %v", pid, err)
+	}
+	return start(s)
+}
+
+// StartCgroup begins sampling the cgroup with the given path relative to the
+// cgroup root. The cgroup doesn't need to exist yet, samples are skipped
+// until it is created.
+func StartCgroup(path string) *Monitor {
+	s, err := newCgroupSampler(path)
+	if err != nil {
+		logrus.Debugf("not measuring resource usage of cgroup %s: %v", path, err)
+	}
+	return start(s)
+}
+
+func start(s *sampler) *Monitor {
+	m := &Monitor{
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if s == nil {
+		close(m.stopped)
+		return m
+	}
+	m.s = s
+	m.sample()
+	go m.run()
+	return m
+}
+
+func (m *Monitor) run() {
+	defer close(m.stopped)
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+			m.sample()
+		}
+	}
+}
+
+// Stop stops sampling and returns the resource usage. The cgroup counters only
+// grow, so the maximum of every sampled value is returned. If the cgroup still
+// exists, it is sampled one last time.
+func (m *Monitor) Stop() executor.ResourceUsage {
+	close(m.done)
+	<-m.stopped
+	if m.s != nil {
+		m.sample()
+	}
+	return m.usage
+}
+
+func (m *Monitor) sample() {
+	u, err := m.s.sample()
+	if err != nil {
+		return
+	}
+	m.usage = maxUsage(m.usage, u)
+}
+
+func maxUsage(a, b executor.ResourceUsage) executor.ResourceUsage {
+	if b.CPUTime > a.CPUTime {
+		a.CPUTime = b.CPUTime
+	}
+	if b.MemoryPeak > a.MemoryPeak {
+		a.MemoryPeak = b.MemoryPeak
+	}
+	if b.IOReadBytes > a.IOReadBytes {
+		a.IOReadBytes = b.IOReadBytes
+	}
+	if b.IOWriteBytes > a.IOWriteBytes {
+		a.IOWriteBytes = b.IOWriteBytes
+	}
+	if b.PidsPeak > a.PidsPeak {
+		a.PidsPeak = b.PidsPeak
+	}
+	return a
+}
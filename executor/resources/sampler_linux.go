@@ -0,0 +1,188 @@
+package resources
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moby/buildkit/executor"
+	"github.com/pkg/errors"
+)
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+type sampler struct {
+	// unified is set on cgroup v2 hosts where dirs[""] is the cgroup of the
+	// process. On cgroup v1 dirs maps controllers to their directories.
+	unified bool
+	dirs    map[string]string
+}
+
+func newSampler(pid int) (*sampler, error) {
+	dt, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	s := &sampler{dirs: map[string]string{}}
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err == nil {
+		s.unified = true
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(dt)), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if s.unified {
+			if parts[0] == "0" && parts[1] == "" {
+				s.dirs[""] = filepath.Join(cgroupRoot, parts[2])
+			}
+			continue
+		}
+		for _, c := range strings.Split(parts[1], ",") {
+			s.dirs[c] = filepath.Join(cgroupRoot, parts[1], parts[2])
+		}
+	}
+	if len(s.dirs) == 0 {
+		return nil, errors.Errorf("no cgroup found for process %d", pid)
+	}
+	return s, nil
+}
+
+func newCgroupSampler(path string) (*sampler, error) {
+	s := &sampler{dirs: map[string]string{}}
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err == nil {
+		s.unified = true
+		s.dirs[""] = filepath.Join(cgroupRoot, path)
+		return s, nil
+	}
+	// on cgroup v1 every hierarchy is mounted under the root, named after its
+	// controllers, e.g. "cpu,cpuacct"
+	fis, err := ioutil.ReadDir(cgroupRoot)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, fi := range fis {
+		if !fi.IsDir() {
+			continue
+		}
+		for _, c := range strings.Split(fi.Name(), ",") {
+			s.dirs[c] = filepath.Join(cgroupRoot, fi.Name(), path)
+		}
+	}
+	if len(s.dirs) == 0 {
+		return nil, errors.Errorf("no cgroup hierarchy found in %s", cgroupRoot)
+	}
+	return s, nil
+}
+
+func (s *sampler) sample() (executor.ResourceUsage, error) {
+	if s.unified {
+		return sampleV2(s.dirs[""])
+	}
+	return sampleV1(s.dirs)
+}
+
+func sampleV2(dir string) (u executor.ResourceUsage, err error) {
+	// the cpu time is always available and tells if the cgroup still exists
+	stat, err := readKeyValues(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return u, err
+	}
+	u.CPUTime = time.Duration(stat["usage_usec"]) * time.Microsecond
+
+	// memory.peak and pids.peak are only available on newer kernels
+	if u.MemoryPeak, err = readUint(filepath.Join(dir, "memory.peak")); err != nil {
+		u.MemoryPeak, _ = readUint(filepath.Join(dir, "memory.current"))
+	}
+	if u.PidsPeak, err = readUint(filepath.Join(dir, "pids.peak")); err != nil {
+		u.PidsPeak, _ = readUint(filepath.Join(dir, "pids.current"))
+	}
+
+	if dt, err := ioutil.ReadFile(filepath.Join(dir, "io.stat")); err == nil {
+		// 8:0 rbytes=1024 wbytes=2048 rios=1 wios=2 dbytes=0 dios=0
+		for _, line := range strings.Split(string(dt), "\n") {
+			for _, f := range strings.Fields(line) {
+				kv := strings.SplitN(f, "=", 2)
+				if len(kv) != 2 {
+					continue
+				}
+				v, err := strconv.ParseUint(kv[1], 10, 64)
+				if err != nil {
+					continue
+				}
+				switch kv[0] {
+				case "rbytes":
+					u.IOReadBytes += v
+				case "wbytes":
+					u.IOWriteBytes += v
+				}
+			}
+		}
+	}
+	return u, nil
+}
+
+func sampleV1(dirs map[string]string) (u executor.ResourceUsage, err error) {
+	cpu, err := readUint(filepath.Join(dirs["cpuacct"], "cpuacct.usage"))
+	if err != nil {
+		return u, err
+	}
+	u.CPUTime = time.Duration(cpu)
+	u.MemoryPeak, _ = readUint(filepath.Join(dirs["memory"], "memory.max_usage_in_bytes"))
+	// cgroup v1 doesn't record the peak number of pids, PidsPeak is left
+	// unset
+
+	if dt, err := ioutil.ReadFile(filepath.Join(dirs["blkio"], "blkio.throttle.io_service_bytes_recursive")); err == nil {
+		// 8:0 Read 1024
+		for _, line := range strings.Split(string(dt), "\n") {
+			f := strings.Fields(line)
+			if len(f) != 3 {
+				continue
+			}
+			v, err := strconv.ParseUint(f[2], 10, 64)
+			if err != nil {
+				continue
+			}
+			switch f[1] {
+			case "Read":
+				u.IOReadBytes += v
+			case "Write":
+				u.IOWriteBytes += v
+			}
+		}
+	}
+	return u, nil
+}
+
+func readUint(p string) (uint64, error) {
+	dt, err := ioutil.ReadFile(p)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(string(bytes.TrimSpace(dt)), 10, 64)
+}
+
+func readKeyValues(p string) (map[string]uint64, error) {
+	dt, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]uint64{}
+	s := bufio.NewScanner(bytes.NewReader(dt))
+	for s.Scan() {
+		f := strings.Fields(s.Text())
+		if len(f) != 2 {
+			continue
+		}
+		if v, err := strconv.ParseUint(f[1], 10, 64); err == nil {
+			m[f[0]] = v
+		}
+	}
+	return m, s.Err()
+}
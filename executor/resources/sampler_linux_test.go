@@ -0,0 +1,97 @@
+package resources
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/executor"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, dt := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, ioutil.WriteFile(p, []byte(dt), 0600))
+	}
+}
+
+func TestSampleV2(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "cgroupv2")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = sampleV2(dir)
+	require.Error(t, err)
+
+	writeFiles(t, dir, map[string]string{
+		"cpu.stat":       "usage_usec 1500000\nuser_usec 1000000\nsystem_usec 500000\n",
+		"memory.current": "4096\n",
+		"memory.peak":    "8192\n",
+		"pids.current":   "3\n",
+		"io.stat":        "8:0 rbytes=1024 wbytes=2048 rios=1 wios=2 dbytes=0 dios=0\n8:16 rbytes=1 wbytes=2 rios=1 wios=1 dbytes=0 dios=0\n",
+	})
+
+	u, err := sampleV2(dir)
+	require.NoError(t, err)
+	require.Equal(t, executor.ResourceUsage{
+		CPUTime:      1500 * time.Millisecond,
+		MemoryPeak:   8192,
+		IOReadBytes:  1025,
+		IOWriteBytes: 2050,
+		PidsPeak:     3,
+	}, u)
+}
+
+func TestSampleV1(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "cgroupv1")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFiles(t, dir, map[string]string{
+		"cpu,cpuacct/ctr/cpuacct.usage":                       "2000000000\n",
+		"memory/ctr/memory.max_usage_in_bytes":                "1048576\n",
+		"pids/ctr/pids.current":                               "2\n",
+		"blkio/ctr/blkio.throttle.io_service_bytes_recursive": "8:0 Read 100\n8:0 Write 200\n8:0 Sync 300\n8:0 Total 300\nTotal 300\n",
+	})
+
+	u, err := sampleV1(map[string]string{
+		"cpu":     filepath.Join(dir, "cpu,cpuacct/ctr"),
+		"cpuacct": filepath.Join(dir, "cpu,cpuacct/ctr"),
+		"memory":  filepath.Join(dir, "memory/ctr"),
+		"pids":    filepath.Join(dir, "pids/ctr"),
+		"blkio":   filepath.Join(dir, "blkio/ctr"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, executor.ResourceUsage{
+		CPUTime:      2 * time.Second,
+		MemoryPeak:   1048576,
+		IOReadBytes:  100,
+		IOWriteBytes: 200,
+	}, u)
+}
+
+func TestMonitorSelf(t *testing.T) {
+	t.Parallel()
+
+	m := Start(os.Getpid())
+	u := m.Stop()
+	if m.s != nil {
+		require.NotEqual(t, time.Duration(0), u.CPUTime)
+	}
+}
+
+func TestMonitorMissingCgroup(t *testing.T) {
+	t.Parallel()
+
+	// samples of a cgroup that doesn't exist are skipped
+	m := StartCgroup("/buildkit-test-missing")
+	require.Equal(t, executor.ResourceUsage{}, m.Stop())
+}
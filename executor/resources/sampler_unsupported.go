@@ -0,0 +1,22 @@
+// +build !linux
+
+package resources
+
+import (
+	"github.com/moby/buildkit/executor"
+	"github.com/pkg/errors"
+)
+
+type sampler struct{}
+
+func newSampler(pid int) (*sampler, error) {
+	return nil, errors.New("measuring resource usage is only supported on linux")
+}
+
+func newCgroupSampler(path string) (*sampler, error) {
+	return nil, errors.New("measuring resource usage is only supported on linux")
+}
+
+func (s *sampler) sample() (executor.ResourceUsage, error) {
+	return executor.ResourceUsage{}, errors.New("measuring resource usage is only supported on linux")
+}
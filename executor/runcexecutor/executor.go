@@ -7,7 +7,6 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -45,6 +44,8 @@ type Opt struct {
 	NoPivot     bool
 	DNS         *oci.DNSConfig
 	OOMScoreAdj *int
+	// Limits are the default and maximum resource limits of the containers
+	Limits *oci.Limits
 }
 
 var defaultCommandCandidates = []string{"buildkit-runc", "runc"}
@@ -60,6 +61,7 @@ type runcExecutor struct {
 	noPivot          bool
 	dns              *oci.DNSConfig
 	oomScoreAdj      *int
+	limits           *oci.Limits
 	running          map[string]chan error
 	mu               sync.Mutex
 }
@@ -123,6 +125,7 @@ func New(opt Opt, networkProviders map[pb.NetMode]network.Provider) (executor.Ex
 		noPivot:          opt.NoPivot,
 		dns:              opt.DNS,
 		oomScoreAdj:      opt.OOMScoreAdj,
+		limits:           opt.Limits,
 		running:          make(map[string]chan error),
 	}
 	return w, nil
@@ -243,15 +246,17 @@ func (w *runcExecutor) Run(ctx context.Context, id string, root executor.Mount,
 		}
 	}
 
-	if w.cgroupParent != "" {
-		var cgroupsPath string
-		lastSeparator := w.cgroupParent[len(w.cgroupParent)-1:]
-		if strings.Contains(w.cgroupParent, ".slice") && lastSeparator == ":" {
-			cgroupsPath = w.cgroupParent + id
-		} else {
-			cgroupsPath = filepath.Join("/", w.cgroupParent, "buildkit", id)
-		}
-		opts = append(opts, containerdoci.WithCgroup(cgroupsPath))
+	cgroupParent := w.cgroupParent
+	if meta.CgroupParent != "" {
+		cgroupParent = meta.CgroupParent
+	}
+	if cgroupParent != "" {
+		opts = append(opts, containerdoci.WithCgroup(oci.CgroupPath(cgroupParent, meta.BuildRef, id)))
+		// runs after the container has been deleted
+		defer oci.AcquireBuildCgroup(cgroupParent, meta.BuildRef)()
+	}
+	if err := w.limits.Apply(&meta); err != nil {
+		return err
 	}
 	spec, cleanup, err := oci.GenerateSpec(ctx, meta, mounts, id, resolvConf, hostsFile, namespace, w.processMode, w.idmap, opts...)
 	if err != nil {
@@ -326,7 +331,11 @@ func (w *runcExecutor) Run(ctx context.Context, id string, root executor.Mount,
 		})
 	}
 
-	err = w.run(runCtx, id, bundle, process)
+	var cgroupsPath string
+	if spec.Linux != nil {
+		cgroupsPath = spec.Linux.CgroupsPath
+	}
+	err = w.run(runCtx, id, bundle, cgroupsPath, process)
 	close(ended)
 	return exitError(ctx, err)
 }
@@ -15,7 +15,7 @@ var unsupportedConsoleError = errors.New("tty for runc is only supported on linu
 
 func updateRuncFieldsForHostOS(runtime *runc.Runc) {}
 
-func (w *runcExecutor) run(ctx context.Context, id, bundle string, process executor.ProcessInfo) error {
+func (w *runcExecutor) run(ctx context.Context, id, bundle, cgroupsPath string, process executor.ProcessInfo) error {
 	if process.Meta.Tty {
 		return unsupportedConsoleError
 	}
@@ -2,8 +2,12 @@ package runcexecutor
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -11,6 +15,7 @@ import (
 	runc "github.com/containerd/go-runc"
 	"github.com/docker/docker/pkg/signal"
 	"github.com/moby/buildkit/executor"
+	"github.com/moby/buildkit/executor/resources"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -22,15 +27,107 @@ func updateRuncFieldsForHostOS(runtime *runc.Runc) {
 	runtime.PdeathSignal = syscall.SIGKILL // this can still leak the process
 }
 
-func (w *runcExecutor) run(ctx context.Context, id, bundle string, process executor.ProcessInfo) error {
-	return w.callWithIO(ctx, id, bundle, process, func(ctx context.Context, started chan<- int, io runc.IO) error {
-		_, err := w.runc.Run(ctx, id, bundle, &runc.CreateOpts{
+func (w *runcExecutor) run(ctx context.Context, id, bundle, cgroupsPath string, process executor.ProcessInfo) error {
+	// the usage of a cgroup in the systemd "slice:prefix:name" form isn't
+	// measured
+	if process.ResourceUsage == nil || cgroupsPath == "" || strings.Contains(cgroupsPath, ":") {
+		return w.callWithIO(ctx, id, bundle, process, func(ctx context.Context, started chan<- int, io runc.IO) error {
+			_, err := w.runc.Run(ctx, id, bundle, &runc.CreateOpts{
+				NoPivot: w.noPivot,
+				Started: started,
+				IO:      io,
+			})
+			return err
+		})
+	}
+
+	// the container is kept after its process has exited so that the last
+	// sample includes all of the usage
+	m := resources.StartCgroup(cgroupsPath)
+	err := w.callWithIO(ctx, id, bundle, process, func(ctx context.Context, started chan<- int, io runc.IO) error {
+		return w.runKeep(ctx, id, bundle, &runc.CreateOpts{
 			NoPivot: w.noPivot,
 			Started: started,
 			IO:      io,
 		})
-		return err
 	})
+	process.ResourceUsage(m.Stop())
+	if err1 := w.runc.Delete(context.TODO(), id, &runc.DeleteOpts{Force: true}); err == nil && err1 != nil {
+		err = errors.Wrapf(err1, "failed to delete container %s", id)
+	}
+	return err
+}
+
+// runKeep is like runc.Run but runs the container with --keep, so that it is
+// not deleted when its process exits. The caller must delete the container.
+func (w *runcExecutor) runKeep(ctx context.Context, id, bundle string, opts *runc.CreateOpts) error {
+	if opts.Started != nil {
+		defer close(opts.Started)
+	}
+	args := runcGlobalArgs(w.runc)
+	args = append(args, "run", "--bundle", bundle, "--keep")
+	if opts.NoPivot {
+		args = append(args, "--no-pivot")
+	}
+	args = append(args, id)
+
+	command := w.runc.Command
+	if command == "" {
+		command = runc.DefaultCommand
+	}
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid:   w.runc.Setpgid,
+		Pdeathsig: w.runc.PdeathSignal,
+	}
+	// NOTIFY_SOCKET changes the behavior of runc, same as in go-runc
+	for _, env := range os.Environ() {
+		if !strings.HasPrefix(env, "NOTIFY_SOCKET=") {
+			cmd.Env = append(cmd.Env, env)
+		}
+	}
+	if opts.IO != nil {
+		opts.Set(cmd)
+	}
+
+	ec, err := runc.Monitor.Start(cmd)
+	if err != nil {
+		return err
+	}
+	if opts.Started != nil {
+		opts.Started <- cmd.Process.Pid
+	}
+	status, err := runc.Monitor.Wait(cmd, ec)
+	if err == nil && status != 0 {
+		err = fmt.Errorf("%s did not terminate successfully: %w", cmd.Args[0], &runc.ExitError{Status: status})
+	}
+	return err
+}
+
+// runcGlobalArgs returns the global options of r like go-runc passes them
+func runcGlobalArgs(r *runc.Runc) (out []string) {
+	if r.Root != "" {
+		out = append(out, "--root", r.Root)
+	}
+	if r.Debug {
+		out = append(out, "--debug")
+	}
+	if r.Log != "" {
+		out = append(out, "--log", r.Log)
+	}
+	if r.LogFormat != "" {
+		out = append(out, "--log-format", string(r.LogFormat))
+	}
+	if r.Criu != "" {
+		out = append(out, "--criu", r.Criu)
+	}
+	if r.SystemdCgroup {
+		out = append(out, "--systemd-cgroup")
+	}
+	if r.Rootless != nil {
+		out = append(out, "--rootless="+strconv.FormatBool(*r.Rootless))
+	}
+	return out
 }
 
 func (w *runcExecutor) exec(ctx context.Context, id, bundle string, specsProcess *specs.Process, process executor.ProcessInfo) error {
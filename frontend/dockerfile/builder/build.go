@@ -22,6 +22,7 @@ import (
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
 	"github.com/moby/buildkit/frontend/gateway/client"
 	gwpb "github.com/moby/buildkit/frontend/gateway/pb"
+	"github.com/moby/buildkit/frontend/subrequests"
 	"github.com/moby/buildkit/solver/errdefs"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/apicaps"
@@ -55,6 +56,7 @@ const (
 	keyContextKeepGitDir       = "build-arg:BUILDKIT_CONTEXT_KEEP_GIT_DIR"
 	keySyntax                  = "build-arg:BUILDKIT_SYNTAX"
 	keyHostname                = "hostname"
+	keyLockfile                = "lockfile" // JSON representation of subrequests.Lockfile
 )
 
 var httpPrefix = regexp.MustCompile(`^https?://`)
@@ -114,6 +116,11 @@ func Build(ctx context.Context, c client.Client) (*client.Result, error) {
 		return nil, err
 	}
 
+	lockfile, err := parseLockfile(opts[keyLockfile])
+	if err != nil {
+		return nil, err
+	}
+
 	filename := opts[keyFilename]
 	if filename == "" {
 		filename = defaultDockerfileName
@@ -363,10 +370,48 @@ func Build(ctx context.Context, c client.Client) (*client.Result, error) {
 		return nil, capsError
 	}
 
-	if res, ok, err := checkSubRequest(ctx, opts); ok {
+	convertOpt := func(tp *specs.Platform, prefixPlatform bool) dockerfile2llb.ConvertOpt {
+		return dockerfile2llb.ConvertOpt{
+			Target:            opts[keyTarget],
+			MetaResolver:      c,
+			BuildArgs:         filter(opts, buildArgPrefix),
+			Labels:            filter(opts, labelPrefix),
+			CacheIDNamespace:  opts[keyCacheNS],
+			SessionID:         c.BuildOpts().SessionID,
+			BuildContext:      buildContext,
+			Excludes:          excludes,
+			IgnoreCache:       ignoreCache,
+			TargetPlatform:    tp,
+			BuildPlatforms:    buildPlatforms,
+			ImageResolveMode:  resolveMode,
+			PrefixPlatform:    prefixPlatform,
+			ExtraHosts:        extraHosts,
+			ForceNetMode:      defaultNetMode,
+			OverrideCopyImage: opts[keyOverrideCopyImage],
+			LLBCaps:           &caps,
+			SourceMap:         sourceMap,
+			Hostname:          opts[keyHostname],
+			ContextByName:     contextByNameFunc(c, resolveMode, tp),
+			Lockfile:          lockfile,
+		}
+	}
+
+	if opts["requestid"] == subrequests.RequestLock {
+		convertOpts := make([]dockerfile2llb.ConvertOpt, len(targetPlatforms))
+		for i, tp := range targetPlatforms {
+			convertOpts[i] = convertOpt(tp, false)
+		}
+		return lock(ctx, c, dtDockerfile, convertOpts, sourceMap)
+	}
+
+	if res, ok, err := checkSubRequest(ctx, opts, dtDockerfile, sourceMap); ok {
 		return res, err
 	}
 
+	if err := lintBuild(ctx, c, dtDockerfile, opts[keyTarget], sourceMap); err != nil {
+		return nil, err
+	}
+
 	exportMap := len(targetPlatforms) > 1
 
 	if v := opts[keyMultiPlatform]; v != "" {
@@ -396,27 +441,7 @@ func Build(ctx context.Context, c client.Client) (*client.Result, error) {
 						err = wrapSource(err, sourceMap, el.Location)
 					}
 				}()
-				st, img, err := dockerfile2llb.Dockerfile2LLB(ctx, dtDockerfile, dockerfile2llb.ConvertOpt{
-					Target:            opts[keyTarget],
-					MetaResolver:      c,
-					BuildArgs:         filter(opts, buildArgPrefix),
-					Labels:            filter(opts, labelPrefix),
-					CacheIDNamespace:  opts[keyCacheNS],
-					SessionID:         c.BuildOpts().SessionID,
-					BuildContext:      buildContext,
-					Excludes:          excludes,
-					IgnoreCache:       ignoreCache,
-					TargetPlatform:    tp,
-					BuildPlatforms:    buildPlatforms,
-					ImageResolveMode:  resolveMode,
-					PrefixPlatform:    exportMap,
-					ExtraHosts:        extraHosts,
-					ForceNetMode:      defaultNetMode,
-					OverrideCopyImage: opts[keyOverrideCopyImage],
-					LLBCaps:           &caps,
-					SourceMap:         sourceMap,
-					Hostname:          opts[keyHostname],
-				})
+				st, img, err := dockerfile2llb.Dockerfile2LLB(ctx, dtDockerfile, convertOpt(tp, exportMap))
 
 				if err != nil {
 					return errors.Wrapf(err, "failed to create LLB definition")
@@ -709,10 +734,15 @@ func wrapSource(err error, sm *llb.SourceMap, ranges []parser.Range) error {
 			Filename:   sm.Filename,
 			Definition: sm.Definition.ToPB(),
 		},
-		Ranges: make([]*pb.Range, 0, len(ranges)),
+		Ranges: toPBRanges(ranges),
 	}
+	return errdefs.WithSource(err, s)
+}
+
+func toPBRanges(ranges []parser.Range) []*pb.Range {
+	out := make([]*pb.Range, 0, len(ranges))
 	for _, r := range ranges {
-		s.Ranges = append(s.Ranges, &pb.Range{
+		out = append(out, &pb.Range{
 			Start: pb.Position{
 				Line:      int32(r.Start.Line),
 				Character: int32(r.Start.Character),
@@ -723,5 +753,5 @@ func wrapSource(err error, sm *llb.SourceMap, ranges []parser.Range) error {
 			},
 		})
 	}
-	return errdefs.WithSource(err, s)
+	return out
 }
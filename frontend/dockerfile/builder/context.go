@@ -0,0 +1,173 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerfile2llb"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
+	"github.com/moby/buildkit/frontend/gateway/client"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	contextPrefix       = "context:"
+	inputMetadataPrefix = "input-metadata:"
+)
+
+// contextByNameFunc returns the lookup of named contexts set with
+// "context:<name>=<source>" frontend options. Names are normalized like image
+// references so "alpine" and "docker.io/library/alpine:latest" are the same.
+// Images are resolved for the platform of the stage, or the default platform
+// if the stage doesn't set one.
+func contextByNameFunc(c client.Client, resolveMode llb.ResolveMode, defaultPlatform *specs.Platform) func(context.Context, string, *specs.Platform) (*llb.State, *dockerfile2llb.Image, error) {
+	return func(ctx context.Context, name string, p *specs.Platform) (*llb.State, *dockerfile2llb.Image, error) {
+		if p == nil {
+			p = defaultPlatform
+		}
+		if named, err := reference.ParseNormalizedNamed(name); err == nil {
+			name = strings.TrimSuffix(reference.FamiliarString(named), ":latest")
+		}
+		v, ok := c.BuildOpts().Opts[contextPrefix+name]
+		if !ok {
+			return nil, nil, nil
+		}
+		return contextByName(ctx, c, name, v, resolveMode, p)
+	}
+}
+
+func contextByName(ctx context.Context, c client.Client, name, source string, resolveMode llb.ResolveMode, p *specs.Platform) (*llb.State, *dockerfile2llb.Image, error) {
+	opts := c.BuildOpts().Opts
+
+	if ref := strings.TrimPrefix(source, "docker-image://"); ref != source {
+		named, err := reference.ParseNormalizedNamed(ref)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid image reference %q for context %s", ref, name)
+		}
+		named = reference.TagNameOnly(named)
+
+		dgst, dt, err := c.ResolveImageConfig(ctx, named.String(), llb.ResolveImageConfigOpt{
+			Platform:    p,
+			ResolveMode: resolveMode.String(),
+			LogName:     fmt.Sprintf("[context %s] load metadata for %s", name, ref),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		var img dockerfile2llb.Image
+		if err := json.Unmarshal(dt, &img); err != nil {
+			return nil, nil, err
+		}
+		img.Created = nil
+
+		if dgst != "" {
+			if _, ok := named.(reference.Canonical); !ok {
+				named, err = reference.WithDigest(named, dgst)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+
+		imgOpt := []llb.ImageOption{
+			resolveMode,
+			llb.WithCustomName("[context " + name + "] " + ref),
+		}
+		if p != nil {
+			imgOpt = append(imgOpt, llb.Platform(*p))
+		}
+		st := llb.Image(named.String(), imgOpt...)
+		return &st, &img, nil
+	}
+
+	if st, ok := detectGitContext(source, opts[keyContextKeepGitDir]); ok {
+		return st, nil, nil
+	}
+
+	if httpPrefix.MatchString(source) {
+		httpContext := llb.HTTP(source, llb.Filename("context"), llb.WithCustomName("[context "+name+"] "+source))
+		st := llb.Scratch().File(llb.Copy(httpContext, "/context", "/", &llb.CopyInfo{
+			AttemptUnpack: true,
+		}), llb.WithCustomName("[context "+name+"] unpack "+source))
+		return &st, nil, nil
+	}
+
+	if localName := strings.TrimPrefix(source, "local:"); localName != source {
+		excludes, err := localExcludes(ctx, c, localName)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to read %s for context %s", dockerignoreFilename, name)
+		}
+		st := llb.Local(localName,
+			llb.SessionID(c.BuildOpts().SessionID),
+			llb.ExcludePatterns(excludes),
+			llb.SharedKeyHint(contextPrefix+localName),
+			llb.WithCustomName("[context "+name+"] load from client"),
+		)
+		return &st, nil, nil
+	}
+
+	if inputName := strings.TrimPrefix(source, "input:"); inputName != source {
+		inputs, err := c.Inputs(ctx)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to get frontend inputs")
+		}
+		st, ok := inputs[inputName]
+		if !ok {
+			return nil, nil, errors.Errorf("invalid input %s for context %s", inputName, name)
+		}
+
+		var img *dockerfile2llb.Image
+		if md, ok := opts[inputMetadataPrefix+inputName]; ok {
+			m := map[string][]byte{}
+			if err := json.Unmarshal([]byte(md), &m); err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to parse input metadata %s", md)
+			}
+			if dt, ok := m[exptypes.ExporterImageConfigKey]; ok {
+				img = &dockerfile2llb.Image{}
+				if err := json.Unmarshal(dt, img); err != nil {
+					return nil, nil, errors.Wrapf(err, "failed to parse image config for context %s", name)
+				}
+			}
+		}
+		return &st, img, nil
+	}
+
+	return nil, nil, errors.Errorf("unsupported source %q for context %s", source, name)
+}
+
+func localExcludes(ctx context.Context, c client.Client, localName string) ([]string, error) {
+	st := llb.Local(localName,
+		llb.SessionID(c.BuildOpts().SessionID),
+		llb.FollowPaths([]string{dockerignoreFilename}),
+		llb.SharedKeyHint(contextPrefix+localName+"-"+dockerignoreFilename),
+		dockerfile2llb.WithInternalName("load "+dockerignoreFilename),
+	)
+	def, err := st.Marshal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.Solve(ctx, client.SolveRequest{
+		Definition: def.ToPB(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	ref, err := res.SingleRef()
+	if err != nil {
+		return nil, err
+	}
+	dt, err := ref.ReadFile(ctx, client.ReadRequest{
+		Filename: dockerignoreFilename,
+	})
+	if err != nil {
+		return nil, nil
+	}
+	return dockerignore.ReadAll(bytes.NewBuffer(dt))
+}
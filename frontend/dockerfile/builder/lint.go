@@ -0,0 +1,129 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerfile2llb"
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/frontend/gateway/client"
+	gwpb "github.com/moby/buildkit/frontend/gateway/pb"
+	"github.com/moby/buildkit/frontend/subrequests"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+)
+
+const keyCheck = "check"
+
+// lintConfig returns the rules configured by the check directive of the
+// Dockerfile, nil if it has none
+func lintConfig(dt []byte, sm *llb.SourceMap) (*linter.Config, error) {
+	d, ok := dockerfile2llb.ParseDirectives(bytes.NewBuffer(dt))[keyCheck]
+	if !ok {
+		return nil, nil
+	}
+	cfg, err := linter.ParseConfig(d.Value)
+	if err != nil {
+		return nil, wrapSource(errors.Wrap(err, "invalid check directive"), sm, d.Location)
+	}
+	return cfg, nil
+}
+
+func lintDockerfile(dt []byte, target string, cfg *linter.Config) ([]linter.Warning, error) {
+	res, err := parser.Parse(bytes.NewBuffer(dt))
+	if err != nil {
+		return nil, err
+	}
+	return linter.Lint(res, linter.Opt{
+		Target: target,
+		Config: cfg,
+	})
+}
+
+func lintError(warnings []linter.Warning, sm *llb.SourceMap) error {
+	w := warnings[0]
+	msg := fmt.Sprintf("lint violation %s: %s", w.Rule.Name, w.Detail)
+	if len(warnings) > 1 {
+		msg += fmt.Sprintf(" (and %d more)", len(warnings)-1)
+	}
+	return wrapSource(errors.New(msg), sm, w.Location)
+}
+
+func lint(dt []byte, target string, sm *llb.SourceMap) (*client.Result, error) {
+	cfg, err := lintConfig(dt, sm)
+	if err != nil {
+		return nil, err
+	}
+	warnings, err := lintDockerfile(dt, target, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	results := subrequests.LintResults{
+		Warnings: make([]subrequests.LintWarning, 0, len(warnings)),
+	}
+	for _, w := range warnings {
+		results.Warnings = append(results.Warnings, subrequests.LintWarning{
+			RuleName:    w.Rule.Name,
+			Description: w.Rule.Description,
+			Detail:      w.Detail,
+			Location:    toPBRanges(w.Location),
+		})
+	}
+	if cfg != nil && cfg.Error && len(warnings) > 0 {
+		results.Error = lintError(warnings, nil).Error()
+	}
+
+	return jsonResult(results)
+}
+
+// lintBuild reports the rule violations of the Dockerfile as build warnings
+// and fails the build if the check directive sets error=true.
+func lintBuild(ctx context.Context, c client.Client, dt []byte, target string, sm *llb.SourceMap) error {
+	cfg, err := lintConfig(dt, sm)
+	if err != nil {
+		return err
+	}
+	warnings, err := lintDockerfile(dt, target, cfg)
+	if err != nil {
+		// invalid Dockerfiles are reported by the conversion
+		return nil
+	}
+	if len(warnings) == 0 {
+		return nil
+	}
+	if cfg != nil && cfg.Error {
+		return lintError(warnings, sm)
+	}
+
+	caps := c.BuildOpts().Caps
+	if err := (&caps).Supports(gwpb.CapGatewayWarnings); err != nil || sm == nil || sm.Definition == nil || len(sm.Definition.Def) == 0 {
+		return nil
+	}
+	// warnings are reported on the vertex loading the Dockerfile, the input
+	// of the last op of its definition
+	var op pb.Op
+	if err := op.Unmarshal(sm.Definition.Def[len(sm.Definition.Def)-1]); err != nil || len(op.Inputs) == 0 {
+		return nil
+	}
+	dgst := op.Inputs[0].Digest
+	info := &pb.SourceInfo{
+		Data:       sm.Data,
+		Filename:   sm.Filename,
+		Definition: sm.Definition.ToPB(),
+	}
+	for _, w := range warnings {
+		if err := c.Warn(ctx, dgst, fmt.Sprintf("%s: %s", w.Rule.Name, w.Detail), client.WarnOpts{
+			Level:      client.WarnLevelWarning,
+			SourceInfo: info,
+			Range:      toPBRanges(w.Location),
+			Detail:     [][]byte{[]byte(w.Rule.Description)},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package builder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerfile2llb"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/frontend/subrequests"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+const lockReadChunkSize = 1 << 20
+
+func parseLockfile(v string) (*subrequests.Lockfile, error) {
+	if v == "" {
+		return nil, nil
+	}
+	var l subrequests.Lockfile
+	if err := json.Unmarshal([]byte(v), &l); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", keyLockfile)
+	}
+	return &l, nil
+}
+
+// lock converts the Dockerfile for every target platform and resolves the
+// sources recorded by the conversions to image digests, checksums and
+// commits
+func lock(ctx context.Context, c client.Client, dt []byte, convertOpts []dockerfile2llb.ConvertOpt, sm *llb.SourceMap) (*client.Result, error) {
+	rec := dockerfile2llb.NewLockRecorder()
+	for _, opt := range convertOpts {
+		opt.Lockfile = nil
+		opt.LockRecorder = rec
+		if _, _, err := dockerfile2llb.Dockerfile2LLB(ctx, dt, opt); err != nil {
+			var el *parser.ErrorLocation
+			if errors.As(err, &el) {
+				err = wrapSource(err, sm, el.Location)
+			}
+			return nil, errors.Wrap(err, "failed to create LLB definition")
+		}
+	}
+	l := rec.Lockfile()
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for i := range l.Images {
+		img := &l.Images[i]
+		if img.Digest != "" {
+			continue
+		}
+		eg.Go(func() error {
+			dgst, _, err := c.ResolveImageConfig(ctx, img.Ref, llb.ResolveImageConfigOpt{
+				Platform:    convertOpts[0].TargetPlatform,
+				ResolveMode: convertOpts[0].ImageResolveMode.String(),
+				LogName:     fmt.Sprintf("[internal] load metadata for %s", img.Ref),
+			})
+			if err != nil {
+				return err
+			}
+			if dgst == "" {
+				return errors.Errorf("failed to resolve digest of image %s", img.Ref)
+			}
+			img.Digest = dgst
+			return nil
+		})
+	}
+	for i := range l.HTTP {
+		h := &l.HTTP[i]
+		eg.Go(func() error {
+			checksum, err := lockHTTP(ctx, c, h.URL)
+			if err != nil {
+				return err
+			}
+			h.Checksum = checksum
+			return nil
+		})
+	}
+	for i := range l.Git {
+		g := &l.Git[i]
+		eg.Go(func() error {
+			commit, err := lockGit(ctx, c, g.Remote, g.Ref)
+			if err != nil {
+				return err
+			}
+			g.Commit = commit
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return jsonResult(l)
+}
+
+// lockHTTP downloads the content of the URL and returns its checksum
+func lockHTTP(ctx context.Context, c client.Client, url string) (digest.Digest, error) {
+	st := llb.HTTP(url, llb.Filename("content"), dockerfile2llb.WithInternalName("lock "+url))
+	ref, err := solveLockState(ctx, c, st)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for offset := 0; ; offset += lockReadChunkSize {
+		dt, err := ref.ReadFile(ctx, client.ReadRequest{
+			Filename: "content",
+			Range: &client.FileRange{
+				Offset: offset,
+				Length: lockReadChunkSize,
+			},
+		})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to read %s", url)
+		}
+		h.Write(dt)
+		if len(dt) < lockReadChunkSize {
+			break
+		}
+	}
+	return digest.NewDigest(digest.SHA256, h), nil
+}
+
+// lockGit checks out the git ref and returns the commit it points to
+func lockGit(ctx context.Context, c client.Client, remote, ref string) (string, error) {
+	name := remote
+	if ref != "" {
+		name += "#" + ref
+	}
+	st := llb.Git(remote, ref, llb.KeepGitDir(), dockerfile2llb.WithInternalName("lock "+name))
+	r, err := solveLockState(ctx, c, st)
+	if err != nil {
+		return "", err
+	}
+	dt, err := r.ReadFile(ctx, client.ReadRequest{
+		Filename: ".git/HEAD",
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read commit of %s", name)
+	}
+	commit := strings.TrimSpace(string(dt))
+	if !dockerfile2llb.ReCommitSHA.MatchString(commit) {
+		return "", errors.Errorf("failed to resolve commit of %s", name)
+	}
+	return commit, nil
+}
+
+func solveLockState(ctx context.Context, c client.Client, st llb.State) (client.Reference, error) {
+	def, err := st.Marshal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.Solve(ctx, client.SolveRequest{
+		Definition: def.ToPB(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.SingleRef()
+}
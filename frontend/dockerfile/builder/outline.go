@@ -0,0 +1,270 @@
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/frontend/subrequests"
+	"github.com/pkg/errors"
+)
+
+type parsedDockerfile struct {
+	shlex    *shell.Lex
+	stages   []instructions.Stage
+	metaArgs []instructions.ArgCommand
+}
+
+func parseDockerfile(dt []byte) (*parsedDockerfile, error) {
+	res, err := parser.Parse(bytes.NewBuffer(dt))
+	if err != nil {
+		return nil, err
+	}
+	stages, metaArgs, err := instructions.Parse(res.AST)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) == 0 {
+		return nil, errors.New("dockerfile contains no stages to build")
+	}
+	return &parsedDockerfile{
+		shlex:    shell.NewLex(res.EscapeToken),
+		stages:   stages,
+		metaArgs: metaArgs,
+	}, nil
+}
+
+func (p *parsedDockerfile) targetIndex(target string) (int, error) {
+	if target == "" {
+		return len(p.stages) - 1, nil
+	}
+	for i, st := range p.stages {
+		if strings.EqualFold(st.Name, target) {
+			return i, nil
+		}
+	}
+	return 0, errors.Errorf("failed to reach build target %s in Dockerfile", target)
+}
+
+// reachableStages returns the indexes of the stages needed to build the
+// target stage, in Dockerfile order. All stages are returned if a stage is
+// referenced through a variable.
+func (p *parsedDockerfile) reachableStages(target int) []int {
+	if out, ok := instructions.ReachableStages(p.stages, p.metaArgs, target, p.shlex); ok {
+		return out
+	}
+	out := make([]int, len(p.stages))
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}
+
+// expandArg processes the default values of the ARG command with the args
+// declared before it and adds its args to env
+func (p *parsedDockerfile) expandArg(c *instructions.ArgCommand, env map[string]string) error {
+	if err := c.Expand(func(word string) (string, error) {
+		return p.shlex.ProcessWordWithMap(word, env)
+	}); err != nil {
+		return parser.WithLocation(err, c.Location())
+	}
+	for _, kv := range c.Args {
+		env[kv.Key] = kv.ValueString()
+	}
+	return nil
+}
+
+func stageName(st instructions.Stage, i int) string {
+	if st.Name != "" {
+		return st.Name
+	}
+	return strconv.Itoa(i)
+}
+
+// dockerfileOutline lists the stages, build args, secrets and SSH sockets used
+// by the target without solving anything
+func dockerfileOutline(dt []byte, target string) (*subrequests.Outline, error) {
+	p, err := parseDockerfile(dt)
+	if err != nil {
+		return nil, err
+	}
+	targetIdx, err := p.targetIndex(target)
+	if err != nil {
+		return nil, err
+	}
+
+	metaArgs := map[string]instructions.KeyValuePairOptional{}
+	metaArgLocations := map[string][]parser.Range{}
+	metaEnv := map[string]string{}
+	for i := range p.metaArgs {
+		a := &p.metaArgs[i]
+		if err := p.expandArg(a, metaEnv); err != nil {
+			return nil, err
+		}
+		for _, kv := range a.Args {
+			metaArgs[kv.Key] = kv
+			metaArgLocations[kv.Key] = a.Location()
+		}
+	}
+
+	o := &subrequests.Outline{
+		Name:        p.stages[targetIdx].Name,
+		Description: p.stages[targetIdx].Comment,
+	}
+
+	args := map[string]struct{}{}
+	addArg := func(kv instructions.KeyValuePairOptional, location []parser.Range) {
+		if instructions.IsBuiltinArg(kv.Key) {
+			return
+		}
+		if _, ok := args[kv.Key]; ok {
+			return
+		}
+		args[kv.Key] = struct{}{}
+		arg := subrequests.OutlineArg{
+			Name:        kv.Key,
+			Description: kv.Comment,
+			Value:       kv.ValueString(),
+			Location:    toPBRanges(location),
+		}
+		if meta, ok := metaArgs[kv.Key]; ok {
+			if kv.Value == nil {
+				arg.Value = meta.ValueString()
+			}
+			if arg.Description == "" {
+				arg.Description = meta.Comment
+			}
+		}
+		o.Args = append(o.Args, arg)
+	}
+
+	secrets := map[string]int{}
+	ssh := map[string]int{}
+
+	for _, i := range p.reachableStages(targetIdx) {
+		st := p.stages[i]
+		o.Stages = append(o.Stages, stageName(st, i))
+		env := map[string]string{}
+
+		for _, word := range []string{st.BaseName, st.Platform} {
+			_, used, err := p.shlex.ProcessWordWithUnmatched(word, map[string]string{})
+			if err != nil {
+				continue
+			}
+			for _, a := range p.metaArgs {
+				for _, arg := range a.Args {
+					if _, ok := used[arg.Key]; ok {
+						addArg(arg, metaArgLocations[arg.Key])
+					}
+				}
+			}
+		}
+
+		for _, cmd := range st.Commands {
+			switch c := cmd.(type) {
+			case *instructions.ArgCommand:
+				if err := p.expandArg(c, env); err != nil {
+					return nil, err
+				}
+				for _, kv := range c.Args {
+					addArg(kv, c.Location())
+				}
+			case *instructions.RunCommand:
+				for _, m := range instructions.GetMounts(c) {
+					switch m.Type {
+					case instructions.MountTypeSecret:
+						id := m.CacheID
+						if m.Source != "" {
+							id = m.Source
+						}
+						if id == "" {
+							id = path.Base(m.Target)
+						}
+						if idx, ok := secrets[id]; ok {
+							o.Secrets[idx].Required = o.Secrets[idx].Required || m.Required
+							continue
+						}
+						secrets[id] = len(o.Secrets)
+						o.Secrets = append(o.Secrets, subrequests.OutlineSecret{
+							ID:       id,
+							Required: m.Required,
+							Location: toPBRanges(c.Location()),
+						})
+					case instructions.MountTypeSSH:
+						id := m.CacheID
+						if id == "" {
+							id = "default"
+						}
+						if idx, ok := ssh[id]; ok {
+							o.SSH[idx].Required = o.SSH[idx].Required || m.Required
+							continue
+						}
+						ssh[id] = len(o.SSH)
+						o.SSH = append(o.SSH, subrequests.OutlineSSH{
+							ID:       id,
+							Required: m.Required,
+							Location: toPBRanges(c.Location()),
+						})
+					}
+				}
+			}
+		}
+	}
+	return o, nil
+}
+
+// dockerfileTargets lists all the stages of the Dockerfile
+func dockerfileTargets(dt []byte) (*subrequests.TargetsList, error) {
+	p, err := parseDockerfile(dt)
+	if err != nil {
+		return nil, err
+	}
+	l := &subrequests.TargetsList{
+		Targets: make([]subrequests.Target, 0, len(p.stages)),
+	}
+	for i, st := range p.stages {
+		l.Targets = append(l.Targets, subrequests.Target{
+			Name:        st.Name,
+			Default:     i == len(p.stages)-1,
+			Description: st.Comment,
+			Base:        st.BaseName,
+			Platform:    st.Platform,
+			Location:    toPBRanges(st.Location),
+		})
+	}
+	return l, nil
+}
+
+func outline(dt []byte, target string) (*client.Result, error) {
+	o, err := dockerfileOutline(dt, target)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(o)
+}
+
+func targets(dt []byte) (*client.Result, error) {
+	l, err := dockerfileTargets(dt)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(l)
+}
+
+func jsonResult(v interface{}) (*client.Result, error) {
+	dt, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	res := client.NewResult()
+	res.Metadata = map[string][]byte{
+		"result.json": dt,
+	}
+	return res, nil
+}
@@ -0,0 +1,87 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/frontend/subrequests"
+	"github.com/stretchr/testify/require"
+)
+
+const outlineDockerfile = `# GO_VERSION version of the Go toolchain
+ARG GO_VERSION=1.16
+ARG UNUSED=foo
+
+FROM golang:${GO_VERSION} AS base
+# TARGETPLATFORM is set automatically
+ARG TARGETPLATFORM
+ARG GO_VERSION
+
+# build compiles the binary
+FROM base AS build
+# LDFLAGS flags passed to the linker
+ARG LDFLAGS="-s -w"
+RUN --mount=type=secret,id=token --mount=type=ssh go build -ldflags "$LDFLAGS" .
+RUN --mount=type=secret,target=/root/.netrc,required=true true
+
+FROM scratch AS docs
+ARG DOCS_DIR=docs
+RUN --mount=type=secret,id=docs-token true
+
+# release is the final image
+FROM scratch AS release
+COPY --from=build /out /
+`
+
+func TestDockerfileOutline(t *testing.T) {
+	o, err := dockerfileOutline([]byte(outlineDockerfile), "")
+	require.NoError(t, err)
+
+	require.Equal(t, "release", o.Name)
+	require.Equal(t, "is the final image", o.Description)
+	require.Equal(t, []string{"base", "build", "release"}, o.Stages)
+
+	require.Equal(t, 2, len(o.Args))
+	require.Equal(t, "GO_VERSION", o.Args[0].Name)
+	require.Equal(t, "1.16", o.Args[0].Value)
+	require.Equal(t, "version of the Go toolchain", o.Args[0].Description)
+	require.Equal(t, int32(2), o.Args[0].Location[0].Start.Line)
+	require.Equal(t, "LDFLAGS", o.Args[1].Name)
+	require.Equal(t, "-s -w", o.Args[1].Value)
+	require.Equal(t, "flags passed to the linker", o.Args[1].Description)
+
+	require.Equal(t, 2, len(o.Secrets))
+	require.Equal(t, "token", o.Secrets[0].ID)
+	require.False(t, o.Secrets[0].Required)
+	require.Equal(t, ".netrc", o.Secrets[1].ID)
+	require.True(t, o.Secrets[1].Required)
+
+	require.Equal(t, 1, len(o.SSH))
+	require.Equal(t, "default", o.SSH[0].ID)
+
+	o, err = dockerfileOutline([]byte(outlineDockerfile), "docs")
+	require.NoError(t, err)
+	require.Equal(t, []string{"docs"}, o.Stages)
+	require.Equal(t, []subrequests.OutlineSecret{{
+		ID:       "docs-token",
+		Location: o.Secrets[0].Location,
+	}}, o.Secrets)
+	require.Equal(t, "DOCS_DIR", o.Args[0].Name)
+
+	_, err = dockerfileOutline([]byte(outlineDockerfile), "notexist")
+	require.Error(t, err)
+}
+
+func TestDockerfileTargets(t *testing.T) {
+	l, err := dockerfileTargets([]byte(outlineDockerfile))
+	require.NoError(t, err)
+
+	require.Equal(t, 4, len(l.Targets))
+	require.Equal(t, "base", l.Targets[0].Name)
+	require.Equal(t, "golang:${GO_VERSION}", l.Targets[0].Base)
+	require.False(t, l.Targets[0].Default)
+	require.Equal(t, "build", l.Targets[1].Name)
+	require.Equal(t, "compiles the binary", l.Targets[1].Description)
+	require.Equal(t, "release", l.Targets[3].Name)
+	require.True(t, l.Targets[3].Default)
+	require.Equal(t, int32(22), l.Targets[3].Location[0].Start.Line)
+}
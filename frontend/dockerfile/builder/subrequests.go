@@ -4,12 +4,13 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/frontend/gateway/client"
 	"github.com/moby/buildkit/frontend/subrequests"
 	"github.com/moby/buildkit/solver/errdefs"
 )
 
-func checkSubRequest(ctx context.Context, opts map[string]string) (*client.Result, bool, error) {
+func checkSubRequest(ctx context.Context, opts map[string]string, dt []byte, sm *llb.SourceMap) (*client.Result, bool, error) {
 	req, ok := opts["requestid"]
 	if !ok {
 		return nil, false, nil
@@ -18,6 +19,15 @@ func checkSubRequest(ctx context.Context, opts map[string]string) (*client.Resul
 	case subrequests.RequestSubrequestsDescribe:
 		res, err := describe()
 		return res, true, err
+	case subrequests.RequestLint:
+		res, err := lint(dt, opts[keyTarget], sm)
+		return res, true, err
+	case subrequests.RequestOutline:
+		res, err := outline(dt, opts[keyTarget])
+		return res, true, err
+	case subrequests.RequestTargets:
+		res, err := targets(dt)
+		return res, true, err
 	default:
 		return nil, true, errdefs.NewUnsupportedSubrequestError(req)
 	}
@@ -26,6 +36,10 @@ func checkSubRequest(ctx context.Context, opts map[string]string) (*client.Resul
 func describe() (*client.Result, error) {
 	all := []subrequests.Request{
 		subrequests.SubrequestsDescribeDefinition,
+		subrequests.LintDefinition,
+		subrequests.OutlineDefinition,
+		subrequests.TargetsDefinition,
+		subrequests.LockDefinition,
 	}
 	dt, err := json.MarshalIndent(all, "  ", "")
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -23,9 +24,11 @@ import (
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
 	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"github.com/moby/buildkit/frontend/subrequests"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/apicaps"
 	"github.com/moby/buildkit/util/system"
+	digest "github.com/opencontainers/go-digest"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
@@ -63,6 +66,18 @@ type ConvertOpt struct {
 	ContextLocalName  string
 	SourceMap         *llb.SourceMap
 	Hostname          string
+	// ContextByName returns the state that replaces a stage or image name
+	// used in FROM or COPY --from. The platform is the one of the stage and
+	// is nil if the stage doesn't set one. A nil state means the name is not
+	// overridden. The returned image config, if any, is used like the config
+	// of a base image.
+	ContextByName func(ctx context.Context, name string, platform *specs.Platform) (*llb.State, *Image, error)
+	// Lockfile pins the images, HTTP sources and git sources of the reachable
+	// stages. Sources that are not pinned by it or the Dockerfile fail the
+	// conversion.
+	Lockfile *subrequests.Lockfile
+	// LockRecorder collects the sources a lockfile needs to pin
+	LockRecorder *LockRecorder
 }
 
 func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State, *Image, error) {
@@ -146,6 +161,31 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 			}
 			ds.platform = &p
 		}
+
+		if st.Name != "" && opt.ContextByName != nil {
+			s, img, err := opt.ContextByName(ctx, st.Name, ds.platform)
+			if err != nil {
+				return nil, nil, parser.WithLocation(err, st.Location)
+			}
+			if s != nil {
+				ds.noinit = true
+				ds.state = *s
+				ds.image = emptyImage(platformOpt.targetPlatform)
+				if img != nil {
+					ds.image = *img
+					if img.Architecture != "" && img.OS != "" {
+						ds.platform = &specs.Platform{
+							OS:           img.OS,
+							Architecture: img.Architecture,
+							Variant:      img.Variant,
+						}
+					}
+				}
+				allDispatchStates.addState(ds)
+				continue
+			}
+		}
+
 		allDispatchStates.addState(ds)
 
 		total := 0
@@ -190,6 +230,9 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 
 	// fill dependencies to stages so unreachable ones can avoid loading image configs
 	for _, d := range allDispatchStates.states {
+		if d.noinit {
+			continue
+		}
 		d.commands = make([]command, len(d.stage.Commands))
 		for i, cmd := range d.stage.Commands {
 			newCmd, err := toCommand(cmd, allDispatchStates)
@@ -220,7 +263,7 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 	for i, d := range allDispatchStates.states {
 		reachable := isReachable(target, d)
 		// resolve image config for every stage
-		if d.base == nil {
+		if d.base == nil && !d.noinit {
 			if d.stage.BaseName == emptyImageName {
 				d.state = llb.Scratch()
 				d.image = emptyImage(platformOpt.targetPlatform)
@@ -228,15 +271,44 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 			}
 			func(i int, d *dispatchState) {
 				eg.Go(func() error {
-					ref, err := reference.ParseNormalizedNamed(d.stage.BaseName)
-					if err != nil {
-						return parser.WithLocation(errors.Wrapf(err, "failed to parse stage name %q", d.stage.BaseName), d.stage.Location)
-					}
 					platform := d.platform
 					if platform == nil {
 						platform = &platformOpt.targetPlatform
 					}
+					if opt.ContextByName != nil && reachable {
+						st, img, err := opt.ContextByName(ctx, d.stage.BaseName, platform)
+						if err != nil {
+							return parser.WithLocation(err, d.stage.Location)
+						}
+						if st != nil {
+							d.state = *st
+							d.image = emptyImage(*platform)
+							if img != nil {
+								d.image = *img
+							}
+							d.platform = platform
+							return nil
+						}
+					}
+					ref, err := reference.ParseNormalizedNamed(d.stage.BaseName)
+					if err != nil {
+						return parser.WithLocation(errors.Wrapf(err, "failed to parse stage name %q", d.stage.BaseName), d.stage.Location)
+					}
 					d.stage.BaseName = reference.TagNameOnly(ref).String()
+					lockName := d.stage.BaseName
+					_, pinned := ref.(reference.Canonical)
+					if reachable && !pinned && opt.Lockfile != nil {
+						dgst, err := lockedImage(opt.Lockfile, lockName)
+						if err != nil {
+							return parser.WithLocation(err, d.stage.Location)
+						}
+						if ref, err = reference.WithDigest(reference.TagNameOnly(ref), dgst); err != nil {
+							return err
+						}
+						d.stage.BaseName = ref.String()
+						pinned = true
+					}
+					var resolved digest.Digest
 					var isScratch bool
 					if metaResolver != nil && reachable && !d.unregistered {
 						prefix := "["
@@ -252,6 +324,7 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 						if err != nil {
 							return err
 						}
+						resolved = dgst
 						var img Image
 						if err := json.Unmarshal(dt, &img); err != nil {
 							return err
@@ -281,6 +354,9 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 							}
 						}
 					}
+					if reachable && !pinned {
+						opt.LockRecorder.addImage(lockName, resolved)
+					}
 					if isScratch {
 						d.state = llb.Scratch()
 					} else {
@@ -307,7 +383,7 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 	ctxPaths := map[string]struct{}{}
 
 	for _, d := range allDispatchStates.states {
-		if !isReachable(target, d) {
+		if !isReachable(target, d) || d.noinit {
 			continue
 		}
 		if d.base != nil {
@@ -360,6 +436,8 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 			copyImage:         opt.OverrideCopyImage,
 			llbCaps:           opt.LLBCaps,
 			sourceMap:         opt.SourceMap,
+			lockfile:          opt.Lockfile,
+			lockRecorder:      opt.LockRecorder,
 		}
 		if opt.copyImage == "" {
 			opt.copyImage = DefaultCopyImage
@@ -478,6 +556,8 @@ type dispatchOpt struct {
 	copyImage         string
 	llbCaps           *apicaps.CapSet
 	sourceMap         *llb.SourceMap
+	lockfile          *subrequests.Lockfile
+	lockRecorder      *LockRecorder
 }
 
 func dispatch(d *dispatchState, cmd command, opt dispatchOpt) error {
@@ -493,6 +573,20 @@ func dispatch(d *dispatchState, cmd command, opt dispatchOpt) error {
 			return err
 		}
 	}
+	if ex, ok := cmd.Command.(instructions.SupportsSingleWordExpansionRaw); ok {
+		err := ex.ExpandRaw(func(word string) (string, error) {
+			env, err := d.state.Env(context.TODO())
+			if err != nil {
+				return "", err
+			}
+			lex := *opt.shlex
+			lex.SkipProcessQuotes = true
+			return lex.ProcessWord(word, env)
+		})
+		if err != nil {
+			return err
+		}
+	}
 
 	var err error
 	switch c := cmd.Command.(type) {
@@ -505,9 +599,34 @@ func dispatch(d *dispatchState, cmd command, opt dispatchOpt) error {
 	case *instructions.WorkdirCommand:
 		err = dispatchWorkdir(d, c, true, &opt)
 	case *instructions.AddCommand:
-		err = dispatchCopy(d, c.SourcesAndDest, opt.buildContext, true, c, c.Chown, c.Chmod, c.Location(), opt)
+		var checksum digest.Digest
+		if c.Checksum != "" {
+			checksum, err = digest.Parse(c.Checksum)
+			if err != nil {
+				return errors.Wrapf(err, "invalid checksum %q", c.Checksum)
+			}
+		}
+		err = dispatchCopy(d, copyConfig{
+			params:         c.SourcesAndDest,
+			sourceContents: c.SourceContents,
+			source:         opt.buildContext,
+			isAddCommand:   true,
+			cmdToPrint:     c,
+			chown:          c.Chown,
+			chmod:          c.Chmod,
+			link:           c.Link,
+			checksum:       checksum,
+			keepGitDir:     c.KeepGitDir,
+			excludes:       c.Excludes,
+			parents:        c.Parents,
+			location:       c.Location(),
+			opt:            opt,
+		})
 		if err == nil {
 			for _, src := range c.Sources() {
+				if _, ok := parseGitSource(src); ok {
+					continue
+				}
 				if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
 					d.ctxPaths[path.Join("/", filepath.ToSlash(src))] = struct{}{}
 				}
@@ -540,7 +659,20 @@ func dispatch(d *dispatchState, cmd command, opt dispatchOpt) error {
 		if len(cmd.sources) != 0 {
 			l = cmd.sources[0].state
 		}
-		err = dispatchCopy(d, c.SourcesAndDest, l, false, c, c.Chown, c.Chmod, c.Location(), opt)
+		err = dispatchCopy(d, copyConfig{
+			params:         c.SourcesAndDest,
+			sourceContents: c.SourceContents,
+			source:         l,
+			isAddCommand:   false,
+			cmdToPrint:     c,
+			chown:          c.Chown,
+			chmod:          c.Chmod,
+			link:           c.Link,
+			excludes:       c.Excludes,
+			parents:        c.Parents,
+			location:       c.Location(),
+			opt:            opt,
+		})
 		if err == nil && len(cmd.sources) == 0 {
 			for _, src := range c.Sources() {
 				d.ctxPaths[path.Join("/", filepath.ToSlash(src))] = struct{}{}
@@ -564,6 +696,7 @@ type dispatchState struct {
 	ignoreCache    bool
 	cmdSet         bool
 	unregistered   bool
+	noinit         bool // state was replaced by a named context
 	stageName      string
 	cmdIndex       int
 	cmdTotal       int
@@ -647,7 +780,42 @@ func dispatchEnv(d *dispatchState, c *instructions.EnvCommand) error {
 }
 
 func dispatchRun(d *dispatchState, c *instructions.RunCommand, proxy *llb.ProxyEnv, sources []*dispatchState, dopt dispatchOpt) error {
+	var opt []llb.RunOption
+
 	var args []string = c.CmdLine
+	if len(c.Files) > 0 {
+		if len(args) != 1 || !c.PrependShell {
+			return errors.Errorf("parsing produced an invalid run command: %v", args)
+		}
+
+		if heredoc := parser.MustParseHeredoc(args[0]); heredoc != nil {
+			data := c.Files[0].Data
+			if c.Files[0].Chomp {
+				data = parser.ChompHeredocContent(data)
+			}
+			if d.image.OS != "windows" && strings.HasPrefix(data, "#!") {
+				// A single here-document with a shebang is written to a file
+				// that is executed directly.
+				destPath := "/dev/pipes/"
+				st := llb.Scratch().Dir("/").File(llb.Mkfile(c.Files[0].Name, 0755, []byte(data)), WithInternalName("preparing inline document"))
+				opt = append(opt, llb.AddMount(destPath, st, llb.SourcePath("/"), llb.Readonly))
+				args = []string{path.Join(destPath, c.Files[0].Name)}
+			} else {
+				// A single here-document is run as a script by the shell, so
+				// the syntax also works with shells that don't support
+				// here-documents themselves.
+				args = []string{data}
+			}
+		} else {
+			// Here-documents used as redirections of a command are passed
+			// to the shell as they were written in the Dockerfile.
+			full := args[0]
+			for _, file := range c.Files {
+				full += "\n" + file.Data + file.Name
+			}
+			args = []string{full}
+		}
+	}
 	if c.PrependShell {
 		args = withShell(d.image, args)
 	}
@@ -655,7 +823,7 @@ func dispatchRun(d *dispatchState, c *instructions.RunCommand, proxy *llb.ProxyE
 	if err != nil {
 		return err
 	}
-	opt := []llb.RunOption{llb.Args(args), dfCmd(c), location(dopt.sourceMap, c.Location())}
+	opt = append(opt, llb.Args(args), dfCmd(c), location(dopt.sourceMap, c.Location()))
 	if d.ignoreCache {
 		opt = append(opt, llb.IgnoreCache)
 	}
@@ -669,6 +837,18 @@ func dispatchRun(d *dispatchState, c *instructions.RunCommand, proxy *llb.ProxyE
 	}
 	opt = append(opt, runMounts...)
 
+	ulimitOpt, err := dispatchRunUlimits(c, dopt)
+	if err != nil {
+		return err
+	}
+	opt = append(opt, ulimitOpt...)
+
+	deviceOpt, err := dispatchRunDevices(c, dopt)
+	if err != nil {
+		return err
+	}
+	opt = append(opt, deviceOpt...)
+
 	securityOpt, err := dispatchRunSecurity(c)
 	if err != nil {
 		return err
@@ -734,7 +914,8 @@ func dispatchWorkdir(d *dispatchState, c *instructions.WorkdirCommand, commit bo
 	return nil
 }
 
-func dispatchCopyFileOp(d *dispatchState, c instructions.SourcesAndDest, sourceState llb.State, isAddCommand bool, cmdToPrint fmt.Stringer, chown string, chmod string, loc []parser.Range, opt dispatchOpt) error {
+func dispatchCopyFileOp(d *dispatchState, cfg copyConfig) error {
+	c := cfg.params
 	pp, err := pathRelativeToWorkingDir(d.state, c.Dest())
 	if err != nil {
 		return err
@@ -746,13 +927,19 @@ func dispatchCopyFileOp(d *dispatchState, c instructions.SourcesAndDest, sourceS
 
 	var copyOpt []llb.CopyOption
 
-	if chown != "" {
-		copyOpt = append(copyOpt, llb.WithUser(chown))
+	if cfg.chown != "" {
+		if cfg.link {
+			// a linked copy has no /etc/passwd to look up user names
+			if _, _, err := parseUser(cfg.chown); err != nil {
+				return errors.Errorf("--chown with user name %q is not supported with --link, use numeric IDs", cfg.chown)
+			}
+		}
+		copyOpt = append(copyOpt, llb.WithUser(cfg.chown))
 	}
 
 	var mode *os.FileMode
-	if chmod != "" {
-		p, err := strconv.ParseUint(chmod, 8, 32)
+	if cfg.chmod != "" {
+		p, err := strconv.ParseUint(cfg.chmod, 8, 32)
 		if err == nil {
 			perm := os.FileMode(p)
 			mode = &perm
@@ -760,18 +947,48 @@ func dispatchCopyFileOp(d *dispatchState, c instructions.SourcesAndDest, sourceS
 	}
 
 	commitMessage := bytes.NewBufferString("")
-	if isAddCommand {
+	if cfg.isAddCommand {
 		commitMessage.WriteString("ADD")
 	} else {
 		commitMessage.WriteString("COPY")
 	}
 
+	if err := validateChecksumSources(c, cfg); err != nil {
+		return err
+	}
+
+	if err := validateCopyFilters(cfg); err != nil {
+		return err
+	}
+
 	var a *llb.FileAction
 
 	for _, src := range c.Sources() {
 		commitMessage.WriteString(" " + src)
-		if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
-			if !isAddCommand {
+		if gitSrc, ok := parseGitSource(src); ok && cfg.isAddCommand {
+			gitOpts := []llb.GitOption{dfCmd(cfg.cmdToPrint)}
+			if cfg.keepGitDir {
+				gitOpts = append(gitOpts, llb.KeepGitDir())
+			}
+			ref, err := gitRef(gitSrc, cfg.opt)
+			if err != nil {
+				return err
+			}
+			st := llb.Git(gitSrc.remote, ref, gitOpts...)
+
+			opts := append([]llb.CopyOption{&llb.CopyInfo{
+				Mode:                mode,
+				CopyDirContentsOnly: true,
+				CreateDestPath:      true,
+			}}, copyOpt...)
+
+			if a == nil {
+				a = llb.Copy(st, path.Join("/", gitSrc.subdir), dest, opts...)
+			} else {
+				a = a.Copy(st, path.Join("/", gitSrc.subdir), dest, opts...)
+			}
+		} else if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
+			if !cfg.isAddCommand {
 				return errors.New("source can't be a URL for COPY")
 			}
 
@@ -788,7 +1005,15 @@ func dispatchCopyFileOp(d *dispatchState, c instructions.SourcesAndDest, sourceS
 				}
 			}
 
-			st := llb.HTTP(src, llb.Filename(f), dfCmd(c))
+			httpOpts := []llb.HTTPOption{llb.Filename(f), dfCmd(c)}
+			checksum, err := httpChecksum(src, cfg)
+			if err != nil {
+				return err
+			}
+			if checksum != "" {
+				httpOpts = append(httpOpts, llb.Checksum(checksum))
+			}
+			st := llb.HTTP(src, httpOpts...)
 
 			opts := append([]llb.CopyOption{&llb.CopyInfo{
 				CreateDestPath: true,
@@ -804,23 +1029,42 @@ func dispatchCopyFileOp(d *dispatchState, c instructions.SourcesAndDest, sourceS
 				Mode:                mode,
 				FollowSymlinks:      true,
 				CopyDirContentsOnly: true,
-				AttemptUnpack:       isAddCommand,
+				AttemptUnpack:       cfg.isAddCommand,
 				CreateDestPath:      true,
 				AllowWildcard:       true,
 				AllowEmptyWildcard:  true,
+				ExcludePatterns:     cfg.excludes,
+				Parents:             cfg.parents,
 			}}, copyOpt...)
 
 			if a == nil {
-				a = llb.Copy(sourceState, filepath.Join("/", src), dest, opts...)
+				a = llb.Copy(cfg.source, filepath.Join("/", src), dest, opts...)
 			} else {
-				a = a.Copy(sourceState, filepath.Join("/", src), dest, opts...)
+				a = a.Copy(cfg.source, filepath.Join("/", src), dest, opts...)
 			}
 		}
 	}
 
+	for _, src := range cfg.sourceContents {
+		commitMessage.WriteString(" <<" + src.Path)
+
+		st := llb.Scratch().Dir("/").File(llb.Mkfile(src.Path, 0664, []byte(src.Data)), WithInternalName("preparing inline document"))
+
+		opts := append([]llb.CopyOption{&llb.CopyInfo{
+			Mode:           mode,
+			CreateDestPath: true,
+		}}, copyOpt...)
+
+		if a == nil {
+			a = llb.Copy(st, src.Path, dest, opts...)
+		} else {
+			a = a.Copy(st, src.Path, dest, opts...)
+		}
+	}
+
 	commitMessage.WriteString(" " + c.Dest())
 
-	platform := opt.targetPlatform
+	platform := cfg.opt.targetPlatform
 	if d.platform != nil {
 		platform = *d.platform
 	}
@@ -830,31 +1074,113 @@ func dispatchCopyFileOp(d *dispatchState, c instructions.SourcesAndDest, sourceS
 		return err
 	}
 
+	name := uppercaseCmd(processCmdEnv(cfg.opt.shlex, cfg.cmdToPrint.String(), env))
 	fileOpt := []llb.ConstraintsOpt{
-		llb.WithCustomName(prefixCommand(d, uppercaseCmd(processCmdEnv(opt.shlex, cmdToPrint.String(), env)), d.prefixPlatform, &platform)),
-		location(opt.sourceMap, loc),
+		llb.WithCustomName(prefixCommand(d, name, d.prefixPlatform, &platform)),
+		location(cfg.opt.sourceMap, cfg.location),
 	}
 	if d.ignoreCache {
 		fileOpt = append(fileOpt, llb.IgnoreCache)
 	}
 
-	d.state = d.state.File(a, fileOpt...)
+	if cfg.link && supportsLink(cfg.opt.llbCaps) {
+		// The files are copied to an empty state and merged on top of the
+		// stage, so the copy doesn't depend on the previous layers and its
+		// layer is reused when they change.
+		copyState := llb.Scratch().File(a, fileOpt...)
+
+		mergeOpt := []llb.ConstraintsOpt{
+			llb.WithCustomName(prefixCommand(d, "LINK "+name, d.prefixPlatform, &platform)),
+			location(cfg.opt.sourceMap, cfg.location),
+		}
+		if d.ignoreCache {
+			mergeOpt = append(mergeOpt, llb.IgnoreCache)
+		}
+		d.state = d.state.WithOutput(llb.Merge([]llb.State{d.state, copyState}, mergeOpt...).Output())
+	} else {
+		d.state = d.state.File(a, fileOpt...)
+	}
 	return commitToHistory(&d.image, commitMessage.String(), true, &d.state)
 }
 
-func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState llb.State, isAddCommand bool, cmdToPrint fmt.Stringer, chown string, chmod string, loc []parser.Range, opt dispatchOpt) error {
-	if useFileOp(opt.buildArgValues, opt.llbCaps) {
-		return dispatchCopyFileOp(d, c, sourceState, isAddCommand, cmdToPrint, chown, chmod, loc, opt)
+func validateChecksumSources(c instructions.SourcesAndDest, cfg copyConfig) error {
+	if cfg.checksum == "" {
+		return nil
+	}
+	if len(cfg.sourceContents) > 0 {
+		return errors.New("checksum can't be specified for inline sources")
+	}
+	for _, src := range c.Sources() {
+		if _, ok := parseGitSource(src); ok || (!strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://")) {
+			return errors.Errorf("checksum can't be specified for non-HTTP(S) source %s", src)
+		}
+	}
+	return nil
+}
+
+func validateCopyFilters(cfg copyConfig) error {
+	if len(cfg.excludes) > 0 && cfg.opt.llbCaps != nil {
+		if err := cfg.opt.llbCaps.Supports(pb.CapFileCopyIncludeExcludePatterns); err != nil {
+			return errors.Wrap(err, "--exclude is not supported")
+		}
+	}
+	if cfg.parents && cfg.opt.llbCaps != nil {
+		if err := cfg.opt.llbCaps.Supports(pb.CapFileCopyParents); err != nil {
+			return errors.Wrap(err, "--parents is not supported")
+		}
+	}
+	return nil
+}
+
+// supportsLink returns true if the daemon can merge the layer of a linked
+// copy on top of the stage. Older daemons fall back to a regular copy.
+func supportsLink(caps *apicaps.CapSet) bool {
+	return caps == nil || caps.Supports(pb.CapMergeOp) == nil
+}
+
+type copyConfig struct {
+	params         instructions.SourcesAndDest
+	sourceContents []instructions.SourceContent
+	source         llb.State
+	isAddCommand   bool
+	cmdToPrint     fmt.Stringer
+	chown          string
+	chmod          string
+	link           bool
+	checksum       digest.Digest
+	keepGitDir     bool
+	excludes       []string
+	parents        bool
+	location       []parser.Range
+	opt            dispatchOpt
+}
+
+func dispatchCopy(d *dispatchState, cfg copyConfig) error {
+	if useFileOp(cfg.opt.buildArgValues, cfg.opt.llbCaps) {
+		return dispatchCopyFileOp(d, cfg)
+	}
+
+	if len(cfg.sourceContents) > 0 {
+		return errors.New("inline sources are only supported with file operations")
+	}
+	if len(cfg.excludes) > 0 || cfg.parents {
+		return errors.New("--exclude and --parents are only supported with file operations")
+	}
+
+	c := cfg.params
+
+	if err := validateChecksumSources(c, cfg); err != nil {
+		return err
 	}
 
-	if chmod != "" {
-		if opt.llbCaps != nil && opt.llbCaps.Supports(pb.CapFileBase) != nil {
-			return errors.Wrap(opt.llbCaps.Supports(pb.CapFileBase), "chmod is not supported")
+	if cfg.chmod != "" {
+		if cfg.opt.llbCaps != nil && cfg.opt.llbCaps.Supports(pb.CapFileBase) != nil {
+			return errors.Wrap(cfg.opt.llbCaps.Supports(pb.CapFileBase), "chmod is not supported")
 		}
 		return errors.New("chmod is not supported")
 	}
 
-	img := llb.Image(opt.copyImage, llb.MarkImageInternal, llb.Platform(opt.buildPlatforms[0]), WithInternalName("helper image for file operations"))
+	img := llb.Image(cfg.opt.copyImage, llb.MarkImageInternal, llb.Platform(cfg.opt.buildPlatforms[0]), WithInternalName("helper image for file operations"))
 	pp, err := pathRelativeToWorkingDir(d.state, c.Dest())
 	if err != nil {
 		return err
@@ -864,12 +1190,12 @@ func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState l
 		dest += string(filepath.Separator)
 	}
 	args := []string{"copy"}
-	unpack := isAddCommand
+	unpack := cfg.isAddCommand
 
 	mounts := make([]llb.RunOption, 0, len(c.Sources()))
-	if chown != "" {
-		args = append(args, fmt.Sprintf("--chown=%s", chown))
-		_, _, err := parseUser(chown)
+	if cfg.chown != "" {
+		args = append(args, fmt.Sprintf("--chown=%s", cfg.chown))
+		_, _, err := parseUser(cfg.chown)
 		if err != nil {
 			mounts = append(mounts, llb.AddMount("/etc/passwd", d.state, llb.SourcePath("/etc/passwd"), llb.Readonly))
 			mounts = append(mounts, llb.AddMount("/etc/group", d.state, llb.SourcePath("/etc/group"), llb.Readonly))
@@ -877,7 +1203,7 @@ func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState l
 	}
 
 	commitMessage := bytes.NewBufferString("")
-	if isAddCommand {
+	if cfg.isAddCommand {
 		commitMessage.WriteString("ADD")
 	} else {
 		commitMessage.WriteString("COPY")
@@ -885,8 +1211,11 @@ func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState l
 
 	for i, src := range c.Sources() {
 		commitMessage.WriteString(" " + src)
+		if _, ok := parseGitSource(src); ok && cfg.isAddCommand {
+			return errors.New("git sources are only supported with file operations")
+		}
 		if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
-			if !isAddCommand {
+			if !cfg.isAddCommand {
 				return errors.New("source can't be a URL for COPY")
 			}
 
@@ -905,7 +1234,15 @@ func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState l
 			}
 			target := path.Join(fmt.Sprintf("/src-%d", i), f)
 			args = append(args, target)
-			mounts = append(mounts, llb.AddMount(path.Dir(target), llb.HTTP(src, llb.Filename(f), dfCmd(c)), llb.Readonly))
+			httpOpts := []llb.HTTPOption{llb.Filename(f), dfCmd(c)}
+			checksum, err := httpChecksum(src, cfg)
+			if err != nil {
+				return err
+			}
+			if checksum != "" {
+				httpOpts = append(httpOpts, llb.Checksum(checksum))
+			}
+			mounts = append(mounts, llb.AddMount(path.Dir(target), llb.HTTP(src, httpOpts...), llb.Readonly))
 		} else {
 			d, f := splitWildcards(src)
 			targetCmd := fmt.Sprintf("/src-%d", i)
@@ -916,7 +1253,7 @@ func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState l
 			}
 			targetCmd = path.Join(targetCmd, f)
 			args = append(args, targetCmd)
-			mounts = append(mounts, llb.AddMount(targetMount, sourceState, llb.SourcePath(d), llb.Readonly))
+			mounts = append(mounts, llb.AddMount(targetMount, cfg.source, llb.SourcePath(d), llb.Readonly))
 		}
 	}
 
@@ -927,7 +1264,7 @@ func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState l
 		args = append(args[:1], append([]string{"--unpack"}, args[1:]...)...)
 	}
 
-	platform := opt.targetPlatform
+	platform := cfg.opt.targetPlatform
 	if d.platform != nil {
 		platform = *d.platform
 	}
@@ -941,16 +1278,16 @@ func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState l
 		llb.Args(args),
 		llb.Dir("/dest"),
 		llb.ReadonlyRootFS(),
-		dfCmd(cmdToPrint),
-		llb.WithCustomName(prefixCommand(d, uppercaseCmd(processCmdEnv(opt.shlex, cmdToPrint.String(), env)), d.prefixPlatform, &platform)),
-		location(opt.sourceMap, loc),
+		dfCmd(cfg.cmdToPrint),
+		llb.WithCustomName(prefixCommand(d, uppercaseCmd(processCmdEnv(cfg.opt.shlex, cfg.cmdToPrint.String(), env)), d.prefixPlatform, &platform)),
+		location(cfg.opt.sourceMap, cfg.location),
 	}
 	if d.ignoreCache {
 		runOpt = append(runOpt, llb.IgnoreCache)
 	}
 
-	if opt.llbCaps != nil {
-		if err := opt.llbCaps.Supports(pb.CapExecMetaNetwork); err == nil {
+	if cfg.opt.llbCaps != nil {
+		if err := cfg.opt.llbCaps.Supports(pb.CapExecMetaNetwork); err == nil {
 			runOpt = append(runOpt, llb.Network(llb.NetModeNone))
 		}
 	}
@@ -1433,3 +1770,34 @@ func location(sm *llb.SourceMap, locations []parser.Range) llb.ConstraintsOpt {
 	}
 	return sm.Location(loc)
 }
+
+var reGitHTTPRemote = regexp.MustCompile(`^https?://.+\.git$`)
+
+type gitSource struct {
+	remote string
+	ref    string
+	subdir string
+}
+
+// parseGitSource parses a git source of ADD in the form
+// <remote>[#<ref>[:<subdir>]]. Remotes are git@, git:// or ssh:// URLs, or
+// http(s) URLs ending with .git.
+func parseGitSource(src string) (*gitSource, bool) {
+	parts := strings.SplitN(src, "#", 2)
+	remote := parts[0]
+	switch {
+	case strings.HasPrefix(remote, "git@"), strings.HasPrefix(remote, "git://"), strings.HasPrefix(remote, "ssh://"):
+	case reGitHTTPRemote.MatchString(remote):
+	default:
+		return nil, false
+	}
+	gs := &gitSource{remote: remote}
+	if len(parts) == 2 {
+		refAndDir := strings.SplitN(parts[1], ":", 2)
+		gs.ref = refAndDir[0]
+		if len(refAndDir) == 2 {
+			gs.subdir = refAndDir[1]
+		}
+	}
+	return gs, true
+}
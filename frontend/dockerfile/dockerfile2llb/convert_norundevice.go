@@ -0,0 +1,12 @@
+// +build !dfrundevice
+
+package dockerfile2llb
+
+import (
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+func dispatchRunDevices(c *instructions.RunCommand, opt dispatchOpt) ([]llb.RunOption, error) {
+	return nil, nil
+}
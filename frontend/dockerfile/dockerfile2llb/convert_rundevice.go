@@ -0,0 +1,31 @@
+// +build dfrundevice
+
+package dockerfile2llb
+
+import (
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+)
+
+func dispatchRunDevices(c *instructions.RunCommand, opt dispatchOpt) ([]llb.RunOption, error) {
+	devices := instructions.GetDevices(c)
+	if len(devices) == 0 {
+		return nil, nil
+	}
+	if opt.llbCaps != nil {
+		if err := opt.llbCaps.Supports(pb.CapExecDevice); err != nil {
+			return nil, errors.Wrap(err, "device is not supported")
+		}
+	}
+	out := make([]llb.RunOption, 0, len(devices))
+	for _, d := range devices {
+		var opts []llb.DeviceOption
+		if d.Permissions != "" {
+			opts = append(opts, llb.DevicePermissions(d.Permissions))
+		}
+		out = append(out, llb.AddDevice(d.Path, opts...))
+	}
+	return out, nil
+}
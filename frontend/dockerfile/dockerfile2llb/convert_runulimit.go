@@ -0,0 +1,27 @@
+// +build dfrunulimit
+
+package dockerfile2llb
+
+import (
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+)
+
+func dispatchRunUlimits(c *instructions.RunCommand, opt dispatchOpt) ([]llb.RunOption, error) {
+	ulimits := instructions.GetUlimits(c)
+	if len(ulimits) == 0 {
+		return nil, nil
+	}
+	if opt.llbCaps != nil {
+		if err := opt.llbCaps.Supports(pb.CapExecMetaUlimit); err != nil {
+			return nil, errors.Wrap(err, "ulimit is not supported")
+		}
+	}
+	out := make([]llb.RunOption, 0, len(ulimits))
+	for _, u := range ulimits {
+		out = append(out, llb.AddUlimit(llb.UlimitName(u.Name), u.Soft, u.Hard))
+	}
+	return out, nil
+}
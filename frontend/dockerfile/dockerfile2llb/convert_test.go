@@ -1,12 +1,23 @@
 package dockerfile2llb
 
 import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
 	"testing"
 
+	"github.com/containerd/containerd/platforms"
+	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"github.com/moby/buildkit/frontend/subrequests"
+	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/appcontext"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func toEnvMap(args []instructions.KeyValuePairOptional, env []string) map[string]string {
@@ -188,3 +199,327 @@ COPY --from=stage1 f2 /sub/
 	_, _, err = Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{})
 	assert.EqualError(t, err, "circular dependency detected on stage: stage0")
 }
+
+func TestDockerfileNamedContexts(t *testing.T) {
+	df := `FROM scratch AS base
+RUN false
+FROM alpine
+COPY --from=base /foo /
+`
+	var mu sync.Mutex
+	var names []string
+	contextByName := func(ctx context.Context, name string, _ *specs.Platform) (*llb.State, *Image, error) {
+		mu.Lock()
+		names = append(names, name)
+		mu.Unlock()
+		switch name {
+		case "base":
+			st := llb.Scratch()
+			return &st, nil, nil
+		case "alpine":
+			st := llb.Image("busybox")
+			img := emptyImage(platforms.DefaultSpec())
+			img.Config.Env = []string{"FOO=bar"}
+			img.Config.WorkingDir = "/work"
+			return &st, &img, nil
+		}
+		return nil, nil, nil
+	}
+
+	st, img, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		ContextByName: contextByName,
+	})
+	require.NoError(t, err)
+
+	sort.Strings(names)
+	require.Equal(t, []string{"alpine", "base"}, names)
+	require.Contains(t, img.Config.Env, "FOO=bar")
+	require.Equal(t, "/work", img.Config.WorkingDir)
+
+	env, err := st.Env(context.TODO())
+	require.NoError(t, err)
+	require.Contains(t, env, "FOO=bar")
+
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+	var identifiers []string
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if exec := op.GetExec(); exec != nil {
+			require.NotEqual(t, []string{"/bin/sh", "-c", "false"}, exec.Meta.Args, "RUN of the replaced stage should not be part of the build")
+		}
+		if src := op.GetSource(); src != nil {
+			identifiers = append(identifiers, src.Identifier)
+		}
+	}
+	require.Contains(t, identifiers, "docker-image://docker.io/library/busybox:latest")
+	require.NotContains(t, identifiers, "docker-image://docker.io/library/alpine:latest")
+}
+
+func TestDockerfileNamedContextsPlatform(t *testing.T) {
+	df := `FROM --platform=$BUILDPLATFORM alpine AS build
+FROM busybox AS base
+FROM base
+COPY --from=build /foo /
+`
+	var mu sync.Mutex
+	platformsByName := map[string]*specs.Platform{}
+	contextByName := func(ctx context.Context, name string, p *specs.Platform) (*llb.State, *Image, error) {
+		mu.Lock()
+		platformsByName[name] = p
+		mu.Unlock()
+		if name == "alpine" || name == "busybox" {
+			st := llb.Scratch()
+			return &st, nil, nil
+		}
+		return nil, nil, nil
+	}
+
+	buildPlatform := specs.Platform{OS: "linux", Architecture: "amd64"}
+	targetPlatform := specs.Platform{OS: "linux", Architecture: "arm64"}
+	_, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		ContextByName:  contextByName,
+		TargetPlatform: &targetPlatform,
+		BuildPlatforms: []specs.Platform{buildPlatform},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, &buildPlatform, platformsByName["build"])
+	require.Nil(t, platformsByName["base"])
+	require.Equal(t, &buildPlatform, platformsByName["alpine"])
+	require.Equal(t, &targetPlatform, platformsByName["busybox"])
+}
+
+func TestDockerfileCopyLink(t *testing.T) {
+	df := `FROM scratch
+COPY foo /
+COPY --link --chown=1000:1000 foo /bar/
+`
+	caps := pb.Caps.CapSet(pb.Caps.All())
+	st, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps: &caps,
+	})
+	require.NoError(t, err)
+
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+
+	var merges, copies int
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if merge := op.GetMerge(); merge != nil {
+			merges++
+			require.Equal(t, 2, len(merge.Inputs))
+		}
+		if file := op.GetFile(); file != nil {
+			for _, a := range file.Actions {
+				if cp := a.GetCopy(); cp != nil {
+					copies++
+					if cp.Dest == "/bar/" {
+						// copied to an empty state
+						require.Equal(t, pb.Empty, a.Input)
+					}
+				}
+			}
+		}
+	}
+	require.Equal(t, 1, merges)
+	require.Equal(t, 2, copies)
+
+	df = `FROM scratch
+COPY --link --chown=nobody foo /bar/
+`
+	_, _, err = Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps: &caps,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not supported with --link")
+}
+
+func TestDockerfileAddGitAndChecksum(t *testing.T) {
+	df := `FROM scratch
+ADD --keep-git-dir https://example.com/repo.git#v1.0:docs /docs/
+ADD --checksum=sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d https://example.com/foo /foo
+`
+	caps := pb.Caps.CapSet(pb.Caps.All())
+	st, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps: &caps,
+	})
+	require.NoError(t, err)
+
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+
+	sources := map[string]*pb.SourceOp{}
+	var copySrcs []string
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if src := op.GetSource(); src != nil {
+			sources[src.Identifier] = src
+		}
+		if file := op.GetFile(); file != nil {
+			for _, a := range file.Actions {
+				if cp := a.GetCopy(); cp != nil {
+					copySrcs = append(copySrcs, cp.Src)
+				}
+			}
+		}
+	}
+
+	git, ok := sources["git://example.com/repo.git#v1.0"]
+	require.True(t, ok)
+	require.Equal(t, "true", git.Attrs[pb.AttrKeepGitDir])
+	require.Contains(t, copySrcs, "/docs")
+
+	http, ok := sources["https://example.com/foo"]
+	require.True(t, ok)
+	require.Equal(t, "sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d", http.Attrs[pb.AttrHTTPChecksum])
+
+	for _, df := range []string{
+		"FROM scratch\nADD --checksum=sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d foo /foo\n",
+		"FROM scratch\nADD --checksum=foo https://example.com/foo /foo\n",
+	} {
+		_, _, err = Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+			LLBCaps: &caps,
+		})
+		require.Error(t, err)
+	}
+}
+
+func TestDockerfileCopyExcludeParents(t *testing.T) {
+	df := `FROM scratch
+ARG PATTERN=*.md
+COPY --parents --exclude=$PATTERN --exclude=tmp/ src/*/ /dest/
+`
+	caps := pb.Caps.CapSet(pb.Caps.All())
+	st, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps: &caps,
+	})
+	require.NoError(t, err)
+
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+
+	var copies []*pb.FileActionCopy
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if file := op.GetFile(); file != nil {
+			for _, a := range file.Actions {
+				if cp := a.GetCopy(); cp != nil {
+					copies = append(copies, cp)
+				}
+			}
+		}
+	}
+	require.Equal(t, 1, len(copies))
+	require.Equal(t, "/src/*", copies[0].Src)
+	require.Equal(t, []string{"*.md", "tmp/"}, copies[0].ExcludePatterns)
+	require.True(t, copies[0].Parents)
+}
+
+func TestDockerfileExpansionModifiers(t *testing.T) {
+	df := `FROM scratch
+ARG TARGETPLATFORM
+ENV ARCH=${TARGETPLATFORM#*/} OS=${TARGETPLATFORM%%/*}
+ENV VARIANT_DIR=/opt/${TARGETPLATFORM//\//-}
+WORKDIR $VARIANT_DIR
+`
+	tp := platforms.MustParse("linux/arm/v7")
+	_, img, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		TargetPlatform: &tp,
+	})
+	require.NoError(t, err)
+
+	require.Contains(t, img.Config.Env, "ARCH=arm/v7")
+	require.Contains(t, img.Config.Env, "OS=linux")
+	require.Contains(t, img.Config.Env, "VARIANT_DIR=/opt/linux-arm-v7")
+	require.Equal(t, "/opt/linux-arm-v7", img.Config.WorkingDir)
+}
+
+type testMetaResolver struct {
+	dgst digest.Digest
+}
+
+func (r testMetaResolver) ResolveImageConfig(ctx context.Context, ref string, opt llb.ResolveImageConfigOpt) (digest.Digest, []byte, error) {
+	img := emptyImage(platforms.DefaultSpec())
+	img.RootFS.DiffIDs = []digest.Digest{digest.FromString(ref)}
+	dt, err := json.Marshal(img)
+	return r.dgst, dt, err
+}
+
+func TestDockerfileLockfile(t *testing.T) {
+	df := `FROM alpine:3.18 AS base
+FROM busybox@sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d AS unused
+FROM base
+COPY --from=golang:1.20 /usr/local/go /go
+ADD https://example.com/foo /foo
+ADD --checksum=sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d https://example.com/bar /bar
+ADD https://example.com/repo.git#v1.0 /repo/
+`
+	caps := pb.Caps.CapSet(pb.Caps.All())
+	alpineDigest := digest.FromString("alpine")
+	rec := NewLockRecorder()
+	_, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps:      &caps,
+		MetaResolver: testMetaResolver{dgst: alpineDigest},
+		LockRecorder: rec,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &subrequests.Lockfile{
+		Images: []subrequests.LockedImage{
+			{Ref: "docker.io/library/alpine:3.18", Digest: alpineDigest},
+			{Ref: "docker.io/library/golang:1.20"},
+		},
+		HTTP: []subrequests.LockedHTTP{{URL: "https://example.com/foo"}},
+		Git:  []subrequests.LockedGit{{Remote: "https://example.com/repo.git", Ref: "v1.0"}},
+	}, rec.Lockfile())
+
+	commit := "0123456789abcdef0123456789abcdef01234567"
+	lockfile := &subrequests.Lockfile{
+		Images: []subrequests.LockedImage{
+			{Ref: "docker.io/library/alpine:3.18", Digest: alpineDigest},
+			{Ref: "docker.io/library/golang:1.20", Digest: digest.FromString("golang")},
+		},
+		HTTP: []subrequests.LockedHTTP{{URL: "https://example.com/foo", Checksum: digest.FromString("foo")}},
+		Git:  []subrequests.LockedGit{{Remote: "https://example.com/repo.git", Ref: "v1.0", Commit: commit}},
+	}
+	st, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps:      &caps,
+		MetaResolver: testMetaResolver{dgst: alpineDigest},
+		Lockfile:     lockfile,
+	})
+	require.NoError(t, err)
+
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+	sources := map[string]*pb.SourceOp{}
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if src := op.GetSource(); src != nil {
+			sources[src.Identifier] = src
+		}
+	}
+	require.Contains(t, sources, "docker-image://docker.io/library/alpine:3.18@"+alpineDigest.String())
+	require.Contains(t, sources, "docker-image://docker.io/library/golang:1.20@"+digest.FromString("golang").String())
+	require.Contains(t, sources, "git://example.com/repo.git#"+commit)
+	require.Equal(t, digest.FromString("foo").String(), sources["https://example.com/foo"].Attrs[pb.AttrHTTPChecksum])
+
+	for _, l := range []*subrequests.Lockfile{
+		{Images: lockfile.Images, HTTP: lockfile.HTTP},
+		{Images: lockfile.Images, Git: lockfile.Git},
+		{Images: lockfile.Images[:1], HTTP: lockfile.HTTP, Git: lockfile.Git},
+	} {
+		_, _, err = Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+			LLBCaps:      &caps,
+			MetaResolver: testMetaResolver{dgst: alpineDigest},
+			Lockfile:     l,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not pinned by the lockfile")
+	}
+}
@@ -0,0 +1,155 @@
+package dockerfile2llb
+
+import (
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/moby/buildkit/frontend/subrequests"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// ReCommitSHA matches a full git commit SHA
+var ReCommitSHA = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+type gitKey struct {
+	remote string
+	ref    string
+}
+
+// LockRecorder collects the sources of a conversion that a lockfile pins.
+// Sources already pinned by the Dockerfile are not recorded. Image digests
+// resolved by the conversion are recorded, the other digests, checksums and
+// commits are left empty for the caller to resolve.
+type LockRecorder struct {
+	mu     sync.Mutex
+	images map[string]digest.Digest
+	http   map[string]struct{}
+	git    map[gitKey]struct{}
+}
+
+func NewLockRecorder() *LockRecorder {
+	return &LockRecorder{
+		images: map[string]digest.Digest{},
+		http:   map[string]struct{}{},
+		git:    map[gitKey]struct{}{},
+	}
+}
+
+func (r *LockRecorder) addImage(ref string, dgst digest.Digest) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if dgst != "" || r.images[ref] == "" {
+		r.images[ref] = dgst
+	}
+}
+
+func (r *LockRecorder) addHTTP(url string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.http[url] = struct{}{}
+	r.mu.Unlock()
+}
+
+func (r *LockRecorder) addGit(remote, ref string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.git[gitKey{remote: remote, ref: ref}] = struct{}{}
+	r.mu.Unlock()
+}
+
+// Lockfile returns the recorded sources, sorted
+func (r *LockRecorder) Lockfile() *subrequests.Lockfile {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l := &subrequests.Lockfile{}
+	for ref, dgst := range r.images {
+		l.Images = append(l.Images, subrequests.LockedImage{Ref: ref, Digest: dgst})
+	}
+	sort.Slice(l.Images, func(i, j int) bool {
+		return l.Images[i].Ref < l.Images[j].Ref
+	})
+	for url := range r.http {
+		l.HTTP = append(l.HTTP, subrequests.LockedHTTP{URL: url})
+	}
+	sort.Slice(l.HTTP, func(i, j int) bool {
+		return l.HTTP[i].URL < l.HTTP[j].URL
+	})
+	for k := range r.git {
+		l.Git = append(l.Git, subrequests.LockedGit{Remote: k.remote, Ref: k.ref})
+	}
+	sort.Slice(l.Git, func(i, j int) bool {
+		if l.Git[i].Remote != l.Git[j].Remote {
+			return l.Git[i].Remote < l.Git[j].Remote
+		}
+		return l.Git[i].Ref < l.Git[j].Ref
+	})
+	return l
+}
+
+func lockedImage(l *subrequests.Lockfile, ref string) (digest.Digest, error) {
+	for _, img := range l.Images {
+		if img.Ref == ref {
+			if err := img.Digest.Validate(); err != nil {
+				return "", errors.Wrapf(err, "invalid digest of image %s in lockfile", ref)
+			}
+			return img.Digest, nil
+		}
+	}
+	return "", errors.Errorf("image %s is not pinned by the lockfile", ref)
+}
+
+// httpChecksum returns the checksum the content of the URL is verified with,
+// set by the --checksum flag or pinned by the lockfile
+func httpChecksum(src string, cfg copyConfig) (digest.Digest, error) {
+	if cfg.checksum != "" {
+		return cfg.checksum, nil
+	}
+	cfg.opt.lockRecorder.addHTTP(src)
+	if cfg.opt.lockfile == nil {
+		return "", nil
+	}
+	for _, h := range cfg.opt.lockfile.HTTP {
+		if h.URL == src {
+			if err := h.Checksum.Validate(); err != nil {
+				return "", errors.Wrapf(err, "invalid checksum of %s in lockfile", src)
+			}
+			return h.Checksum, nil
+		}
+	}
+	return "", errors.Errorf("URL %s is not pinned by the lockfile", src)
+}
+
+// gitRef returns the ref the git source is checked out at, the commit pinned
+// by the lockfile if the source doesn't point to a commit already
+func gitRef(gs *gitSource, opt dispatchOpt) (string, error) {
+	if ReCommitSHA.MatchString(gs.ref) {
+		return gs.ref, nil
+	}
+	opt.lockRecorder.addGit(gs.remote, gs.ref)
+	if opt.lockfile == nil {
+		return gs.ref, nil
+	}
+	for _, g := range opt.lockfile.Git {
+		if g.Remote == gs.remote && g.Ref == gs.ref {
+			if !ReCommitSHA.MatchString(g.Commit) {
+				return "", errors.Errorf("invalid commit %q of git source %s in lockfile", g.Commit, gs.remote)
+			}
+			return g.Commit, nil
+		}
+	}
+	src := gs.remote
+	if gs.ref != "" {
+		src += "#" + gs.ref
+	}
+	return "", errors.Errorf("git source %s is not pinned by the lockfile", src)
+}
@@ -0,0 +1,171 @@
+// +build dfheredoc
+
+package dockerfile
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/continuity/fs/fstest"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/frontend/dockerfile/builder"
+	"github.com/moby/buildkit/util/testutil/integration"
+	"github.com/stretchr/testify/require"
+)
+
+var hdTests = []integration.Test{
+	testCopyHeredoc,
+	testRunBasicHeredoc,
+	testRunShebangHeredoc,
+	testRunComplexHeredoc,
+}
+
+func init() {
+	allTests = append(allTests, hdTests...)
+}
+
+func testCopyHeredoc(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	dockerfile := []byte(`
+FROM busybox AS build
+
+ENV NAME=world
+COPY <<EOF /dest/expanded
+hello $NAME
+EOF
+COPY <<'EOF' /dest/literal
+hello $NAME
+EOF
+COPY <<-EOF /dest/chomped
+	it's "quoted"
+	EOF
+
+FROM scratch
+COPY --from=build /dest /
+`)
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := client.New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	destDir, err := ioutil.TempDir("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	_, err = f.Solve(context.TODO(), c, client.SolveOpt{
+		Exports: []client.ExportEntry{
+			{
+				Type:      client.ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+			builder.DefaultLocalNameContext:    dir,
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	contents := map[string]string{
+		"expanded": "hello world\n",
+		"literal":  "hello $NAME\n",
+		"chomped":  "it's \"quoted\"\n",
+	}
+	for name, content := range contents {
+		dt, err := ioutil.ReadFile(filepath.Join(destDir, name))
+		require.NoError(t, err)
+		require.Equal(t, content, string(dt))
+	}
+}
+
+func testRunBasicHeredoc(t *testing.T, sb integration.Sandbox) {
+	testRunHeredoc(t, sb, []byte(`
+FROM busybox AS build
+
+RUN <<EOF
+echo "i am" > /dest
+whoami >> /dest
+EOF
+
+FROM scratch
+COPY --from=build /dest /dest
+`), "i am\nroot\n")
+}
+
+func testRunShebangHeredoc(t *testing.T, sb integration.Sandbox) {
+	testRunHeredoc(t, sb, []byte(`
+FROM busybox AS build
+
+RUN <<EOF
+#!/bin/awk -f
+BEGIN {
+	print "hello" > "/dest"
+}
+EOF
+
+FROM scratch
+COPY --from=build /dest /dest
+`), "hello\n")
+}
+
+func testRunComplexHeredoc(t *testing.T, sb integration.Sandbox) {
+	testRunHeredoc(t, sb, []byte(`
+FROM busybox AS build
+
+RUN cat <<EOF1 | tr '[:upper:]' '[:lower:]' > /dest1; cat <<EOF2 > /dest2
+HELLO
+EOF1
+WORLD
+EOF2
+RUN cat /dest1 /dest2 > /dest
+
+FROM scratch
+COPY --from=build /dest /dest
+`), "hello\nWORLD\n")
+}
+
+func testRunHeredoc(t *testing.T, sb integration.Sandbox, dockerfile []byte, expected string) {
+	f := getFrontend(t, sb)
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := client.New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	destDir, err := ioutil.TempDir("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	_, err = f.Solve(context.TODO(), c, client.SolveOpt{
+		Exports: []client.ExportEntry{
+			{
+				Type:      client.ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+			builder.DefaultLocalNameContext:    dir,
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	dt, err := ioutil.ReadFile(filepath.Join(destDir, "dest"))
+	require.NoError(t, err)
+	require.Equal(t, expected, string(dt))
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/moby/buildkit/util/testutil"
 	"github.com/moby/buildkit/util/testutil/httpserver"
 	"github.com/moby/buildkit/util/testutil/integration"
+	digest "github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -105,9 +106,14 @@ var allTests = []integration.Test{
 	testErrorsSourceMap,
 	testMultiArgs,
 	testFrontendSubrequests,
+	testFrontendLint,
+	testFrontendOutline,
+	testFrontendLock,
 	testDockefileCheckHostname,
 	testDefaultShellAndPath,
 	testDockerfileLowercase,
+	testNamedImageContext,
+	testNamedLocalContext,
 }
 
 var fileOpTests = []integration.Test{
@@ -132,6 +138,10 @@ var fileOpTests = []integration.Test{
 	testWorkdirCopyIgnoreRelative,
 	testCopyFollowAllSymlinks,
 	testDockerfileAddChownExpand,
+	testCopyLink,
+	testAddGit,
+	testAddChecksum,
+	testCopyExcludeParents,
 }
 
 // Tests that depend on the `security.*` entitlements
@@ -2761,6 +2771,106 @@ func testDockerfileLowercase(t *testing.T, sb integration.Sandbox) {
 	require.NoError(t, err)
 }
 
+func testNamedImageContext(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	dockerfile := []byte(`
+FROM busybox AS base
+RUN cat /etc/alpine-release > /out
+FROM scratch
+COPY --from=base /out /
+`)
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := client.New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	destDir, err := ioutil.TempDir("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	_, err = f.Solve(context.TODO(), c, client.SolveOpt{
+		FrontendAttrs: map[string]string{
+			"context:busybox": "docker-image://alpine",
+		},
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+			builder.DefaultLocalNameContext:    dir,
+		},
+		Exports: []client.ExportEntry{
+			{
+				Type:      client.ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	dt, err := ioutil.ReadFile(filepath.Join(destDir, "out"))
+	require.NoError(t, err)
+	require.True(t, len(dt) > 0)
+}
+
+func testNamedLocalContext(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	dockerfile := []byte(`
+FROM busybox AS base
+RUN echo bar > /foo
+FROM scratch
+COPY --from=base /foo /
+`)
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	outf := []byte(`contents`)
+	dir2, err := tmpdir(
+		fstest.CreateFile("foo", outf, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir2)
+
+	c, err := client.New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	destDir, err := ioutil.TempDir("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	_, err = f.Solve(context.TODO(), c, client.SolveOpt{
+		FrontendAttrs: map[string]string{
+			"context:base": "local:basedir",
+		},
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+			builder.DefaultLocalNameContext:    dir,
+			"basedir":                          dir2,
+		},
+		Exports: []client.ExportEntry{
+			{
+				Type:      client.ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	dt, err := ioutil.ReadFile(filepath.Join(destDir, "foo"))
+	require.NoError(t, err)
+	require.Equal(t, outf, dt)
+}
+
 func testExportedHistory(t *testing.T, sb integration.Sandbox) {
 	skipDockerd(t, sb)
 	f := getFrontend(t, sb)
@@ -3061,6 +3171,262 @@ COPY --from=base /out /
 	require.Equal(t, "1000 nobody\n", string(dt))
 }
 
+func testCopyLink(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+	isFileOp := getFileOp(t, sb)
+
+	dockerfile := []byte(`
+FROM busybox AS base
+RUN mkdir -m 0700 /out
+COPY --link --chown=1000:1000 foo /out/
+ADD --link bar /out/baz
+RUN stat -c "%u %a" /out/foo > /out/fooperm
+
+FROM scratch
+COPY --from=base /out /
+`)
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+		fstest.CreateFile("foo", []byte(`foo-contents`), 0600),
+		fstest.CreateFile("bar", []byte(`bar-contents`), 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := client.New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	destDir, err := ioutil.TempDir("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	_, err = f.Solve(context.TODO(), c, client.SolveOpt{
+		Exports: []client.ExportEntry{
+			{
+				Type:      client.ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+		FrontendAttrs: map[string]string{
+			"build-arg:BUILDKIT_DISABLE_FILEOP": strconv.FormatBool(!isFileOp),
+		},
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+			builder.DefaultLocalNameContext:    dir,
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	dt, err := ioutil.ReadFile(filepath.Join(destDir, "foo"))
+	require.NoError(t, err)
+	require.Equal(t, "foo-contents", string(dt))
+
+	dt, err = ioutil.ReadFile(filepath.Join(destDir, "baz"))
+	require.NoError(t, err)
+	require.Equal(t, "bar-contents", string(dt))
+
+	dt, err = ioutil.ReadFile(filepath.Join(destDir, "fooperm"))
+	require.NoError(t, err)
+	require.Equal(t, "1000 600\n", string(dt))
+}
+
+func testAddGit(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+	isFileOp := getFileOp(t, sb)
+	if !isFileOp {
+		t.Skip("git sources require file operations")
+	}
+
+	gitDir, err := ioutil.TempDir("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(gitDir)
+
+	err = os.MkdirAll(filepath.Join(gitDir, "sub"), 0700)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(gitDir, "sub", "foo"), []byte("fromgit"), 0600)
+	require.NoError(t, err)
+
+	err = runShell(gitDir,
+		"git init",
+		"git config --local user.email test",
+		"git config --local user.name test",
+		"git add sub/foo",
+		"git commit -m initial",
+		"git tag v1",
+		"git update-server-info",
+	)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(filepath.Join(gitDir))))
+	defer server.Close()
+
+	dockerfile := []byte(fmt.Sprintf(`
+FROM scratch
+ADD %s /dest/
+ADD --keep-git-dir %s /repo/
+`, server.URL+"/.git#v1:sub", server.URL+"/.git#v1"))
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := client.New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	destDir, err := ioutil.TempDir("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	_, err = f.Solve(context.TODO(), c, client.SolveOpt{
+		Exports: []client.ExportEntry{
+			{
+				Type:      client.ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+			builder.DefaultLocalNameContext:    dir,
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	dt, err := ioutil.ReadFile(filepath.Join(destDir, "dest/foo"))
+	require.NoError(t, err)
+	require.Equal(t, "fromgit", string(dt))
+
+	dt, err = ioutil.ReadFile(filepath.Join(destDir, "repo/sub/foo"))
+	require.NoError(t, err)
+	require.Equal(t, "fromgit", string(dt))
+
+	_, err = os.Stat(filepath.Join(destDir, "repo/.git"))
+	require.NoError(t, err)
+}
+
+func testAddChecksum(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	resp := httpserver.Response{
+		Etag:    identity.NewID(),
+		Content: []byte("content1"),
+	}
+	server := httpserver.NewTestServer(map[string]httpserver.Response{
+		"/foo": resp,
+	})
+	defer server.Close()
+
+	c, err := client.New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	for _, tc := range []struct {
+		checksum string
+		valid    bool
+	}{
+		{digest.FromBytes(resp.Content).String(), true},
+		{digest.FromBytes([]byte("content2")).String(), false},
+	} {
+		dockerfile := []byte(fmt.Sprintf(`
+FROM scratch
+ADD --checksum=%s %s /dest/
+`, tc.checksum, server.URL+"/foo"))
+
+		dir, err := tmpdir(
+			fstest.CreateFile("Dockerfile", dockerfile, 0600),
+		)
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		_, err = f.Solve(context.TODO(), c, client.SolveOpt{
+			LocalDirs: map[string]string{
+				builder.DefaultLocalNameDockerfile: dir,
+				builder.DefaultLocalNameContext:    dir,
+			},
+		}, nil)
+		if tc.valid {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "digest mismatch")
+		}
+	}
+}
+
+func testCopyExcludeParents(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+	isFileOp := getFileOp(t, sb)
+	if !isFileOp {
+		t.Skip("--exclude and --parents require file operations")
+	}
+
+	dockerfile := []byte(`
+FROM scratch
+COPY --exclude=*.md --exclude=tmp docs /docs/
+COPY --parents src/*/main.go /app/
+`)
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+		fstest.CreateDir("docs", 0700),
+		fstest.CreateFile("docs/index.html", []byte("index"), 0600),
+		fstest.CreateFile("docs/README.md", []byte("readme"), 0600),
+		fstest.CreateDir("docs/tmp", 0700),
+		fstest.CreateFile("docs/tmp/foo", []byte("foo"), 0600),
+		fstest.CreateDir("src", 0700),
+		fstest.CreateDir("src/foo", 0700),
+		fstest.CreateFile("src/foo/main.go", []byte("foo"), 0600),
+		fstest.CreateDir("src/bar", 0700),
+		fstest.CreateFile("src/bar/main.go", []byte("bar"), 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := client.New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	destDir, err := ioutil.TempDir("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	_, err = f.Solve(context.TODO(), c, client.SolveOpt{
+		Exports: []client.ExportEntry{
+			{
+				Type:      client.ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+			builder.DefaultLocalNameContext:    dir,
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	dt, err := ioutil.ReadFile(filepath.Join(destDir, "docs/index.html"))
+	require.NoError(t, err)
+	require.Equal(t, "index", string(dt))
+
+	_, err = os.Stat(filepath.Join(destDir, "docs/README.md"))
+	require.True(t, errors.Is(err, os.ErrNotExist))
+
+	_, err = os.Stat(filepath.Join(destDir, "docs/tmp"))
+	require.True(t, errors.Is(err, os.ErrNotExist))
+
+	dt, err = ioutil.ReadFile(filepath.Join(destDir, "app/src/foo/main.go"))
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(dt))
+
+	dt, err = ioutil.ReadFile(filepath.Join(destDir, "app/src/bar/main.go"))
+	require.NoError(t, err)
+	require.Equal(t, "bar", string(dt))
+}
+
 func testCopyChmod(t *testing.T, sb integration.Sandbox) {
 	f := getFrontend(t, sb)
 	isFileOp := getFileOp(t, sb)
@@ -4888,6 +5254,246 @@ COPY Dockerfile Dockerfile
 	require.True(t, called)
 }
 
+func testFrontendLint(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	c, err := client.New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	dockerfile := []byte(`# check=skip=JSONArgsRecommended;error=true
+FROM scratch AS unused
+FROM scratch
+maintainer me@example.com
+CMD echo hello
+`)
+
+	if gf, ok := f.(*gatewayFrontend); ok {
+		dockerfile = []byte(fmt.Sprintf("#syntax=%s\n%s", gf.gw, dockerfile))
+	}
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	called := false
+
+	frontend := func(ctx context.Context, c gateway.Client) (*gateway.Result, error) {
+		res, err := c.Solve(ctx, gateway.SolveRequest{
+			FrontendOpt: map[string]string{
+				"requestid":     subrequests.RequestLint,
+				"frontend.caps": "moby.buildkit.frontend.subrequests",
+			},
+			Frontend: "dockerfile.v0",
+		})
+		require.NoError(t, err)
+
+		var results subrequests.LintResults
+		require.NoError(t, json.Unmarshal(res.Metadata["result.json"], &results))
+
+		var rules []string
+		for _, w := range results.Warnings {
+			rules = append(rules, w.RuleName)
+			require.NotEmpty(t, w.Location)
+		}
+		require.Equal(t, []string{"UnreachableStage", "ConsistentInstructionCasing", "MaintainerDeprecated"}, rules)
+		require.NotEmpty(t, results.Error)
+
+		called = true
+		return nil, nil
+	}
+
+	_, err = c.Build(context.TODO(), client.SolveOpt{
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+		},
+	}, "", frontend, nil)
+	require.NoError(t, err)
+	require.True(t, called)
+
+	_, err = f.Solve(context.TODO(), c, client.SolveOpt{
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+			builder.DefaultLocalNameContext:    dir,
+		},
+	}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "lint violation UnreachableStage")
+}
+
+func testFrontendOutline(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	c, err := client.New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	dockerfile := []byte(`
+ARG BASE=scratch
+FROM ${BASE} AS base
+# VERSION of the release
+ARG VERSION=v1
+RUN --mount=type=secret,id=token true
+
+# release is the default target
+FROM base AS release
+`)
+
+	if gf, ok := f.(*gatewayFrontend); ok {
+		dockerfile = []byte(fmt.Sprintf("#syntax=%s\n\n%s", gf.gw, dockerfile))
+	}
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	called := false
+
+	frontend := func(ctx context.Context, c gateway.Client) (*gateway.Result, error) {
+		res, err := c.Solve(ctx, gateway.SolveRequest{
+			FrontendOpt: map[string]string{
+				"requestid":     subrequests.RequestOutline,
+				"frontend.caps": "moby.buildkit.frontend.subrequests",
+			},
+			Frontend: "dockerfile.v0",
+		})
+		require.NoError(t, err)
+
+		var outline subrequests.Outline
+		require.NoError(t, json.Unmarshal(res.Metadata["result.json"], &outline))
+		require.Equal(t, "release", outline.Name)
+		require.Equal(t, "is the default target", outline.Description)
+		require.Equal(t, []string{"base", "release"}, outline.Stages)
+		require.Equal(t, 2, len(outline.Args))
+		require.Equal(t, "BASE", outline.Args[0].Name)
+		require.Equal(t, "VERSION", outline.Args[1].Name)
+		require.Equal(t, "of the release", outline.Args[1].Description)
+		require.Equal(t, 1, len(outline.Secrets))
+		require.Equal(t, "token", outline.Secrets[0].ID)
+
+		res, err = c.Solve(ctx, gateway.SolveRequest{
+			FrontendOpt: map[string]string{
+				"requestid":     subrequests.RequestTargets,
+				"frontend.caps": "moby.buildkit.frontend.subrequests",
+			},
+			Frontend: "dockerfile.v0",
+		})
+		require.NoError(t, err)
+
+		var targets subrequests.TargetsList
+		require.NoError(t, json.Unmarshal(res.Metadata["result.json"], &targets))
+		require.Equal(t, 2, len(targets.Targets))
+		require.Equal(t, "base", targets.Targets[0].Name)
+		require.Equal(t, "${BASE}", targets.Targets[0].Base)
+		require.True(t, targets.Targets[1].Default)
+
+		called = true
+		return nil, nil
+	}
+
+	_, err = c.Build(context.TODO(), client.SolveOpt{
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+		},
+	}, "", frontend, nil)
+	require.NoError(t, err)
+	require.True(t, called)
+}
+
+func testFrontendLock(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	c, err := client.New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	content := []byte("content1")
+	server := httpserver.NewTestServer(map[string]httpserver.Response{
+		"/foo": {
+			Etag:    identity.NewID(),
+			Content: content,
+		},
+	})
+	defer server.Close()
+
+	dockerfile := []byte(fmt.Sprintf(`
+FROM busybox AS base
+ADD %s /foo
+RUN [ "$(cat /foo)" = "content1" ]
+`, server.URL+"/foo"))
+
+	if gf, ok := f.(*gatewayFrontend); ok {
+		dockerfile = []byte(fmt.Sprintf("#syntax=%s\n\n%s", gf.gw, dockerfile))
+	}
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	called := false
+
+	frontend := func(ctx context.Context, c gateway.Client) (*gateway.Result, error) {
+		res, err := c.Solve(ctx, gateway.SolveRequest{
+			FrontendOpt: map[string]string{
+				"requestid":     subrequests.RequestLock,
+				"frontend.caps": "moby.buildkit.frontend.subrequests",
+			},
+			Frontend: "dockerfile.v0",
+		})
+		require.NoError(t, err)
+
+		var lockfile subrequests.Lockfile
+		require.NoError(t, json.Unmarshal(res.Metadata["result.json"], &lockfile))
+		require.Equal(t, 1, len(lockfile.Images))
+		require.Equal(t, "docker.io/library/busybox:latest", lockfile.Images[0].Ref)
+		require.NotEmpty(t, lockfile.Images[0].Digest)
+		require.Equal(t, []subrequests.LockedHTTP{{
+			URL:      server.URL + "/foo",
+			Checksum: digest.FromBytes(content),
+		}}, lockfile.HTTP)
+
+		dt, err := json.Marshal(lockfile)
+		require.NoError(t, err)
+		_, err = c.Solve(ctx, gateway.SolveRequest{
+			FrontendOpt: map[string]string{
+				"lockfile": string(dt),
+			},
+			Frontend: "dockerfile.v0",
+		})
+		require.NoError(t, err)
+
+		lockfile.HTTP = nil
+		dt, err = json.Marshal(lockfile)
+		require.NoError(t, err)
+		_, err = c.Solve(ctx, gateway.SolveRequest{
+			FrontendOpt: map[string]string{
+				"lockfile": string(dt),
+			},
+			Frontend: "dockerfile.v0",
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not pinned by the lockfile")
+
+		called = true
+		return nil, nil
+	}
+
+	_, err = c.Build(context.TODO(), client.SolveOpt{
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+			builder.DefaultLocalNameContext:    dir,
+		},
+	}, "", frontend, nil)
+	require.NoError(t, err)
+	require.True(t, called)
+}
+
 // moby/buildkit#1301
 func testDockefileCheckHostname(t *testing.T, sb integration.Sandbox) {
 	f := getFrontend(t, sb)
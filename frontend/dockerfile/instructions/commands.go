@@ -76,6 +76,12 @@ type SupportsSingleWordExpansion interface {
 	Expand(expander SingleWordExpander) error
 }
 
+// SupportsSingleWordExpansionRaw interface marks a command as supporting
+// variable expansion of raw content, like the body of a here-document
+type SupportsSingleWordExpansionRaw interface {
+	ExpandRaw(expander SingleWordExpander) error
+}
+
 // PlatformSpecific adds platform checks to a command
 type PlatformSpecific interface {
 	CheckPlatform(platform string) error
@@ -180,6 +186,28 @@ func (s SourcesAndDest) Dest() string {
 	return s[len(s)-1]
 }
 
+// SourceContent represents an inline source, like a here-document, that is
+// written to the destination as a file named Path
+type SourceContent struct {
+	Path   string
+	Data   string
+	Expand bool
+}
+
+func expandSourceContentsInPlace(contents []SourceContent, expander SingleWordExpander) error {
+	for i, content := range contents {
+		if !content.Expand {
+			continue
+		}
+		data, err := expander(content.Data)
+		if err != nil {
+			return err
+		}
+		contents[i].Data = data
+	}
+	return nil
+}
+
 // AddCommand : ADD foo /path
 //
 // Add the file 'foo' to '/path'. Tarball and Remote URL (http, https) handling
@@ -188,8 +216,14 @@ func (s SourcesAndDest) Dest() string {
 type AddCommand struct {
 	withNameAndCode
 	SourcesAndDest
-	Chown string
-	Chmod string
+	SourceContents []SourceContent
+	Chown          string
+	Chmod          string
+	Link           bool
+	Checksum       string
+	KeepGitDir     bool
+	Excludes       []string
+	Parents        bool
 }
 
 // Expand variables
@@ -199,9 +233,22 @@ func (c *AddCommand) Expand(expander SingleWordExpander) error {
 		return err
 	}
 	c.Chown = expandedChown
+	expandedChecksum, err := expander(c.Checksum)
+	if err != nil {
+		return err
+	}
+	c.Checksum = expandedChecksum
+	if err := expandSliceInPlace(c.Excludes, expander); err != nil {
+		return err
+	}
 	return expandSliceInPlace(c.SourcesAndDest, expander)
 }
 
+// ExpandRaw expands variables in the inline sources
+func (c *AddCommand) ExpandRaw(expander SingleWordExpander) error {
+	return expandSourceContentsInPlace(c.SourceContents, expander)
+}
+
 // CopyCommand : COPY foo /path
 //
 // Same as 'ADD' but without the tar and remote url handling.
@@ -209,9 +256,13 @@ func (c *AddCommand) Expand(expander SingleWordExpander) error {
 type CopyCommand struct {
 	withNameAndCode
 	SourcesAndDest
-	From  string
-	Chown string
-	Chmod string
+	SourceContents []SourceContent
+	From           string
+	Chown          string
+	Chmod          string
+	Link           bool
+	Excludes       []string
+	Parents        bool
 }
 
 // Expand variables
@@ -221,9 +272,17 @@ func (c *CopyCommand) Expand(expander SingleWordExpander) error {
 		return err
 	}
 	c.Chown = expandedChown
+	if err := expandSliceInPlace(c.Excludes, expander); err != nil {
+		return err
+	}
 	return expandSliceInPlace(c.SourcesAndDest, expander)
 }
 
+// ExpandRaw expands variables in the inline sources
+func (c *CopyCommand) ExpandRaw(expander SingleWordExpander) error {
+	return expandSourceContentsInPlace(c.SourceContents, expander)
+}
+
 // OnbuildCommand : ONBUILD <some other command>
 type OnbuildCommand struct {
 	withNameAndCode
@@ -249,9 +308,17 @@ func (c *WorkdirCommand) Expand(expander SingleWordExpander) error {
 	return nil
 }
 
+// ShellInlineFile represents a here-document passed to a shell command
+type ShellInlineFile struct {
+	Name  string
+	Data  string
+	Chomp bool
+}
+
 // ShellDependantCmdLine represents a cmdline optionally prepended with the shell
 type ShellDependantCmdLine struct {
 	CmdLine      strslice.StrSlice
+	Files        []ShellInlineFile
 	PrependShell bool
 }
 
@@ -0,0 +1,58 @@
+// +build dfrundevice
+
+package instructions
+
+import (
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+)
+
+type devicesKeyT string
+
+var devicesKey = devicesKeyT("dockerfile/run/devices")
+
+func init() {
+	parseRunPreHooks = append(parseRunPreHooks, runDevicePreHook)
+	parseRunPostHooks = append(parseRunPostHooks, runDevicePostHook)
+}
+
+func runDevicePreHook(cmd *RunCommand, req parseRequest) error {
+	st := &deviceState{}
+	st.flag = req.flags.AddStrings("device")
+	cmd.setExternalValue(devicesKey, st)
+	return nil
+}
+
+func runDevicePostHook(cmd *RunCommand, req parseRequest) error {
+	st := getDeviceState(cmd)
+	if st == nil {
+		return errors.Errorf("no device state")
+	}
+	var devices []*pb.Device
+	for _, str := range st.flag.StringValues {
+		d, err := pb.ParseDevice(str)
+		if err != nil {
+			return err
+		}
+		devices = append(devices, d)
+	}
+	st.devices = devices
+	return nil
+}
+
+func getDeviceState(cmd *RunCommand) *deviceState {
+	v := cmd.getExternalValue(devicesKey)
+	if v == nil {
+		return nil
+	}
+	return v.(*deviceState)
+}
+
+func GetDevices(cmd *RunCommand) []*pb.Device {
+	return getDeviceState(cmd).devices
+}
+
+type deviceState struct {
+	flag    *Flag
+	devices []*pb.Device
+}
@@ -0,0 +1,32 @@
+// +build dfrundevice
+
+package instructions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDevice(t *testing.T) {
+	ast, err := parser.Parse(strings.NewReader("RUN --device=/dev/fuse --device=/dev/kvm:rw true"))
+	require.NoError(t, err)
+	cmd, err := ParseInstruction(ast.AST.Children[0])
+	require.NoError(t, err)
+	run, ok := cmd.(*RunCommand)
+	require.True(t, ok)
+	require.Equal(t, []*pb.Device{
+		{Path: "/dev/fuse"},
+		{Path: "/dev/kvm", Permissions: "rw"},
+	}, GetDevices(run))
+
+	for _, v := range []string{"fuse", "/dev/../fuse", "/dev/fuse:", "/dev/fuse:rx", "/dev/fuse:rr"} {
+		ast, err := parser.Parse(strings.NewReader("RUN --device=" + v + " true"))
+		require.NoError(t, err)
+		_, err = ParseInstruction(ast.AST.Children[0])
+		require.Error(t, err, v)
+	}
+}
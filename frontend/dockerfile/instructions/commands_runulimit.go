@@ -0,0 +1,58 @@
+// +build dfrunulimit
+
+package instructions
+
+import (
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+)
+
+type ulimitsKeyT string
+
+var ulimitsKey = ulimitsKeyT("dockerfile/run/ulimits")
+
+func init() {
+	parseRunPreHooks = append(parseRunPreHooks, runUlimitPreHook)
+	parseRunPostHooks = append(parseRunPostHooks, runUlimitPostHook)
+}
+
+func runUlimitPreHook(cmd *RunCommand, req parseRequest) error {
+	st := &ulimitState{}
+	st.flag = req.flags.AddStrings("ulimit")
+	cmd.setExternalValue(ulimitsKey, st)
+	return nil
+}
+
+func runUlimitPostHook(cmd *RunCommand, req parseRequest) error {
+	st := getUlimitState(cmd)
+	if st == nil {
+		return errors.Errorf("no ulimit state")
+	}
+	var ulimits []*pb.Ulimit
+	for _, str := range st.flag.StringValues {
+		u, err := pb.ParseUlimit(str)
+		if err != nil {
+			return err
+		}
+		ulimits = append(ulimits, u)
+	}
+	st.ulimits = ulimits
+	return nil
+}
+
+func getUlimitState(cmd *RunCommand) *ulimitState {
+	v := cmd.getExternalValue(ulimitsKey)
+	if v == nil {
+		return nil
+	}
+	return v.(*ulimitState)
+}
+
+func GetUlimits(cmd *RunCommand) []*pb.Ulimit {
+	return getUlimitState(cmd).ulimits
+}
+
+type ulimitState struct {
+	flag    *Flag
+	ulimits []*pb.Ulimit
+}
@@ -0,0 +1,32 @@
+// +build dfrunulimit
+
+package instructions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunUlimit(t *testing.T) {
+	ast, err := parser.Parse(strings.NewReader("RUN --ulimit=nofile=1024:2048 --ulimit=nproc=100 true"))
+	require.NoError(t, err)
+	cmd, err := ParseInstruction(ast.AST.Children[0])
+	require.NoError(t, err)
+	run, ok := cmd.(*RunCommand)
+	require.True(t, ok)
+	require.Equal(t, []*pb.Ulimit{
+		{Name: "nofile", Soft: 1024, Hard: 2048},
+		{Name: "nproc", Soft: 100, Hard: 100},
+	}, GetUlimits(run))
+
+	for _, v := range []string{"foo=1", "nofile", "nofile=a", "nofile=2048:1024"} {
+		ast, err := parser.Parse(strings.NewReader("RUN --ulimit=" + v + " true"))
+		require.NoError(t, err)
+		_, err = ParseInstruction(ast.AST.Children[0])
+		require.Error(t, err, v)
+	}
+}
@@ -23,6 +23,7 @@ type parseRequest struct {
 	original   string
 	location   []parser.Range
 	comments   []string
+	heredocs   []parser.Heredoc
 }
 
 var parseRunPreHooks []func(*RunCommand, parseRequest) error
@@ -52,6 +53,7 @@ func newParseRequestFromNode(node *parser.Node) parseRequest {
 		flags:      NewBFlagsWithArgs(node.Flags),
 		location:   node.Location(),
 		comments:   node.PrevComment,
+		heredocs:   node.Heredocs,
 	}
 }
 
@@ -242,14 +244,29 @@ func parseAdd(req parseRequest) (*AddCommand, error) {
 	}
 	flChown := req.flags.AddString("chown", "")
 	flChmod := req.flags.AddString("chmod", "")
+	flLink := req.flags.AddBool("link", false)
+	flChecksum := req.flags.AddString("checksum", "")
+	flKeepGitDir := req.flags.AddBool("keep-git-dir", false)
+	flExcludes := req.flags.AddStrings("exclude")
+	flParents := req.flags.AddBool("parents", false)
 	if err := req.flags.Parse(); err != nil {
 		return nil, err
 	}
+	sourcesAndDest, sourceContents, err := parseSourcesAndDest(req, "ADD")
+	if err != nil {
+		return nil, err
+	}
 	return &AddCommand{
-		SourcesAndDest:  SourcesAndDest(req.args),
+		SourcesAndDest:  sourcesAndDest,
+		SourceContents:  sourceContents,
 		withNameAndCode: newWithNameAndCode(req),
 		Chown:           flChown.Value,
 		Chmod:           flChmod.Value,
+		Link:            flLink.IsTrue(),
+		Checksum:        flChecksum.Value,
+		KeepGitDir:      flKeepGitDir.IsTrue(),
+		Excludes:        flExcludes.StringValues,
+		Parents:         flParents.IsTrue(),
 	}, nil
 }
 
@@ -260,18 +277,65 @@ func parseCopy(req parseRequest) (*CopyCommand, error) {
 	flChown := req.flags.AddString("chown", "")
 	flFrom := req.flags.AddString("from", "")
 	flChmod := req.flags.AddString("chmod", "")
+	flLink := req.flags.AddBool("link", false)
+	flExcludes := req.flags.AddStrings("exclude")
+	flParents := req.flags.AddBool("parents", false)
 	if err := req.flags.Parse(); err != nil {
 		return nil, err
 	}
+	sourcesAndDest, sourceContents, err := parseSourcesAndDest(req, "COPY")
+	if err != nil {
+		return nil, err
+	}
 	return &CopyCommand{
-		SourcesAndDest:  SourcesAndDest(req.args),
+		SourcesAndDest:  sourcesAndDest,
+		SourceContents:  sourceContents,
 		From:            flFrom.Value,
 		withNameAndCode: newWithNameAndCode(req),
 		Chown:           flChown.Value,
 		Chmod:           flChmod.Value,
+		Link:            flLink.IsTrue(),
+		Excludes:        flExcludes.StringValues,
+		Parents:         flParents.IsTrue(),
 	}, nil
 }
 
+// parseSourcesAndDest splits the here-documents used as sources from the
+// source paths and destination of an ADD or COPY instruction
+func parseSourcesAndDest(req parseRequest, command string) (SourcesAndDest, []SourceContent, error) {
+	srcs := req.args[:len(req.args)-1]
+	dest := req.args[len(req.args)-1]
+	if heredoc := parser.MustParseHeredoc(dest); heredoc != nil && len(req.heredocs) > 0 {
+		return nil, nil, errors.Errorf("%s cannot accept a heredoc as a destination", command)
+	}
+
+	heredocs := make(map[string]parser.Heredoc, len(req.heredocs))
+	for _, heredoc := range req.heredocs {
+		heredocs[heredoc.Name] = heredoc
+	}
+
+	var sourcesAndDest SourcesAndDest
+	var sourceContents []SourceContent
+	for _, src := range srcs {
+		heredoc := parser.MustParseHeredoc(src)
+		if heredoc == nil || len(req.heredocs) == 0 {
+			sourcesAndDest = append(sourcesAndDest, src)
+			continue
+		}
+		content := heredocs[heredoc.Name].Content
+		if heredoc.Chomp {
+			content = parser.ChompHeredocContent(content)
+		}
+		sourceContents = append(sourceContents, SourceContent{
+			Path:   heredoc.Name,
+			Data:   content,
+			Expand: heredoc.Expand,
+		})
+	}
+	sourcesAndDest = append(sourcesAndDest, dest)
+	return sourcesAndDest, sourceContents, nil
+}
+
 func parseFrom(req parseRequest) (*Stage, error) {
 	stageName, err := parseBuildStageName(req.args)
 	if err != nil {
@@ -357,8 +421,17 @@ func parseShellDependentCommand(req parseRequest, emptyAsNil bool) ShellDependan
 	if emptyAsNil && len(cmd) == 0 {
 		cmd = nil
 	}
+	var files []ShellInlineFile
+	for _, heredoc := range req.heredocs {
+		files = append(files, ShellInlineFile{
+			Name:  heredoc.Name,
+			Data:  heredoc.Content,
+			Chomp: heredoc.Chomp,
+		})
+	}
 	return ShellDependantCmdLine{
 		CmdLine:      cmd,
+		Files:        files,
 		PrependShell: !req.attributes["json"],
 	}
 }
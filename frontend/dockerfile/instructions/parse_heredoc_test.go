@@ -0,0 +1,53 @@
+// +build dfheredoc
+
+package instructions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyHeredoc(t *testing.T) {
+	dockerfile := "COPY --chmod=755 foo <<-EOF1 <<'EOF2' /dest/\n\tbar\n\tEOF1\n$baz\nEOF2\n"
+
+	ast, err := parser.Parse(strings.NewReader(dockerfile))
+	require.NoError(t, err)
+
+	n, err := ParseInstruction(ast.AST.Children[0])
+	require.NoError(t, err)
+
+	c, ok := n.(*CopyCommand)
+	require.True(t, ok)
+	require.Equal(t, []string{"foo"}, c.Sources())
+	require.Equal(t, "/dest/", c.Dest())
+	require.Equal(t, []SourceContent{
+		{Path: "EOF1", Data: "bar\n", Expand: true},
+		{Path: "EOF2", Data: "$baz\n", Expand: false},
+	}, c.SourceContents)
+
+	require.NoError(t, c.ExpandRaw(func(word string) (string, error) {
+		return strings.ToUpper(word), nil
+	}))
+	require.Equal(t, "BAR\n", c.SourceContents[0].Data)
+	require.Equal(t, "$baz\n", c.SourceContents[1].Data)
+}
+
+func TestRunHeredoc(t *testing.T) {
+	dockerfile := "RUN <<-EOF\n\techo hello\n\tEOF\n"
+
+	ast, err := parser.Parse(strings.NewReader(dockerfile))
+	require.NoError(t, err)
+
+	n, err := ParseInstruction(ast.AST.Children[0])
+	require.NoError(t, err)
+
+	c, ok := n.(*RunCommand)
+	require.True(t, ok)
+	require.Equal(t, []string{"<<-EOF"}, []string(c.CmdLine))
+	require.Equal(t, []ShellInlineFile{
+		{Name: "EOF", Data: "\techo hello\n", Chomp: true},
+	}, c.Files)
+}
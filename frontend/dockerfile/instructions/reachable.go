@@ -0,0 +1,116 @@
+package instructions
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+)
+
+// BuiltinArgs are the platform args set by the builder. They can be used in
+// FROM without being declared and are not parameters of a build.
+var BuiltinArgs = []string{
+	"BUILDPLATFORM", "BUILDOS", "BUILDARCH", "BUILDVARIANT",
+	"TARGETPLATFORM", "TARGETOS", "TARGETARCH", "TARGETVARIANT",
+}
+
+// IsBuiltinArg returns true if name is one of BuiltinArgs
+func IsBuiltinArg(name string) bool {
+	for _, a := range BuiltinArgs {
+		if a == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ReachableStages returns the indexes of the stages needed to build the
+// target stage, in Dockerfile order. Like in the build, base names are
+// expanded with the meta args and refer to earlier stages, while the sources
+// of COPY --from and RUN --mount refer to any stage by name or index. ok is
+// false if a stage is referenced through a variable, as its dependencies
+// can't be known without the build args.
+func ReachableStages(stages []Stage, metaArgs []ArgCommand, target int, shlex *shell.Lex) (out []int, ok bool) {
+	args := map[string]string{}
+	for _, name := range BuiltinArgs {
+		args[name] = ""
+	}
+	for _, a := range metaArgs {
+		for _, kv := range a.Args {
+			args[kv.Key] = kv.ValueString()
+		}
+	}
+
+	byName := map[string]int{}
+	lookup := func(ref string, byIndex bool) (int, bool) {
+		if i, ok := byName[strings.ToLower(ref)]; ok {
+			return i, true
+		}
+		if !byIndex {
+			return 0, false
+		}
+		if i, err := strconv.Atoi(ref); err == nil && i >= 0 && i < len(stages) {
+			return i, true
+		}
+		return 0, false
+	}
+
+	deps := make([][]int, len(stages))
+	for i, st := range stages {
+		base, err := shlex.ProcessWordWithMap(st.BaseName, args)
+		if err != nil {
+			return nil, false
+		}
+		if dep, ok := lookup(base, false); ok {
+			deps[i] = append(deps[i], dep)
+		}
+		if st.Name != "" {
+			byName[strings.ToLower(st.Name)] = i
+		}
+	}
+	for i, st := range stages {
+		var refs []string
+		for _, cmd := range st.Commands {
+			switch c := cmd.(type) {
+			case *CopyCommand:
+				if c.From != "" {
+					refs = append(refs, c.From)
+				}
+			case *RunCommand:
+				for _, m := range GetMounts(c) {
+					if m.From != "" {
+						refs = append(refs, m.From)
+					}
+				}
+			}
+		}
+		for _, ref := range refs {
+			if strings.Contains(ref, "$") {
+				return nil, false
+			}
+			if dep, ok := lookup(ref, true); ok && dep != i {
+				deps[i] = append(deps[i], dep)
+			}
+		}
+	}
+
+	reachable := make([]bool, len(stages))
+	var visit func(int)
+	visit = func(i int) {
+		if reachable[i] {
+			return
+		}
+		reachable[i] = true
+		for _, dep := range deps[i] {
+			visit(dep)
+		}
+	}
+	visit(target)
+
+	for i, r := range reachable {
+		if r {
+			out = append(out, i)
+		}
+	}
+	return out, true
+}
@@ -0,0 +1,64 @@
+package instructions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReachableStages(t *testing.T) {
+	t.Parallel()
+
+	reachable := func(dockerfile string, target int) ([]int, bool) {
+		res, err := parser.Parse(strings.NewReader(dockerfile))
+		require.NoError(t, err)
+		stages, metaArgs, err := Parse(res.AST)
+		require.NoError(t, err)
+		if target < 0 {
+			target = len(stages) - 1
+		}
+		return ReachableStages(stages, metaArgs, target, shell.NewLex(res.EscapeToken))
+	}
+
+	out, ok := reachable(`
+ARG BASE=base
+FROM alpine AS base
+FROM alpine AS unused
+FROM busybox AS files
+FROM ${BASE}-${TARGETARCH} AS platform
+FROM $BASE
+COPY --from=files /a /a
+COPY --from=0 /b /b
+`, -1)
+	require.True(t, ok)
+	require.Equal(t, []int{0, 2, 4}, out)
+
+	// base names only refer to earlier stages
+	out, ok = reachable(`
+FROM later
+FROM alpine AS later
+`, 0)
+	require.True(t, ok)
+	require.Equal(t, []int{0}, out)
+
+	// sources of copies refer to any stage
+	out, ok = reachable(`
+FROM alpine
+COPY --from=later /a /a
+FROM alpine AS later
+`, 0)
+	require.True(t, ok)
+	require.Equal(t, []int{0, 1}, out)
+
+	// stages referenced through variables can't be resolved
+	_, ok = reachable(`
+ARG SRC=build
+FROM alpine AS build
+FROM alpine
+COPY --from=$SRC /a /a
+`, -1)
+	require.False(t, ok)
+}
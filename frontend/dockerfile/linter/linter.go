@@ -0,0 +1,386 @@
+package linter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/command"
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"github.com/pkg/errors"
+)
+
+// Rule is a check run on a Dockerfile
+type Rule struct {
+	Name        string
+	Description string
+}
+
+var (
+	RuleConsistentInstructionCasing = Rule{
+		Name:        "ConsistentInstructionCasing",
+		Description: "All commands within the Dockerfile should use the same casing (either upper or lower)",
+	}
+	RuleFromAsCasing = Rule{
+		Name:        "FromAsCasing",
+		Description: "The 'as' keyword should match the case of the 'from' keyword",
+	}
+	RuleMaintainerDeprecated = Rule{
+		Name:        "MaintainerDeprecated",
+		Description: "The MAINTAINER instruction is deprecated, use a label instead to define an image author",
+	}
+	RuleUndefinedArgInFrom = Rule{
+		Name:        "UndefinedArgInFrom",
+		Description: "FROM command must use declared ARGs",
+	}
+	RuleUndefinedVar = Rule{
+		Name:        "UndefinedVar",
+		Description: "Variables should be defined before their use",
+	}
+	RuleFromPlatformFlagConstDisallowed = Rule{
+		Name:        "FromPlatformFlagConstDisallowed",
+		Description: "FROM --platform flag should not use a constant value",
+	}
+	RuleUnreachableStage = Rule{
+		Name:        "UnreachableStage",
+		Description: "Stages not used by the build target should be removed",
+	}
+	RuleJSONArgsRecommended = Rule{
+		Name:        "JSONArgsRecommended",
+		Description: "JSON arguments recommended for ENTRYPOINT/CMD to prevent unintended behavior related to OS signals",
+	}
+)
+
+// Rules lists all the rules checked by Lint
+var Rules = []Rule{
+	RuleConsistentInstructionCasing,
+	RuleFromAsCasing,
+	RuleMaintainerDeprecated,
+	RuleUndefinedArgInFrom,
+	RuleUndefinedVar,
+	RuleFromPlatformFlagConstDisallowed,
+	RuleUnreachableStage,
+	RuleJSONArgsRecommended,
+}
+
+// Warning is a rule violation found by Lint
+type Warning struct {
+	Rule     Rule
+	Detail   string
+	Location []parser.Range
+}
+
+// Config selects the rules to check. It is set with the check parser
+// directive, eg. "# check=skip=JSONArgsRecommended,MaintainerDeprecated;error=true".
+type Config struct {
+	SkipAll   bool
+	SkipRules map[string]struct{}
+	// Error makes the build fail if any of the checked rules is violated
+	Error bool
+}
+
+// ParseConfig parses the value of the check directive
+func ParseConfig(v string) (*Config, error) {
+	cfg := &Config{
+		SkipRules: map[string]struct{}{},
+	}
+	for _, part := range strings.Split(v, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Errorf("invalid check option %q", part)
+		}
+		key, value := strings.ToLower(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])
+		switch key {
+		case "skip":
+			for _, name := range strings.Split(value, ",") {
+				name = strings.TrimSpace(name)
+				if strings.EqualFold(name, "all") {
+					cfg.SkipAll = true
+					continue
+				}
+				if name != "" {
+					cfg.SkipRules[name] = struct{}{}
+				}
+			}
+		case "error":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid value for check option %s", key)
+			}
+			cfg.Error = b
+		default:
+			return nil, errors.Errorf("unknown check option %q", key)
+		}
+	}
+	return cfg, nil
+}
+
+func (cfg *Config) enabled(r Rule) bool {
+	if cfg == nil {
+		return true
+	}
+	if cfg.SkipAll {
+		return false
+	}
+	_, skip := cfg.SkipRules[r.Name]
+	return !skip
+}
+
+// Opt contains the options for Lint
+type Opt struct {
+	// Target is the name of the stage built, the last stage if empty
+	Target string
+	Config *Config
+}
+
+type linter struct {
+	opt      Opt
+	shlex    *shell.Lex
+	warnings []Warning
+}
+
+func (l *linter) warn(r Rule, location []parser.Range, format string, args ...interface{}) {
+	if !l.opt.Config.enabled(r) {
+		return
+	}
+	l.warnings = append(l.warnings, Warning{
+		Rule:     r,
+		Detail:   fmt.Sprintf(format, args...),
+		Location: location,
+	})
+}
+
+// Lint checks the parsed Dockerfile against the enabled rules and returns the
+// violations ordered by their location.
+func Lint(dockerfile *parser.Result, opt Opt) ([]Warning, error) {
+	l := &linter{
+		opt:   opt,
+		shlex: shell.NewLex(dockerfile.EscapeToken),
+	}
+
+	l.checkCasing(dockerfile.AST)
+
+	stages, metaArgs, err := instructions.Parse(dockerfile.AST)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) == 0 {
+		return nil, errors.New("dockerfile contains no stages to build")
+	}
+
+	fromArgs := map[string]string{}
+	for _, name := range instructions.BuiltinArgs {
+		fromArgs[name] = ""
+	}
+	for _, a := range metaArgs {
+		for _, kv := range a.Args {
+			fromArgs[kv.Key] = kv.ValueString()
+		}
+	}
+
+	l.checkStages(stages, fromArgs)
+	if err := l.checkReachable(stages, metaArgs); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(l.warnings, func(i, j int) bool {
+		return startLine(l.warnings[i].Location) < startLine(l.warnings[j].Location)
+	})
+	return l.warnings, nil
+}
+
+func startLine(location []parser.Range) int {
+	if len(location) == 0 {
+		return 0
+	}
+	return location[0].Start.Line
+}
+
+func (l *linter) checkCasing(ast *parser.Node) {
+	var upper, lower int
+	for _, n := range ast.Children {
+		kw := keyword(n)
+		if kw == strings.ToUpper(kw) {
+			upper++
+		} else if kw == strings.ToLower(kw) {
+			lower++
+		}
+	}
+	isUpper := upper >= lower
+
+	for _, n := range ast.Children {
+		kw := keyword(n)
+		if (isUpper && kw != strings.ToUpper(kw)) || (!isUpper && kw != strings.ToLower(kw)) {
+			casing := "uppercase"
+			if !isUpper {
+				casing = "lowercase"
+			}
+			l.warn(RuleConsistentInstructionCasing, n.Location(), "Command '%s' should match the case of the command majority (%s)", kw, casing)
+		}
+
+		if n.Value == command.From && n.Next != nil && n.Next.Next != nil {
+			as := n.Next.Next.Value
+			if strings.EqualFold(as, "as") && (kw == strings.ToUpper(kw)) != (as == strings.ToUpper(as)) {
+				l.warn(RuleFromAsCasing, n.Location(), "'%s' and '%s' keywords' casing do not match", as, kw)
+			}
+		}
+
+		if n.Value == command.Maintainer {
+			l.warn(RuleMaintainerDeprecated, n.Location(), "Maintainer instruction is deprecated in favor of using label")
+		}
+	}
+}
+
+// keyword returns the instruction keyword as written in the Dockerfile
+func keyword(n *parser.Node) string {
+	fields := strings.Fields(n.Original)
+	if len(fields) == 0 {
+		return n.Value
+	}
+	return fields[0]
+}
+
+func (l *linter) checkStages(stages []instructions.Stage, fromArgs map[string]string) {
+	// env of the stages, nil if it depends on an image config
+	stageEnv := map[string]map[string]string{}
+
+	for i, st := range stages {
+		for _, word := range []string{st.BaseName, st.Platform} {
+			_, unmatched, err := l.shlex.ProcessWordWithUnmatched(word, fromArgs)
+			if err != nil {
+				continue
+			}
+			for _, name := range sortedKeys(unmatched) {
+				l.warn(RuleUndefinedArgInFrom, st.Location, "FROM argument '%s' is not declared", name)
+			}
+		}
+
+		if st.Platform != "" && !strings.Contains(st.Platform, "$") {
+			l.warn(RuleFromPlatformFlagConstDisallowed, st.Location, "FROM --platform flag should not use constant value %q", st.Platform)
+		}
+
+		var env map[string]string
+		if st.BaseName == "scratch" {
+			env = map[string]string{"PATH": ""}
+		} else if base, ok := stageEnv[strings.ToLower(st.BaseName)]; ok && base != nil {
+			env = copyEnv(base)
+		}
+
+		for _, cmd := range st.Commands {
+			switch c := cmd.(type) {
+			case *instructions.CmdCommand:
+				if c.PrependShell {
+					l.warn(RuleJSONArgsRecommended, c.Location(), "JSON arguments recommended for CMD to prevent unintended behavior related to OS signals")
+				}
+			case *instructions.EntrypointCommand:
+				if c.PrependShell {
+					l.warn(RuleJSONArgsRecommended, c.Location(), "JSON arguments recommended for ENTRYPOINT to prevent unintended behavior related to OS signals")
+				}
+			}
+
+			ex, ok := cmd.(instructions.SupportsSingleWordExpansion)
+			if !ok {
+				continue
+			}
+			if env != nil {
+				unmatched := map[string]struct{}{}
+				err := ex.Expand(func(word string) (string, error) {
+					word, u, err := l.shlex.ProcessWordWithUnmatched(word, env)
+					for name := range u {
+						unmatched[name] = struct{}{}
+					}
+					return word, err
+				})
+				if err == nil {
+					for _, name := range sortedKeys(unmatched) {
+						l.warn(RuleUndefinedVar, cmd.Location(), "Usage of undefined variable '$%s'", name)
+					}
+				}
+			}
+
+			switch c := cmd.(type) {
+			case *instructions.ArgCommand:
+				for _, kv := range c.Args {
+					v := kv.ValueString()
+					if kv.Value == nil {
+						if mv, ok := fromArgs[kv.Key]; ok {
+							v = mv
+						}
+					}
+					if env != nil {
+						env[kv.Key] = v
+					}
+				}
+			case *instructions.EnvCommand:
+				for _, kv := range c.Env {
+					if env != nil {
+						env[kv.Key] = kv.Value
+					}
+				}
+			}
+		}
+
+		stageEnv[strconv.Itoa(i)] = env
+		if st.Name != "" {
+			stageEnv[strings.ToLower(st.Name)] = env
+		}
+	}
+}
+
+func (l *linter) checkReachable(stages []instructions.Stage, metaArgs []instructions.ArgCommand) error {
+	target := len(stages) - 1
+	if l.opt.Target != "" {
+		target = -1
+		for i, st := range stages {
+			if strings.EqualFold(st.Name, l.opt.Target) {
+				target = i
+			}
+		}
+		if target == -1 {
+			return errors.Errorf("failed to reach build target %s in Dockerfile", l.opt.Target)
+		}
+	}
+
+	reachable, ok := instructions.ReachableStages(stages, metaArgs, target, l.shlex)
+	if !ok {
+		// stages referenced through variables can't be resolved without the
+		// build args, skip the check
+		return nil
+	}
+	for i, st := range stages {
+		if len(reachable) > 0 && reachable[0] == i {
+			reachable = reachable[1:]
+			continue
+		}
+		name := st.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		l.warn(RuleUnreachableStage, st.Location, "Stage '%s' is not used by the build target", name)
+	}
+	return nil
+}
+
+func copyEnv(env map[string]string) map[string]string {
+	m := make(map[string]string, len(env))
+	for k, v := range env {
+		m[k] = v
+	}
+	return m
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
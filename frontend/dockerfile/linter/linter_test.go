@@ -0,0 +1,122 @@
+package linter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func lint(t *testing.T, dockerfile string, opt Opt) []Warning {
+	res, err := parser.Parse(strings.NewReader(dockerfile))
+	require.NoError(t, err)
+	warnings, err := Lint(res, opt)
+	require.NoError(t, err)
+	return warnings
+}
+
+func ruleNames(warnings []Warning) []string {
+	var names []string
+	for _, w := range warnings {
+		names = append(names, w.Rule.Name)
+	}
+	return names
+}
+
+func TestLint(t *testing.T) {
+	dockerfile := `ARG BASE=alpine
+FROM scratch as base
+MAINTAINER me@example.com
+ENV FOO=bar
+COPY $FOO $BAR /
+
+FROM --platform=linux/amd64 ${IMAGE} AS unused
+from base as build
+run echo $UNCHECKED
+CMD echo hello
+ENTRYPOINT ["echo"]
+`
+	warnings := lint(t, dockerfile, Opt{})
+	require.Equal(t, []string{
+		"FromAsCasing",
+		"MaintainerDeprecated",
+		"UndefinedVar",
+		"UndefinedArgInFrom",
+		"FromPlatformFlagConstDisallowed",
+		"UnreachableStage",
+		"ConsistentInstructionCasing",
+		"ConsistentInstructionCasing",
+		"JSONArgsRecommended",
+	}, ruleNames(warnings))
+
+	require.Equal(t, "Usage of undefined variable '$BAR'", warnings[2].Detail)
+	require.Equal(t, 5, warnings[2].Location[0].Start.Line)
+	require.Equal(t, "FROM argument 'IMAGE' is not declared", warnings[3].Detail)
+	require.Equal(t, "Stage 'unused' is not used by the build target", warnings[5].Detail)
+}
+
+func TestLintTarget(t *testing.T) {
+	dockerfile := `ARG BASE=a
+FROM scratch AS a
+FROM $BASE AS b
+FROM scratch AS c
+COPY --from=b / /
+FROM scratch AS d
+`
+	warnings := lint(t, dockerfile, Opt{})
+	require.Equal(t, []string{"UnreachableStage", "UnreachableStage", "UnreachableStage"}, ruleNames(warnings))
+
+	warnings = lint(t, dockerfile, Opt{Target: "c"})
+	require.Equal(t, []string{"UnreachableStage"}, ruleNames(warnings))
+	require.Equal(t, "Stage 'd' is not used by the build target", warnings[0].Detail)
+
+	res, err := parser.Parse(strings.NewReader(dockerfile))
+	require.NoError(t, err)
+	_, err = Lint(res, Opt{Target: "e"})
+	require.Error(t, err)
+}
+
+func TestLintUndefinedVarKnownEnv(t *testing.T) {
+	dockerfile := `FROM scratch AS base
+ARG VERSION
+ENV APP=/app
+WORKDIR $APP/$VERSION
+
+FROM base
+USER $APP_USER
+FROM alpine
+WORKDIR $FROM_IMAGE_CONFIG
+`
+	warnings := lint(t, dockerfile, Opt{Config: &Config{
+		SkipRules: map[string]struct{}{"UnreachableStage": {}},
+	}})
+	require.Equal(t, []string{"UndefinedVar"}, ruleNames(warnings))
+	require.Equal(t, "Usage of undefined variable '$APP_USER'", warnings[0].Detail)
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig("skip=JSONArgsRecommended, MaintainerDeprecated;error=true")
+	require.NoError(t, err)
+	require.True(t, cfg.Error)
+	require.False(t, cfg.SkipAll)
+	require.False(t, cfg.enabled(RuleJSONArgsRecommended))
+	require.False(t, cfg.enabled(RuleMaintainerDeprecated))
+	require.True(t, cfg.enabled(RuleUndefinedVar))
+
+	cfg, err = ParseConfig("skip=all")
+	require.NoError(t, err)
+	require.False(t, cfg.enabled(RuleUndefinedVar))
+
+	warnings := lint(t, "FROM scratch\nMAINTAINER me\nCMD echo\n", Opt{Config: &Config{
+		SkipRules: map[string]struct{}{"MaintainerDeprecated": {}},
+	}})
+	require.Equal(t, []string{"JSONArgsRecommended"}, ruleNames(warnings))
+
+	_, err = ParseConfig("skip")
+	require.Error(t, err)
+	_, err = ParseConfig("error=maybe")
+	require.Error(t, err)
+	_, err = ParseConfig("foo=bar")
+	require.Error(t, err)
+}
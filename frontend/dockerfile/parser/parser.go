@@ -12,6 +12,7 @@ import (
 	"unicode"
 
 	"github.com/moby/buildkit/frontend/dockerfile/command"
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
 	"github.com/pkg/errors"
 )
 
@@ -37,6 +38,16 @@ type Node struct {
 	StartLine   int             // the line in the original dockerfile where the node begins
 	EndLine     int             // the line in the original dockerfile where the node ends
 	PrevComment []string
+	Heredocs    []Heredoc // here-documents following the instruction
+}
+
+// Heredoc is a here-document read from the lines following an instruction
+type Heredoc struct {
+	Name           string
+	FileDescriptor uint
+	Expand         bool
+	Chomp          bool
+	Content        string
 }
 
 // Location return the location of node in source code
@@ -91,12 +102,22 @@ var (
 	reComment    = regexp.MustCompile(`^#.*$`)
 )
 
+var (
+	reHeredoc     = regexp.MustCompile(`^(\d*)<<(-?)([^<]*)$`)
+	reLeadingTabs = regexp.MustCompile(`(?m)^\t+`)
+)
+
+// heredocDirectives lists the instructions that may be followed by
+// here-documents. It is empty unless the dfheredoc build tag is set.
+var heredocDirectives = map[string]bool{}
+
 // DefaultEscapeToken is the default escape token
 const DefaultEscapeToken = '\\'
 
 var validDirectives = map[string]struct{}{
 	"escape": {},
 	"syntax": {},
+	"check":  {},
 }
 
 // directive is the structure used during a build run to hold the state of
@@ -313,6 +334,38 @@ func Parse(rwc io.Reader) (*Result, error) {
 			return nil, withLocation(err, startLine, currentLine)
 		}
 		comments = nil
+
+		if child.canContainHeredoc() {
+			heredocs, err := heredocsFromLine(line)
+			if err != nil {
+				return nil, withLocation(err, startLine, currentLine)
+			}
+
+			for _, heredoc := range heredocs {
+				terminator := []byte(heredoc.Name)
+				terminated := false
+				for scanner.Scan() {
+					bytesRead := scanner.Bytes()
+					currentLine++
+
+					possibleTerminator := bytes.TrimRight(bytesRead, "\r")
+					if heredoc.Chomp {
+						possibleTerminator = bytes.TrimLeft(possibleTerminator, "\t")
+					}
+					if bytes.Equal(possibleTerminator, terminator) {
+						terminated = true
+						break
+					}
+					heredoc.Content += string(bytesRead) + "\n"
+				}
+				if !terminated {
+					return nil, withLocation(errors.Errorf("unterminated heredoc %s", heredoc.Name), startLine, currentLine)
+				}
+
+				child.Heredocs = append(child.Heredocs, heredoc)
+			}
+		}
+
 		root.AddChild(child, startLine, currentLine)
 	}
 
@@ -331,6 +384,93 @@ func Parse(rwc io.Reader) (*Result, error) {
 	}, withLocation(handleScannerError(scanner.Err()), currentLine, 0)
 }
 
+func (node *Node) canContainHeredoc() bool {
+	if !heredocDirectives[node.Value] {
+		return false
+	}
+	if node.Attributes["json"] {
+		return false
+	}
+	return true
+}
+
+// ParseHeredoc parses a here-document redirection word like "<<EOF" or
+// "<<-'EOF'". It returns nil if the word is not a here-document.
+func ParseHeredoc(src string) (*Heredoc, error) {
+	match := reHeredoc.FindStringSubmatch(src)
+	if len(match) == 0 {
+		return nil, nil
+	}
+
+	fd, _ := strconv.ParseUint(match[1], 10, 0)
+	chomp := match[2] == "-"
+	rest := match[3]
+	if len(rest) == 0 {
+		return nil, nil
+	}
+
+	shlex := shell.NewLex('\\')
+	shlex.SkipUnsetEnv = true
+
+	// Lex the name both with and without processing quotes. If any part of
+	// the name is quoted the results differ, and like in a shell the content
+	// of the document must not be expanded.
+	words, err := shlex.ProcessWords(rest, []string{})
+	if err != nil {
+		return nil, err
+	}
+	if len(words) != 1 {
+		return nil, nil
+	}
+
+	shlex.RawQuotes = true
+	wordsRaw, err := shlex.ProcessWords(rest, []string{})
+	if err != nil {
+		return nil, err
+	}
+	if len(wordsRaw) != 1 {
+		return nil, errors.Errorf("inconsistent heredoc name %s", rest)
+	}
+
+	return &Heredoc{
+		Name:           words[0],
+		FileDescriptor: uint(fd),
+		Expand:         words[0] == wordsRaw[0],
+		Chomp:          chomp,
+	}, nil
+}
+
+// MustParseHeredoc is like ParseHeredoc but returns nil for invalid input
+func MustParseHeredoc(src string) *Heredoc {
+	heredoc, _ := ParseHeredoc(src)
+	return heredoc
+}
+
+func heredocsFromLine(line string) ([]Heredoc, error) {
+	shlex := shell.NewLex('\\')
+	shlex.RawQuotes = true
+	shlex.SkipUnsetEnv = true
+	words, _ := shlex.ProcessWords(line, []string{})
+
+	var docs []Heredoc
+	for _, word := range words {
+		heredoc, err := ParseHeredoc(word)
+		if err != nil {
+			return nil, err
+		}
+		if heredoc != nil {
+			docs = append(docs, *heredoc)
+		}
+	}
+	return docs, nil
+}
+
+// ChompHeredocContent removes the leading tabs from the lines of a
+// here-document started with "<<-"
+func ChompHeredocContent(src string) string {
+	return reLeadingTabs.ReplaceAllString(src, "")
+}
+
 func trimComments(src []byte) []byte {
 	return reComment.ReplaceAll(src, []byte{})
 }
@@ -0,0 +1,67 @@
+// +build dfheredoc
+
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeredoc(t *testing.T) {
+	dockerfile := "FROM busybox\n" +
+		"RUN <<EOF\necho hello\n\techo world\nEOF\n" +
+		"COPY <<-'EOF1' <<EOF2 /dest/\n\tfoo $bar\n\tEOF1\nbaz\nEOF2\n" +
+		"RUN [\"echo\", \"<<EOF\"]\n" +
+		"CMD <<EOF\n"
+
+	result, err := Parse(bytes.NewBufferString(dockerfile))
+	require.NoError(t, err)
+
+	children := result.AST.Children
+	require.Equal(t, 5, len(children))
+
+	require.Equal(t, []Heredoc{
+		{Name: "EOF", Expand: true, Content: "echo hello\n\techo world\n"},
+	}, children[1].Heredocs)
+	require.Equal(t, 2, children[1].StartLine)
+	require.Equal(t, 5, children[1].EndLine)
+
+	require.Equal(t, []Heredoc{
+		{Name: "EOF1", Expand: false, Chomp: true, Content: "\tfoo $bar\n"},
+		{Name: "EOF2", Expand: true, Content: "baz\n"},
+	}, children[2].Heredocs)
+	require.Equal(t, "foo $bar\n", ChompHeredocContent(children[2].Heredocs[0].Content))
+
+	require.Nil(t, children[3].Heredocs)
+	require.Nil(t, children[4].Heredocs)
+}
+
+func TestParseHeredocUnterminated(t *testing.T) {
+	dockerfile := "FROM busybox\nRUN <<EOF\necho hello\n"
+
+	_, err := Parse(bytes.NewBufferString(dockerfile))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unterminated heredoc")
+}
+
+func TestParseHeredocWord(t *testing.T) {
+	for _, tc := range []struct {
+		word     string
+		expected *Heredoc
+	}{
+		{"<<EOF", &Heredoc{Name: "EOF", Expand: true}},
+		{"<<-EOF", &Heredoc{Name: "EOF", Expand: true, Chomp: true}},
+		{"2<<EOF", &Heredoc{Name: "EOF", Expand: true, FileDescriptor: 2}},
+		{`<<"EOF"`, &Heredoc{Name: "EOF"}},
+		{"<<E'O'F", &Heredoc{Name: "EOF"}},
+		{"<<", nil},
+		{"<<<EOF", nil},
+		{"EOF", nil},
+	} {
+		heredoc, err := ParseHeredoc(tc.word)
+		require.NoError(t, err, tc.word)
+		require.Equal(t, tc.expected, heredoc, tc.word)
+	}
+}
@@ -3,9 +3,11 @@ package shell
 import (
 	"bytes"
 	"fmt"
+	"regexp"
 	"strings"
 	"text/scanner"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 )
@@ -21,6 +23,9 @@ type Lex struct {
 	escapeToken  rune
 	RawQuotes    bool
 	SkipUnsetEnv bool
+	// SkipProcessQuotes treats quotes as regular characters and only allows
+	// escaping $ and the escape token, like in the body of a here-document.
+	SkipProcessQuotes bool
 }
 
 // NewLex creates a new Lex which uses escapeToken to escape quotes.
@@ -59,23 +64,39 @@ func (s *Lex) ProcessWordsWithMap(word string, env map[string]string) ([]string,
 	return words, err
 }
 
+// ProcessWordWithUnmatched is like ProcessWordWithMap but also returns the
+// names of the variables referenced as $xxx or ${xxx} that are not in 'env'.
+func (s *Lex) ProcessWordWithUnmatched(word string, env map[string]string) (string, map[string]struct{}, error) {
+	sw := s.newShellWord(word, env)
+	sw.unmatched = map[string]struct{}{}
+	word, _, err := sw.process(word)
+	return word, sw.unmatched, err
+}
+
 func (s *Lex) process(word string, env map[string]string) (string, []string, error) {
+	return s.newShellWord(word, env).process(word)
+}
+
+func (s *Lex) newShellWord(word string, env map[string]string) *shellWord {
 	sw := &shellWord{
-		envs:         env,
-		escapeToken:  s.escapeToken,
-		skipUnsetEnv: s.SkipUnsetEnv,
-		rawQuotes:    s.RawQuotes,
+		envs:              env,
+		escapeToken:       s.escapeToken,
+		skipUnsetEnv:      s.SkipUnsetEnv,
+		rawQuotes:         s.RawQuotes,
+		skipProcessQuotes: s.SkipProcessQuotes,
 	}
 	sw.scanner.Init(strings.NewReader(word))
-	return sw.process(word)
+	return sw
 }
 
 type shellWord struct {
-	scanner      scanner.Scanner
-	envs         map[string]string
-	escapeToken  rune
-	rawQuotes    bool
-	skipUnsetEnv bool
+	scanner           scanner.Scanner
+	envs              map[string]string
+	escapeToken       rune
+	rawQuotes         bool
+	skipUnsetEnv      bool
+	skipProcessQuotes bool
+	unmatched         map[string]struct{}
 }
 
 func (sw *shellWord) process(source string) (string, []string, error) {
@@ -134,27 +155,36 @@ func (w *wordsStruct) getWords() []string {
 // Process the word, starting at 'pos', and stop when we get to the
 // end of the word or the 'stopChar' character
 func (sw *shellWord) processStopOn(stopChar rune) (string, []string, error) {
+	result, words, _, err := sw.processStopOnAny(stopChar)
+	return result, words, err
+}
+
+// processStopOnAny is like processStopOn but stops on any of stopChars and
+// returns the one it stopped on
+func (sw *shellWord) processStopOnAny(stopChars ...rune) (string, []string, rune, error) {
 	var result bytes.Buffer
 	var words wordsStruct
 
 	var charFuncMapping = map[rune]func() (string, error){
-		'\'': sw.processSingleQuote,
-		'"':  sw.processDoubleQuote,
-		'$':  sw.processDollar,
+		'$': sw.processDollar,
+	}
+	if !sw.skipProcessQuotes {
+		charFuncMapping['\''] = sw.processSingleQuote
+		charFuncMapping['"'] = sw.processDoubleQuote
 	}
 
 	for sw.scanner.Peek() != scanner.EOF {
 		ch := sw.scanner.Peek()
 
-		if stopChar != scanner.EOF && ch == stopChar {
+		if ch != scanner.EOF && containsRune(stopChars, ch) {
 			sw.scanner.Next()
-			return result.String(), words.getWords(), nil
+			return result.String(), words.getWords(), ch, nil
 		}
 		if fn, ok := charFuncMapping[ch]; ok {
 			// Call special processing func for certain chars
 			tmp, err := fn()
 			if err != nil {
-				return "", []string{}, err
+				return "", []string{}, scanner.EOF, err
 			}
 			result.WriteString(tmp)
 
@@ -167,7 +197,14 @@ func (sw *shellWord) processStopOn(stopChar rune) (string, []string, error) {
 			// Not special, just add it to the result
 			ch = sw.scanner.Next()
 
-			if ch == sw.escapeToken {
+			if ch == sw.escapeToken && sw.skipProcessQuotes {
+				// only $ and the escape token itself can be escaped, all
+				// other escape tokens are left as-is
+				if next := sw.scanner.Peek(); next == '$' || next == sw.escapeToken {
+					ch = sw.scanner.Next()
+				}
+				words.addRawChar(ch)
+			} else if ch == sw.escapeToken {
 				// '\' (default escape token, but ` allowed) escapes, except end of line
 				ch = sw.scanner.Next()
 
@@ -183,10 +220,19 @@ func (sw *shellWord) processStopOn(stopChar rune) (string, []string, error) {
 			result.WriteRune(ch)
 		}
 	}
-	if stopChar != scanner.EOF {
-		return "", []string{}, errors.Errorf("unexpected end of statement while looking for matching %s", string(stopChar))
+	if len(stopChars) > 0 && stopChars[0] != scanner.EOF {
+		return "", []string{}, scanner.EOF, errors.Errorf("unexpected end of statement while looking for matching %s", string(stopChars[len(stopChars)-1]))
 	}
-	return result.String(), words.getWords(), nil
+	return result.String(), words.getWords(), scanner.EOF, nil
+}
+
+func containsRune(runes []rune, r rune) bool {
+	for _, c := range runes {
+		if c == r {
+			return true
+		}
+	}
+	return false
 }
 
 func (sw *shellWord) processSingleQuote() (string, error) {
@@ -287,8 +333,11 @@ func (sw *shellWord) processDollar() (string, error) {
 			return "$", nil
 		}
 		value, found := sw.getEnv(name)
-		if !found && sw.skipUnsetEnv {
-			return "$" + name, nil
+		if !found {
+			sw.addUnmatched(name)
+			if sw.skipUnsetEnv {
+				return "$" + name, nil
+			}
 		}
 		return value, nil
 	}
@@ -307,8 +356,11 @@ func (sw *shellWord) processDollar() (string, error) {
 	case '}':
 		// Normal ${xx} case
 		value, found := sw.getEnv(name)
-		if !found && sw.skipUnsetEnv {
-			return fmt.Sprintf("${%s}", name), nil
+		if !found {
+			sw.addUnmatched(name)
+			if sw.skipUnsetEnv {
+				return fmt.Sprintf("${%s}", name), nil
+			}
 		}
 		return value, nil
 	case '?':
@@ -331,6 +383,74 @@ func (sw *shellWord) processDollar() (string, error) {
 			return "", errors.Errorf("%s: %s", name, message)
 		}
 		return newValue, nil
+	case '#', '%':
+		// ${xx#pattern} and ${xx%pattern} remove the shortest prefix or
+		// suffix matching pattern, ${xx##pattern} and ${xx%%pattern} the
+		// longest one
+		modifier := string(ch)
+		longest := false
+		if sw.scanner.Peek() == ch {
+			sw.scanner.Next()
+			modifier += string(ch)
+			longest = true
+		}
+		pattern, _, err := sw.processStopOn('}')
+		if err != nil {
+			if sw.scanner.Peek() == scanner.EOF {
+				return "", errors.New("syntax error: missing '}'")
+			}
+			return "", err
+		}
+		value, found := sw.getEnv(name)
+		if !found {
+			sw.addUnmatched(name)
+			if sw.skipUnsetEnv {
+				return fmt.Sprintf("${%s%s%s}", name, modifier, pattern), nil
+			}
+		}
+		if ch == '#' {
+			return trimPrefix(value, pattern, longest)
+		}
+		return trimSuffix(value, pattern, longest)
+	case '/':
+		// ${xx/pattern/replacement} replaces the longest match of pattern,
+		// ${xx//pattern/replacement} replaces all of them. Without the
+		// replacement, ${xx/pattern} deletes the match.
+		modifier := "/"
+		all := false
+		if sw.scanner.Peek() == '/' {
+			sw.scanner.Next()
+			modifier = "//"
+			all = true
+		}
+		pattern, _, stop, err := sw.processStopOnAny('/', '}')
+		if err != nil {
+			if sw.scanner.Peek() == scanner.EOF {
+				return "", errors.New("syntax error: missing '}'")
+			}
+			return "", err
+		}
+		var replacement string
+		if stop == '/' {
+			replacement, _, err = sw.processStopOn('}')
+			if err != nil {
+				if sw.scanner.Peek() == scanner.EOF {
+					return "", errors.New("syntax error: missing '}'")
+				}
+				return "", err
+			}
+		}
+		value, found := sw.getEnv(name)
+		if !found {
+			sw.addUnmatched(name)
+			if sw.skipUnsetEnv {
+				if stop == '}' {
+					return fmt.Sprintf("${%s%s%s}", name, modifier, pattern), nil
+				}
+				return fmt.Sprintf("${%s%s%s/%s}", name, modifier, pattern, replacement), nil
+			}
+		}
+		return replacePattern(value, pattern, replacement, all)
 	case ':':
 		// Special ${xx:...} format processing
 		// Yes it allows for recursive $'s in the ... spot
@@ -445,6 +565,15 @@ func (sw *shellWord) getEnv(name string) (string, bool) {
 	return "", false
 }
 
+// addUnmatched records a variable that expanded to an empty value because it
+// is not set. Special parameters like $1 are not variables.
+func (sw *shellWord) addUnmatched(name string) {
+	if sw.unmatched == nil || len(name) == 0 || unicode.IsDigit(rune(name[0])) || (len(name) == 1 && isSpecialParam(rune(name[0]))) {
+		return
+	}
+	sw.unmatched[name] = struct{}{}
+}
+
 func BuildEnvs(env []string) map[string]string {
 	envs := map[string]string{}
 
@@ -464,3 +593,129 @@ func BuildEnvs(env []string) map[string]string {
 
 	return envs
 }
+
+// convertShellPatternToRegex converts a shell pattern (*, ? and [...]) to a
+// regular expression anchored at the start of the string, and at the end too
+// if matchEnd is set
+func convertShellPatternToRegex(pattern string, matchEnd bool) (*regexp.Regexp, error) {
+	var re strings.Builder
+	re.WriteString("(?s)^")
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch ch := runes[i]; ch {
+		case '*':
+			re.WriteString(".*")
+		case '?':
+			re.WriteString(".")
+		case '[':
+			end := i + 1
+			if end < len(runes) && (runes[end] == '!' || runes[end] == '^') {
+				end++
+			}
+			if end < len(runes) && runes[end] == ']' {
+				end++
+			}
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end >= len(runes) {
+				// no closing bracket, match [ literally
+				re.WriteString(regexp.QuoteMeta(string(ch)))
+				continue
+			}
+			re.WriteString("[")
+			j := i + 1
+			if runes[j] == '!' || runes[j] == '^' {
+				re.WriteString("^")
+				j++
+			}
+			for ; j < end; j++ {
+				if runes[j] == '-' {
+					re.WriteRune('-')
+				} else {
+					re.WriteString(regexp.QuoteMeta(string(runes[j])))
+				}
+			}
+			re.WriteString("]")
+			i = end
+		default:
+			re.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	if matchEnd {
+		re.WriteString("$")
+	}
+	return regexp.Compile(re.String())
+}
+
+// runeBoundaries returns the byte offsets of the runes in s, including len(s)
+func runeBoundaries(s string) []int {
+	bounds := make([]int, 0, len(s)+1)
+	for i := range s {
+		bounds = append(bounds, i)
+	}
+	return append(bounds, len(s))
+}
+
+func trimPrefix(value, pattern string, longest bool) (string, error) {
+	re, err := convertShellPatternToRegex(pattern, true)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid pattern %q", pattern)
+	}
+	bounds := runeBoundaries(value)
+	for k := range bounds {
+		i := bounds[k]
+		if longest {
+			i = bounds[len(bounds)-1-k]
+		}
+		if re.MatchString(value[:i]) {
+			return value[i:], nil
+		}
+	}
+	return value, nil
+}
+
+func trimSuffix(value, pattern string, longest bool) (string, error) {
+	re, err := convertShellPatternToRegex(pattern, true)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid pattern %q", pattern)
+	}
+	bounds := runeBoundaries(value)
+	for k := range bounds {
+		i := bounds[len(bounds)-1-k]
+		if longest {
+			i = bounds[k]
+		}
+		if re.MatchString(value[i:]) {
+			return value[:i], nil
+		}
+	}
+	return value, nil
+}
+
+func replacePattern(value, pattern, replacement string, all bool) (string, error) {
+	re, err := convertShellPatternToRegex(pattern, false)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid pattern %q", pattern)
+	}
+	re.Longest()
+	var result strings.Builder
+	pos := 0
+	for pos < len(value) {
+		// longest non-empty match starting at this position
+		loc := re.FindStringIndex(value[pos:])
+		if loc == nil || loc[1] == 0 {
+			_, size := utf8.DecodeRuneInString(value[pos:])
+			result.WriteString(value[pos : pos+size])
+			pos += size
+			continue
+		}
+		result.WriteString(replacement)
+		pos += loc[1]
+		if !all {
+			break
+		}
+	}
+	result.WriteString(value[pos:])
+	return result.String(), nil
+}
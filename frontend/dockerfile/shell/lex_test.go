@@ -184,6 +184,48 @@ func TestShellParser4Words(t *testing.T) {
 	}
 }
 
+func TestShellParserSkipProcessQuotes(t *testing.T) {
+	shlex := NewLex('\\')
+	shlex.SkipProcessQuotes = true
+	envs := []string{"FOO=bar"}
+
+	word, err := shlex.ProcessWord("it's \"$FOO\" '${FOO}'\n", envs)
+	require.NoError(t, err)
+	require.Equal(t, "it's \"bar\" 'bar'\n", word)
+
+	word, err = shlex.ProcessWord(`printf "a\n" \$FOO \\`, envs)
+	require.NoError(t, err)
+	require.Equal(t, `printf "a\n" $FOO \`, word)
+}
+
+func TestShellParserUnmatched(t *testing.T) {
+	shlex := NewLex('\\')
+	envs := map[string]string{"FOO": "bar"}
+
+	word, unmatched, err := shlex.ProcessWordWithUnmatched(`$FOO ${BAR} ${BAZ:-def} $QUX-$1 \$ESC '$SINGLE'`, envs)
+	require.NoError(t, err)
+	require.Equal(t, "bar  def - $ESC $SINGLE", word)
+	require.Equal(t, map[string]struct{}{"BAR": {}, "QUX": {}}, unmatched)
+}
+
+func TestShellParserReplacePattern(t *testing.T) {
+	shlex := NewLex('\\')
+	envs := []string{"KOREAN=한국어한국어", "LONG=" + strings.Repeat("ab", 50000)}
+
+	word, err := shlex.ProcessWord("${KOREAN//국/x} ${KOREAN/어*} ${KOREAN//한}", envs)
+	require.NoError(t, err)
+	require.Equal(t, "한x어한x어 한국 국어국어", word)
+
+	word, err = shlex.ProcessWord("${LONG//a}", envs)
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("b", 50000), word)
+
+	shlex.SkipUnsetEnv = true
+	word, err = shlex.ProcessWord("${UNSET/a} ${UNSET//a/b}", envs)
+	require.NoError(t, err)
+	require.Equal(t, "${UNSET/a} ${UNSET//a/b}", word)
+}
+
 func TestGetEnv(t *testing.T) {
 	sw := &shellWord{envs: nil}
 
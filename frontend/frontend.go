@@ -17,8 +17,11 @@ type Frontend interface {
 type FrontendLLBBridge interface {
 	Solve(ctx context.Context, req SolveRequest, sid string) (*Result, error)
 	ResolveImageConfig(ctx context.Context, ref string, opt llb.ResolveImageConfigOpt) (digest.Digest, []byte, error)
+	Warn(ctx context.Context, dgst digest.Digest, msg string, opts WarnOpts) error
 }
 
 type SolveRequest = gw.SolveRequest
 
 type CacheOptionsEntry = gw.CacheOptionsEntry
+
+type WarnOpts = gw.WarnOpts
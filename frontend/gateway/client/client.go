@@ -18,6 +18,28 @@ type Client interface {
 	BuildOpts() BuildOpts
 	Inputs(ctx context.Context) (map[string]llb.State, error)
 	NewContainer(ctx context.Context, req NewContainerRequest) (Container, error)
+	Warn(ctx context.Context, dgst digest.Digest, msg string, opts WarnOpts) error
+}
+
+// WarnLevel is the severity of a warning, higher is more severe.
+type WarnLevel int
+
+const (
+	WarnLevelInfo WarnLevel = iota
+	WarnLevelWarning
+	WarnLevelError
+)
+
+// WarnOpts describes a warning reported for a vertex with Warn.
+type WarnOpts struct {
+	Level WarnLevel
+	// SourceInfo and Range point to the source that caused the warning
+	SourceInfo *pb.SourceInfo
+	Range      []*pb.Range
+	// Detail are the lines of a longer description of the warning
+	Detail [][]byte
+	// URL points to documentation about the warning
+	URL string
 }
 
 // NewContainerRequest encapsulates the requirements for a client to define a
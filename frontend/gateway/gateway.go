@@ -483,6 +483,21 @@ func (lbf *llbBridgeForwarder) ResolveImageConfig(ctx context.Context, req *pb.R
 	}, nil
 }
 
+func (lbf *llbBridgeForwarder) Warn(ctx context.Context, in *pb.WarnRequest) (*pb.WarnResponse, error) {
+	ctx = tracing.ContextWithSpanFromContext(ctx, lbf.callCtx)
+	err := lbf.llbBridge.Warn(ctx, in.Digest, string(in.Short), frontend.WarnOpts{
+		Level:      gwclient.WarnLevel(in.Level),
+		SourceInfo: in.Info,
+		Range:      in.Ranges,
+		Detail:     in.Detail,
+		URL:        in.Url,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.WarnResponse{}, nil
+}
+
 func translateLegacySolveRequest(req *pb.SolveRequest) error {
 	// translates ImportCacheRefs to new CacheImports (v0.4.0)
 	for _, legacyImportRef := range req.ImportCacheRefsDeprecated {
@@ -446,6 +446,22 @@ func (c *grpcClient) ResolveImageConfig(ctx context.Context, ref string, opt llb
 	return resp.Digest, resp.Config, nil
 }
 
+func (c *grpcClient) Warn(ctx context.Context, dgst digest.Digest, msg string, opts client.WarnOpts) error {
+	if err := c.caps.Supports(pb.CapGatewayWarnings); err != nil {
+		return err
+	}
+	_, err := c.client.Warn(ctx, &pb.WarnRequest{
+		Digest: dgst,
+		Level:  int64(opts.Level),
+		Short:  []byte(msg),
+		Detail: opts.Detail,
+		Url:    opts.URL,
+		Info:   opts.SourceInfo,
+		Ranges: opts.Range,
+	})
+	return err
+}
+
 func (c *grpcClient) BuildOpts() client.BuildOpts {
 	return client.BuildOpts{
 		Opts:      c.opts,
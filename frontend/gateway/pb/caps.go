@@ -47,6 +47,10 @@ const (
 	// results. This is generally used by the client to return and handle solve
 	// errors.
 	CapGatewayEvaluateSolve apicaps.CapID = "gateway.solve.evaluate"
+
+	// CapGatewayWarnings is the capability to report warnings for a vertex
+	// through the gateway
+	CapGatewayWarnings apicaps.CapID = "gateway.warnings"
 )
 
 func init() {
@@ -169,4 +173,11 @@ func init() {
 		Enabled: true,
 		Status:  apicaps.CapStatusExperimental,
 	})
+
+	Caps.Init(apicaps.Cap{
+		ID:      CapGatewayWarnings,
+		Name:    "logging warnings",
+		Enabled: true,
+		Status:  apicaps.CapStatusExperimental,
+	})
 }
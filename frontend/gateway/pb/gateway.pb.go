@@ -2013,6 +2013,133 @@ func (m *ResizeMessage) GetCols() uint32 {
 	return 0
 }
 
+type WarnRequest struct {
+	Digest               github_com_opencontainers_go_digest.Digest `protobuf:"bytes,1,opt,name=digest,proto3,customtype=github.com/opencontainers/go-digest.Digest" json:"digest"`
+	Level                int64                                      `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	Short                []byte                                     `protobuf:"bytes,3,opt,name=short,proto3" json:"short,omitempty"`
+	Detail               [][]byte                                   `protobuf:"bytes,4,rep,name=detail,proto3" json:"detail,omitempty"`
+	Url                  string                                     `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	Info                 *pb.SourceInfo                             `protobuf:"bytes,6,opt,name=info,proto3" json:"info,omitempty"`
+	Ranges               []*pb.Range                                `protobuf:"bytes,7,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                   `json:"-"`
+	XXX_unrecognized     []byte                                     `json:"-"`
+	XXX_sizecache        int32                                      `json:"-"`
+}
+
+func (m *WarnRequest) Reset()         { *m = WarnRequest{} }
+func (m *WarnRequest) String() string { return proto.CompactTextString(m) }
+func (*WarnRequest) ProtoMessage()    {}
+func (*WarnRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{33}
+}
+func (m *WarnRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WarnRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WarnRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WarnRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WarnRequest.Merge(m, src)
+}
+func (m *WarnRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WarnRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WarnRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WarnRequest proto.InternalMessageInfo
+
+func (m *WarnRequest) GetLevel() int64 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+func (m *WarnRequest) GetShort() []byte {
+	if m != nil {
+		return m.Short
+	}
+	return nil
+}
+
+func (m *WarnRequest) GetDetail() [][]byte {
+	if m != nil {
+		return m.Detail
+	}
+	return nil
+}
+
+func (m *WarnRequest) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *WarnRequest) GetInfo() *pb.SourceInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *WarnRequest) GetRanges() []*pb.Range {
+	if m != nil {
+		return m.Ranges
+	}
+	return nil
+}
+
+type WarnResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WarnResponse) Reset()         { *m = WarnResponse{} }
+func (m *WarnResponse) String() string { return proto.CompactTextString(m) }
+func (*WarnResponse) ProtoMessage()    {}
+func (*WarnResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{34}
+}
+func (m *WarnResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WarnResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WarnResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WarnResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WarnResponse.Merge(m, src)
+}
+func (m *WarnResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *WarnResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WarnResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WarnResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*Result)(nil), "moby.buildkit.v1.frontend.Result")
 	proto.RegisterMapType((map[string][]byte)(nil), "moby.buildkit.v1.frontend.Result.MetadataEntry")
@@ -2054,132 +2181,141 @@ func init() {
 	proto.RegisterType((*DoneMessage)(nil), "moby.buildkit.v1.frontend.DoneMessage")
 	proto.RegisterType((*FdMessage)(nil), "moby.buildkit.v1.frontend.FdMessage")
 	proto.RegisterType((*ResizeMessage)(nil), "moby.buildkit.v1.frontend.ResizeMessage")
+	proto.RegisterType((*WarnRequest)(nil), "moby.buildkit.v1.frontend.WarnRequest")
+	proto.RegisterType((*WarnResponse)(nil), "moby.buildkit.v1.frontend.WarnResponse")
 }
 
 func init() { proto.RegisterFile("gateway.proto", fileDescriptor_f1a937782ebbded5) }
 
 var fileDescriptor_f1a937782ebbded5 = []byte{
-	// 1909 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0x4f, 0x6f, 0x1b, 0xc7,
-	0x15, 0xd7, 0x8a, 0xa4, 0x48, 0x3e, 0xfe, 0x31, 0x33, 0x4e, 0x53, 0x7a, 0x11, 0x38, 0xcc, 0x22,
-	0x55, 0x69, 0x47, 0x59, 0xa6, 0x74, 0x02, 0xb9, 0x72, 0x90, 0xd4, 0x94, 0x28, 0x58, 0x8d, 0x24,
-	0xab, 0xe3, 0x14, 0x06, 0x82, 0x14, 0xe8, 0x8a, 0x3b, 0xa4, 0x17, 0xa6, 0x76, 0xb7, 0xb3, 0x43,
-	0xcb, 0x4c, 0x2e, 0xed, 0xad, 0xf7, 0x02, 0xbd, 0x16, 0xe8, 0x27, 0xe8, 0xa5, 0xd7, 0x9e, 0x73,
-	0xec, 0xb9, 0x07, 0xa3, 0x10, 0xfa, 0x11, 0x7a, 0x6f, 0xf1, 0x66, 0x67, 0xc8, 0x25, 0x45, 0x2d,
-	0x49, 0xe4, 0xc4, 0x99, 0xb7, 0xef, 0xf7, 0xe6, 0xfd, 0x9b, 0xf7, 0xde, 0x10, 0x2a, 0x03, 0x47,
-	0xb0, 0x4b, 0x67, 0x6c, 0x87, 0x3c, 0x10, 0x01, 0xb9, 0x73, 0x11, 0x9c, 0x8f, 0xed, 0xf3, 0x91,
-	0x37, 0x74, 0x5f, 0x7a, 0xc2, 0x7e, 0xf5, 0x33, 0xbb, 0xcf, 0x03, 0x5f, 0x30, 0xdf, 0x35, 0x3f,
-	0x1a, 0x78, 0xe2, 0xc5, 0xe8, 0xdc, 0xee, 0x05, 0x17, 0xad, 0x41, 0x30, 0x08, 0x5a, 0x12, 0x71,
-	0x3e, 0xea, 0xcb, 0x9d, 0xdc, 0xc8, 0x55, 0x2c, 0xc9, 0x6c, 0xcf, 0xb3, 0x0f, 0x82, 0x60, 0x30,
-	0x64, 0x4e, 0xe8, 0x45, 0x6a, 0xd9, 0xe2, 0x61, 0xaf, 0x15, 0x09, 0x47, 0x8c, 0x22, 0x85, 0xd9,
-	0x49, 0x60, 0x50, 0x91, 0x96, 0x56, 0xa4, 0x15, 0x05, 0xc3, 0x57, 0x8c, 0xb7, 0xc2, 0xf3, 0x56,
-	0x10, 0x6a, 0xee, 0xd6, 0x8d, 0xdc, 0x4e, 0xe8, 0xb5, 0xc4, 0x38, 0x64, 0x51, 0xeb, 0x32, 0xe0,
-	0x2f, 0x19, 0x57, 0x80, 0x07, 0x37, 0x02, 0x46, 0xc2, 0x1b, 0x22, 0xaa, 0xe7, 0x84, 0x11, 0x1e,
-	0x82, 0xbf, 0x0a, 0x94, 0x34, 0x5b, 0x04, 0xbe, 0x17, 0x09, 0xcf, 0x1b, 0x78, 0xad, 0x7e, 0x24,
-	0x31, 0xf1, 0x29, 0x68, 0x44, 0xcc, 0x6e, 0xfd, 0x31, 0x03, 0x5b, 0x94, 0x45, 0xa3, 0xa1, 0x20,
-	0xdb, 0x50, 0xe1, 0xac, 0x7f, 0xc0, 0x42, 0xce, 0x7a, 0x8e, 0x60, 0x6e, 0xdd, 0x68, 0x18, 0xcd,
-	0xe2, 0x93, 0x0d, 0x3a, 0x4b, 0x26, 0xbf, 0x86, 0x2a, 0x67, 0xfd, 0x28, 0xc1, 0xb8, 0xd9, 0x30,
-	0x9a, 0xa5, 0xf6, 0x87, 0xf6, 0x8d, 0xc1, 0xb0, 0x29, 0xeb, 0x9f, 0x38, 0xe1, 0x14, 0xf2, 0x64,
-	0x83, 0xce, 0x09, 0x21, 0x6d, 0xc8, 0x70, 0xd6, 0xaf, 0x67, 0xa4, 0xac, 0xbb, 0xe9, 0xb2, 0x9e,
-	0x6c, 0x50, 0x64, 0x26, 0xbb, 0x90, 0x45, 0x29, 0xf5, 0xac, 0x04, 0xbd, 0xbf, 0x54, 0x81, 0x27,
-	0x1b, 0x54, 0x02, 0xc8, 0x97, 0x50, 0xb8, 0x60, 0xc2, 0x71, 0x1d, 0xe1, 0xd4, 0xa1, 0x91, 0x69,
-	0x96, 0xda, 0xad, 0x54, 0x30, 0x3a, 0xc8, 0x3e, 0x51, 0x88, 0xae, 0x2f, 0xf8, 0x98, 0x4e, 0x04,
-	0x98, 0x8f, 0xa0, 0x32, 0xf3, 0x89, 0xd4, 0x20, 0xf3, 0x92, 0x8d, 0x63, 0xff, 0x51, 0x5c, 0x92,
-	0xb7, 0x21, 0xf7, 0xca, 0x19, 0x8e, 0x98, 0x74, 0x55, 0x99, 0xc6, 0x9b, 0xbd, 0xcd, 0x87, 0x46,
-	0xa7, 0x00, 0x5b, 0x5c, 0x8a, 0xb7, 0xfe, 0x6c, 0x40, 0x6d, 0xde, 0x4f, 0xe4, 0x48, 0x59, 0x68,
-	0x48, 0x25, 0x3f, 0x5d, 0xc3, 0xc5, 0x48, 0x88, 0x62, 0x55, 0xa5, 0x08, 0x73, 0x17, 0x8a, 0x13,
-	0xd2, 0x32, 0x15, 0x8b, 0x09, 0x15, 0xad, 0x5d, 0xc8, 0x50, 0xd6, 0x27, 0x55, 0xd8, 0xf4, 0x54,
-	0x52, 0xd0, 0x4d, 0xcf, 0x25, 0x0d, 0xc8, 0xb8, 0xac, 0xaf, 0x82, 0x5f, 0xb5, 0xc3, 0x73, 0xfb,
-	0x80, 0xf5, 0x3d, 0xdf, 0x13, 0x5e, 0xe0, 0x53, 0xfc, 0x64, 0xfd, 0xd5, 0xc0, 0xe4, 0x42, 0xb5,
-	0xc8, 0x17, 0x33, 0x76, 0x2c, 0x4f, 0x95, 0x6b, 0xda, 0x3f, 0x4f, 0xd7, 0xfe, 0x93, 0xa4, 0xf6,
-	0x4b, 0xf3, 0x27, 0x69, 0x9d, 0x80, 0x0a, 0x65, 0x62, 0xc4, 0x7d, 0xca, 0x7e, 0x37, 0x62, 0x91,
-	0x20, 0x3f, 0xd7, 0x11, 0x91, 0xf2, 0x97, 0xa5, 0x15, 0x32, 0x52, 0x05, 0x20, 0x4d, 0xc8, 0x31,
-	0xce, 0x03, 0xae, 0xb4, 0x20, 0x76, 0x5c, 0x39, 0x6c, 0x1e, 0xf6, 0xec, 0x67, 0xb2, 0x72, 0xd0,
-	0x98, 0xc1, 0xaa, 0x41, 0x55, 0x9f, 0x1a, 0x85, 0x81, 0x1f, 0x31, 0xeb, 0x16, 0x54, 0x8e, 0xfc,
-	0x70, 0x24, 0x22, 0xa5, 0x87, 0xf5, 0x0f, 0x03, 0xaa, 0x9a, 0x12, 0xf3, 0x90, 0x6f, 0xa0, 0x34,
-	0xf5, 0xb1, 0x76, 0xe6, 0x5e, 0x8a, 0x7e, 0xb3, 0xf8, 0x44, 0x80, 0x94, 0x6f, 0x93, 0xe2, 0xcc,
-	0x53, 0xa8, 0xcd, 0x33, 0x2c, 0xf0, 0xf4, 0x07, 0xb3, 0x9e, 0x9e, 0x0f, 0x7c, 0xc2, 0xb3, 0x7f,
-	0x32, 0xe0, 0x0e, 0x65, 0xb2, 0x14, 0x1e, 0x5d, 0x38, 0x03, 0xb6, 0x1f, 0xf8, 0x7d, 0x6f, 0xa0,
-	0xdd, 0x5c, 0x93, 0x59, 0xa5, 0x25, 0x63, 0x82, 0x35, 0xa1, 0x70, 0x36, 0x74, 0x44, 0x3f, 0xe0,
-	0x17, 0x4a, 0x78, 0x19, 0x85, 0x6b, 0x1a, 0x9d, 0x7c, 0x25, 0x0d, 0x28, 0x29, 0xc1, 0x27, 0x81,
-	0xcb, 0x64, 0xcd, 0x28, 0xd2, 0x24, 0x89, 0xd4, 0x21, 0x7f, 0x1c, 0x0c, 0x4e, 0x9d, 0x0b, 0x26,
-	0x8b, 0x43, 0x91, 0xea, 0xad, 0xf5, 0x7b, 0x03, 0xcc, 0x45, 0x5a, 0x29, 0x17, 0xff, 0x12, 0xb6,
-	0x0e, 0xbc, 0x01, 0x8b, 0xe2, 0xe8, 0x17, 0x3b, 0xed, 0xef, 0xdf, 0xbc, 0xb7, 0xf1, 0xaf, 0x37,
-	0xef, 0xdd, 0x4f, 0xd4, 0xd5, 0x20, 0x64, 0x7e, 0x2f, 0xf0, 0x85, 0xe3, 0xf9, 0x8c, 0x63, 0x7b,
-	0xf8, 0xc8, 0x95, 0x10, 0x3b, 0x46, 0x52, 0x25, 0x81, 0xbc, 0x03, 0x5b, 0xb1, 0x74, 0x75, 0xed,
-	0xd5, 0xce, 0xfa, 0x6f, 0x0e, 0xca, 0xcf, 0x50, 0x01, 0xed, 0x0b, 0x1b, 0x60, 0xea, 0x42, 0x95,
-	0x76, 0xf3, 0x8e, 0x4d, 0x70, 0x10, 0x13, 0x0a, 0x87, 0x2a, 0xc4, 0xea, 0xba, 0x4e, 0xf6, 0xe4,
-	0x6b, 0x28, 0xe9, 0xf5, 0xd3, 0x50, 0xd4, 0x33, 0x32, 0x47, 0x1e, 0xa6, 0xe4, 0x48, 0x52, 0x13,
-	0x3b, 0x01, 0x55, 0x19, 0x92, 0xa0, 0x90, 0xcf, 0xe0, 0xce, 0xd1, 0x45, 0x18, 0x70, 0xb1, 0xef,
-	0xf4, 0x5e, 0x30, 0x3a, 0xdb, 0x05, 0xb2, 0x8d, 0x4c, 0xb3, 0x48, 0x6f, 0x66, 0x20, 0x3b, 0xf0,
-	0x96, 0x33, 0x1c, 0x06, 0x97, 0xea, 0xd2, 0xc8, 0xf4, 0xaf, 0xe7, 0x1a, 0x46, 0xb3, 0x40, 0xaf,
-	0x7f, 0x20, 0x1f, 0xc3, 0xed, 0x04, 0xf1, 0x31, 0xe7, 0xce, 0x18, 0xf3, 0x65, 0x4b, 0xf2, 0x2f,
-	0xfa, 0x84, 0x15, 0xec, 0xd0, 0xf3, 0x9d, 0x61, 0x1d, 0x24, 0x4f, 0xbc, 0x21, 0x16, 0x94, 0xbb,
-	0xaf, 0x51, 0x25, 0xc6, 0x1f, 0x0b, 0xc1, 0xeb, 0x25, 0x19, 0x8a, 0x19, 0x1a, 0x39, 0x83, 0xb2,
-	0x54, 0x38, 0xd6, 0x3d, 0xaa, 0x97, 0xa5, 0xd3, 0x76, 0x52, 0x9c, 0x26, 0xd9, 0x9f, 0x86, 0x89,
-	0xab, 0x34, 0x23, 0x81, 0xf4, 0xa0, 0xaa, 0x1d, 0x17, 0xdf, 0xc1, 0x7a, 0x45, 0xca, 0x7c, 0xb4,
-	0x6e, 0x20, 0x62, 0x74, 0x7c, 0xc4, 0x9c, 0x48, 0x4c, 0x83, 0x2e, 0x5e, 0x37, 0x47, 0xb0, 0x7a,
-	0x55, 0xda, 0x3c, 0xd9, 0x9b, 0x9f, 0x43, 0x6d, 0x3e, 0x96, 0xeb, 0x14, 0x7d, 0xf3, 0x57, 0x70,
-	0x7b, 0x81, 0x0a, 0x3f, 0xa8, 0x1e, 0xfc, 0xcd, 0x80, 0xb7, 0xae, 0xf9, 0x8d, 0x10, 0xc8, 0x7e,
-	0x35, 0x0e, 0x99, 0x12, 0x29, 0xd7, 0xe4, 0x04, 0x72, 0x18, 0x97, 0xa8, 0xbe, 0x29, 0x9d, 0xb6,
-	0xbb, 0x4e, 0x20, 0x6c, 0x89, 0x8c, 0x1d, 0x16, 0x4b, 0x31, 0x1f, 0x02, 0x4c, 0x89, 0x6b, 0xb5,
-	0xbe, 0x6f, 0xa0, 0xa2, 0xa2, 0xa2, 0xca, 0x43, 0x2d, 0x9e, 0x52, 0x14, 0x18, 0x67, 0x90, 0x69,
-	0xbb, 0xc8, 0xac, 0xd9, 0x2e, 0xac, 0xef, 0xe0, 0x16, 0x65, 0x8e, 0x7b, 0xe8, 0x0d, 0xd9, 0xcd,
-	0x55, 0x11, 0xef, 0xba, 0x37, 0x64, 0x67, 0x8e, 0x78, 0x31, 0xb9, 0xeb, 0x6a, 0x4f, 0xf6, 0x20,
-	0x47, 0x1d, 0x7f, 0xc0, 0xd4, 0xd1, 0x1f, 0xa4, 0x1c, 0x2d, 0x0f, 0x41, 0x5e, 0x1a, 0x43, 0xac,
-	0x47, 0x50, 0x9c, 0xd0, 0xb0, 0x52, 0x3d, 0xed, 0xf7, 0x23, 0x16, 0x57, 0xbd, 0x0c, 0x55, 0x3b,
-	0xa4, 0x1f, 0x33, 0x7f, 0xa0, 0x8e, 0xce, 0x50, 0xb5, 0xb3, 0xb6, 0x71, 0x54, 0xd1, 0x9a, 0x2b,
-	0xd7, 0x10, 0xc8, 0x1e, 0xe0, 0x3c, 0x65, 0xc8, 0x0b, 0x26, 0xd7, 0x96, 0x8b, 0x6d, 0xce, 0x71,
-	0x0f, 0x3c, 0x7e, 0xb3, 0x81, 0x75, 0xc8, 0x1f, 0x78, 0x3c, 0x61, 0x9f, 0xde, 0x92, 0x6d, 0x6c,
-	0x80, 0xbd, 0xe1, 0xc8, 0x45, 0x6b, 0x05, 0xe3, 0xbe, 0xaa, 0xf4, 0x73, 0x54, 0xeb, 0x8b, 0xd8,
-	0x8f, 0xf2, 0x14, 0xa5, 0xcc, 0x0e, 0xe4, 0x99, 0x2f, 0xb8, 0xc7, 0x74, 0x97, 0x24, 0x76, 0x3c,
-	0x02, 0xdb, 0x72, 0x04, 0x96, 0xdd, 0x98, 0x6a, 0x16, 0x6b, 0x17, 0x6e, 0x21, 0x21, 0x3d, 0x10,
-	0x04, 0xb2, 0x09, 0x25, 0xe5, 0xda, 0xda, 0x83, 0xda, 0x14, 0xa8, 0x8e, 0xde, 0x86, 0x2c, 0x0e,
-	0xd8, 0xaa, 0x8c, 0x2f, 0x3a, 0x57, 0x7e, 0xb7, 0x2a, 0x50, 0x3a, 0xf3, 0x7c, 0xdd, 0x0f, 0xad,
-	0x2b, 0x03, 0xca, 0x67, 0x81, 0x3f, 0xed, 0x44, 0x67, 0x70, 0x4b, 0xdf, 0xc0, 0xc7, 0x67, 0x47,
-	0xfb, 0x4e, 0xa8, 0x4d, 0x69, 0x5c, 0x0f, 0xb3, 0x7a, 0x0b, 0xd8, 0x31, 0x63, 0x27, 0x8b, 0x4d,
-	0x8b, 0xce, 0xc3, 0xc9, 0x2f, 0x20, 0x7f, 0x7c, 0xdc, 0x91, 0x92, 0x36, 0xd7, 0x92, 0xa4, 0x61,
-	0xe4, 0x73, 0xc8, 0x3f, 0x97, 0x4f, 0x94, 0x48, 0x35, 0x96, 0x05, 0x29, 0x17, 0x1b, 0x1a, 0xb3,
-	0x51, 0xd6, 0x0b, 0xb8, 0x4b, 0x35, 0xc8, 0xfa, 0x8f, 0x01, 0xb7, 0x4f, 0xd9, 0xe5, 0xbe, 0x6e,
-	0x9e, 0xda, 0xdb, 0x0d, 0x28, 0x4d, 0x68, 0x47, 0x07, 0xca, 0xeb, 0x49, 0x12, 0x79, 0x1f, 0xb6,
-	0x4e, 0x82, 0x91, 0x2f, 0xb4, 0xea, 0x45, 0xac, 0x33, 0x92, 0x42, 0xd5, 0x07, 0xf2, 0x13, 0xc8,
-	0x9f, 0x32, 0x81, 0x4f, 0x28, 0x99, 0x27, 0xd5, 0x76, 0x09, 0x79, 0x4e, 0x99, 0xc0, 0x89, 0x80,
-	0xea, 0x6f, 0x38, 0x66, 0x84, 0x7a, 0xcc, 0xc8, 0x2e, 0x1a, 0x33, 0xf4, 0x57, 0xb2, 0x0b, 0xa5,
-	0x5e, 0xe0, 0x47, 0x82, 0x3b, 0x1e, 0x1e, 0x9c, 0x93, 0xcc, 0x3f, 0x42, 0xe6, 0xd8, 0x9e, 0xfd,
-	0xe9, 0x47, 0x9a, 0xe4, 0xb4, 0xde, 0x81, 0xb7, 0x67, 0xad, 0x54, 0x33, 0xde, 0x23, 0xf8, 0x31,
-	0x65, 0x43, 0xe6, 0x44, 0x6c, 0x7d, 0x0f, 0x58, 0x26, 0xd4, 0xaf, 0x83, 0x95, 0xe0, 0xbf, 0x67,
-	0xa0, 0xd4, 0x7d, 0xcd, 0x7a, 0x27, 0x2c, 0x8a, 0x9c, 0x01, 0x23, 0xef, 0x42, 0xf1, 0x8c, 0x07,
-	0x3d, 0x16, 0x45, 0x13, 0x59, 0x53, 0x02, 0xf9, 0x0c, 0xb2, 0x47, 0xbe, 0x27, 0x54, 0xc5, 0xde,
-	0x4e, 0x9d, 0x1f, 0x3d, 0xa1, 0x64, 0xe2, 0xdb, 0x09, 0xb7, 0x64, 0x0f, 0xb2, 0x98, 0xef, 0xab,
-	0xd4, 0x1c, 0x37, 0x81, 0x45, 0x0c, 0xe9, 0xc8, 0xd7, 0xa6, 0xf7, 0x2d, 0x53, 0x9e, 0x6f, 0xa6,
-	0x17, 0x4b, 0xef, 0x5b, 0x36, 0x95, 0xa0, 0x90, 0xa4, 0x0b, 0xf9, 0x67, 0xc2, 0xe1, 0x38, 0x72,
-	0xc4, 0x11, 0xb9, 0x97, 0xd6, 0x53, 0x63, 0xce, 0xa9, 0x14, 0x8d, 0x45, 0x27, 0x74, 0x5f, 0x7b,
-	0x42, 0x0e, 0x14, 0xe9, 0x4e, 0x40, 0xb6, 0x84, 0x21, 0xb8, 0x45, 0xf4, 0x41, 0xe0, 0xb3, 0x7a,
-	0x7e, 0x29, 0x1a, 0xd9, 0x12, 0x68, 0xdc, 0x76, 0xf2, 0x90, 0x93, 0x4d, 0xd5, 0xfa, 0x8b, 0x01,
-	0xa5, 0x84, 0x8f, 0x57, 0xb8, 0x07, 0xef, 0x42, 0x16, 0x1f, 0x9b, 0x2a, 0x76, 0x05, 0x79, 0x0b,
-	0x98, 0x70, 0xa8, 0xa4, 0x62, 0xd5, 0x3a, 0x74, 0xe3, 0xbb, 0x59, 0xa1, 0xb8, 0x44, 0xca, 0x57,
-	0x62, 0x2c, 0xdd, 0x5d, 0xa0, 0xb8, 0x24, 0x3b, 0x50, 0x78, 0xc6, 0x7a, 0x23, 0xee, 0x89, 0xb1,
-	0x74, 0x60, 0xb5, 0x5d, 0x43, 0x29, 0x9a, 0x26, 0x2f, 0xcb, 0x84, 0xc3, 0xfa, 0x12, 0x13, 0x6b,
-	0xaa, 0x20, 0x81, 0xec, 0x3e, 0x8e, 0xdc, 0xa8, 0x59, 0x85, 0xca, 0x35, 0xbe, 0x7a, 0xba, 0xcb,
-	0x5e, 0x3d, 0x5d, 0xfd, 0xea, 0x99, 0x0d, 0x08, 0x16, 0xc1, 0x84, 0x83, 0xac, 0xc7, 0x50, 0x9c,
-	0x24, 0x0d, 0x3e, 0x38, 0x0f, 0x5d, 0x75, 0xd2, 0xe6, 0xa1, 0x8b, 0xa6, 0x74, 0x9f, 0x1e, 0xca,
-	0x53, 0x0a, 0x14, 0x97, 0x93, 0x96, 0x93, 0x49, 0xb4, 0x9c, 0x5d, 0x7c, 0xcf, 0x25, 0x32, 0x07,
-	0x99, 0x68, 0x70, 0x19, 0x69, 0x95, 0x71, 0x1d, 0x9b, 0x31, 0x8c, 0xa4, 0x2c, 0x69, 0xc6, 0x30,
-	0x6a, 0xff, 0xaf, 0x00, 0xc5, 0xe3, 0xe3, 0x4e, 0x87, 0x7b, 0xee, 0x80, 0x91, 0x3f, 0x18, 0x40,
-	0xae, 0x3f, 0x13, 0xc8, 0x27, 0xe9, 0x09, 0xbb, 0xf8, 0xad, 0x63, 0x7e, 0xba, 0x26, 0x4a, 0x75,
-	0x80, 0xaf, 0x21, 0x27, 0xa7, 0x0f, 0xf2, 0xd3, 0x15, 0xa7, 0x46, 0xb3, 0xb9, 0x9c, 0x51, 0xc9,
-	0xee, 0x41, 0x41, 0x77, 0x70, 0x72, 0x3f, 0x55, 0xbd, 0x99, 0x01, 0xc5, 0xfc, 0x70, 0x25, 0x5e,
-	0x75, 0xc8, 0x6f, 0x21, 0xaf, 0x1a, 0x33, 0xb9, 0xb7, 0x04, 0x37, 0x1d, 0x11, 0xcc, 0xfb, 0xab,
-	0xb0, 0x4e, 0xcd, 0xd0, 0x0d, 0x38, 0xd5, 0x8c, 0xb9, 0xf6, 0x9e, 0x6a, 0xc6, 0xb5, 0x8e, 0xfe,
-	0x1c, 0xb2, 0xd8, 0xa9, 0x49, 0xda, 0x35, 0x4f, 0xb4, 0x72, 0x33, 0x2d, 0x5c, 0x33, 0x2d, 0xfe,
-	0x37, 0x58, 0x0e, 0xe5, 0x6b, 0x27, 0xbd, 0x10, 0x26, 0xfe, 0x9e, 0x30, 0xef, 0xad, 0xc0, 0x39,
-	0x15, 0xaf, 0x5e, 0x0a, 0xcd, 0x15, 0xfe, 0x23, 0x58, 0x2e, 0x7e, 0xee, 0xdf, 0x88, 0x00, 0xca,
-	0xc9, 0x2e, 0x47, 0xec, 0x14, 0xe8, 0x82, 0xa6, 0x6f, 0xb6, 0x56, 0xe6, 0x57, 0x07, 0x7e, 0x87,
-	0x53, 0xe7, 0x6c, 0x07, 0x24, 0xed, 0x54, 0x77, 0x2c, 0xec, 0xb5, 0xe6, 0x83, 0xb5, 0x30, 0xea,
-	0x70, 0x27, 0xee, 0xb0, 0xaa, 0x8b, 0x92, 0xf4, 0x86, 0x31, 0xe9, 0xc4, 0xe6, 0x8a, 0x7c, 0x4d,
-	0xe3, 0x63, 0xa3, 0x53, 0xfe, 0xfe, 0xea, 0xae, 0xf1, 0xcf, 0xab, 0xbb, 0xc6, 0xbf, 0xaf, 0xee,
-	0x1a, 0xe7, 0x5b, 0xf2, 0x1f, 0xda, 0x07, 0xff, 0x0f, 0x00, 0x00, 0xff, 0xff, 0xe7, 0x9d, 0x1a,
-	0x7c, 0xf3, 0x16, 0x00, 0x00,
+	// 2019 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xcd, 0x6f, 0x1b, 0xc7,
+	0x15, 0xd7, 0x8a, 0x14, 0x3f, 0x1e, 0x3f, 0xc4, 0x8c, 0xd3, 0x74, 0xbd, 0x08, 0x1c, 0x65, 0x91,
+	0x2a, 0xb4, 0xad, 0x2c, 0x53, 0x39, 0x81, 0x5c, 0x39, 0x48, 0x6a, 0x7d, 0xc1, 0x4a, 0x24, 0x59,
+	0x1d, 0xa5, 0x30, 0x10, 0xa4, 0x40, 0x57, 0xdc, 0x21, 0xbd, 0xf0, 0x6a, 0x77, 0x3b, 0x3b, 0xb4,
+	0xac, 0xe4, 0xd2, 0xde, 0x7a, 0x2f, 0xd0, 0x6b, 0x81, 0xfe, 0x05, 0xbd, 0xf4, 0xda, 0x73, 0x8e,
+	0x3d, 0x16, 0x3d, 0x04, 0x85, 0xd1, 0x3f, 0xa1, 0xe8, 0x39, 0x78, 0x33, 0xb3, 0xe4, 0x92, 0xa2,
+	0x96, 0x24, 0x72, 0xe2, 0xcc, 0xdb, 0xf7, 0x7b, 0xf3, 0xbe, 0xe6, 0xbd, 0x37, 0x84, 0x46, 0xdf,
+	0x15, 0xec, 0xd2, 0xbd, 0x72, 0x62, 0x1e, 0x89, 0x88, 0xdc, 0xbe, 0x88, 0xce, 0xaf, 0x9c, 0xf3,
+	0x81, 0x1f, 0x78, 0x2f, 0x7c, 0xe1, 0xbc, 0xfc, 0xb9, 0xd3, 0xe3, 0x51, 0x28, 0x58, 0xe8, 0x59,
+	0x1f, 0xf4, 0x7d, 0xf1, 0x7c, 0x70, 0xee, 0x74, 0xa3, 0x8b, 0x4e, 0x3f, 0xea, 0x47, 0x1d, 0x89,
+	0x38, 0x1f, 0xf4, 0xe4, 0x4e, 0x6e, 0xe4, 0x4a, 0x49, 0xb2, 0x36, 0x27, 0xd9, 0xfb, 0x51, 0xd4,
+	0x0f, 0x98, 0x1b, 0xfb, 0x89, 0x5e, 0x76, 0x78, 0xdc, 0xed, 0x24, 0xc2, 0x15, 0x83, 0x44, 0x63,
+	0x36, 0x32, 0x18, 0x54, 0xa4, 0x93, 0x2a, 0xd2, 0x49, 0xa2, 0xe0, 0x25, 0xe3, 0x9d, 0xf8, 0xbc,
+	0x13, 0xc5, 0x29, 0x77, 0xe7, 0x46, 0x6e, 0x37, 0xf6, 0x3b, 0xe2, 0x2a, 0x66, 0x49, 0xe7, 0x32,
+	0xe2, 0x2f, 0x18, 0xd7, 0x80, 0x07, 0x37, 0x02, 0x06, 0xc2, 0x0f, 0x10, 0xd5, 0x75, 0xe3, 0x04,
+	0x0f, 0xc1, 0x5f, 0x0d, 0xca, 0x9a, 0x2d, 0xa2, 0xd0, 0x4f, 0x84, 0xef, 0xf7, 0xfd, 0x4e, 0x2f,
+	0x91, 0x18, 0x75, 0x0a, 0x1a, 0xa1, 0xd8, 0xed, 0x3f, 0x16, 0xa0, 0x44, 0x59, 0x32, 0x08, 0x04,
+	0x59, 0x87, 0x06, 0x67, 0xbd, 0x3d, 0x16, 0x73, 0xd6, 0x75, 0x05, 0xf3, 0x4c, 0x63, 0xcd, 0x68,
+	0x57, 0x9f, 0x2c, 0xd1, 0x71, 0x32, 0xf9, 0x35, 0x34, 0x39, 0xeb, 0x25, 0x19, 0xc6, 0xe5, 0x35,
+	0xa3, 0x5d, 0xdb, 0xbc, 0xef, 0xdc, 0x18, 0x0c, 0x87, 0xb2, 0xde, 0xb1, 0x1b, 0x8f, 0x20, 0x4f,
+	0x96, 0xe8, 0x84, 0x10, 0xb2, 0x09, 0x05, 0xce, 0x7a, 0x66, 0x41, 0xca, 0xba, 0x93, 0x2f, 0xeb,
+	0xc9, 0x12, 0x45, 0x66, 0xb2, 0x05, 0x45, 0x94, 0x62, 0x16, 0x25, 0xe8, 0xdd, 0x99, 0x0a, 0x3c,
+	0x59, 0xa2, 0x12, 0x40, 0xbe, 0x80, 0xca, 0x05, 0x13, 0xae, 0xe7, 0x0a, 0xd7, 0x84, 0xb5, 0x42,
+	0xbb, 0xb6, 0xd9, 0xc9, 0x05, 0xa3, 0x83, 0x9c, 0x63, 0x8d, 0xd8, 0x0f, 0x05, 0xbf, 0xa2, 0x43,
+	0x01, 0xd6, 0x23, 0x68, 0x8c, 0x7d, 0x22, 0x2d, 0x28, 0xbc, 0x60, 0x57, 0xca, 0x7f, 0x14, 0x97,
+	0xe4, 0x4d, 0x58, 0x79, 0xe9, 0x06, 0x03, 0x26, 0x5d, 0x55, 0xa7, 0x6a, 0xb3, 0xbd, 0xfc, 0xd0,
+	0xd8, 0xa9, 0x40, 0x89, 0x4b, 0xf1, 0xf6, 0x9f, 0x0d, 0x68, 0x4d, 0xfa, 0x89, 0x1c, 0x6a, 0x0b,
+	0x0d, 0xa9, 0xe4, 0xc7, 0x0b, 0xb8, 0x18, 0x09, 0x89, 0x52, 0x55, 0x8a, 0xb0, 0xb6, 0xa0, 0x3a,
+	0x24, 0xcd, 0x52, 0xb1, 0x9a, 0x51, 0xd1, 0xde, 0x82, 0x02, 0x65, 0x3d, 0xd2, 0x84, 0x65, 0x5f,
+	0x27, 0x05, 0x5d, 0xf6, 0x3d, 0xb2, 0x06, 0x05, 0x8f, 0xf5, 0x74, 0xf0, 0x9b, 0x4e, 0x7c, 0xee,
+	0xec, 0xb1, 0x9e, 0x1f, 0xfa, 0xc2, 0x8f, 0x42, 0x8a, 0x9f, 0xec, 0xbf, 0x1a, 0x50, 0x52, 0x6a,
+	0x91, 0xcf, 0xc6, 0xec, 0x98, 0x9d, 0x2a, 0xd7, 0xb4, 0x7f, 0x96, 0xaf, 0xfd, 0x47, 0x59, 0xed,
+	0x67, 0xe6, 0x4f, 0xd6, 0x3a, 0x01, 0x0d, 0xca, 0xc4, 0x80, 0x87, 0x94, 0xfd, 0x6e, 0xc0, 0x12,
+	0x41, 0x7e, 0x91, 0x46, 0xc4, 0x34, 0xe6, 0x48, 0x2b, 0x64, 0xa4, 0x1a, 0x40, 0xda, 0xb0, 0xc2,
+	0x38, 0x8f, 0xb8, 0xd6, 0x82, 0x38, 0xaa, 0x72, 0x38, 0x3c, 0xee, 0x3a, 0x67, 0xb2, 0x72, 0x50,
+	0xc5, 0x60, 0xb7, 0xa0, 0x99, 0x9e, 0x9a, 0xc4, 0x51, 0x98, 0x30, 0x7b, 0x15, 0x1a, 0x87, 0x61,
+	0x3c, 0x10, 0x89, 0xd6, 0xc3, 0xfe, 0x87, 0x01, 0xcd, 0x94, 0xa2, 0x78, 0xc8, 0xd7, 0x50, 0x1b,
+	0xf9, 0x38, 0x75, 0xe6, 0x76, 0x8e, 0x7e, 0xe3, 0xf8, 0x4c, 0x80, 0xb4, 0x6f, 0xb3, 0xe2, 0xac,
+	0x13, 0x68, 0x4d, 0x32, 0x4c, 0xf1, 0xf4, 0x7b, 0xe3, 0x9e, 0x9e, 0x0c, 0x7c, 0xc6, 0xb3, 0x7f,
+	0x32, 0xe0, 0x36, 0x65, 0xb2, 0x14, 0x1e, 0x5e, 0xb8, 0x7d, 0xb6, 0x1b, 0x85, 0x3d, 0xbf, 0x9f,
+	0xba, 0xb9, 0x25, 0xb3, 0x2a, 0x95, 0x8c, 0x09, 0xd6, 0x86, 0xca, 0x69, 0xe0, 0x8a, 0x5e, 0xc4,
+	0x2f, 0xb4, 0xf0, 0x3a, 0x0a, 0x4f, 0x69, 0x74, 0xf8, 0x95, 0xac, 0x41, 0x4d, 0x0b, 0x3e, 0x8e,
+	0x3c, 0x26, 0x6b, 0x46, 0x95, 0x66, 0x49, 0xc4, 0x84, 0xf2, 0x51, 0xd4, 0x3f, 0x71, 0x2f, 0x98,
+	0x2c, 0x0e, 0x55, 0x9a, 0x6e, 0xed, 0xdf, 0x1b, 0x60, 0x4d, 0xd3, 0x4a, 0xbb, 0xf8, 0x73, 0x28,
+	0xed, 0xf9, 0x7d, 0x96, 0xa8, 0xe8, 0x57, 0x77, 0x36, 0xbf, 0xfb, 0xfe, 0x9d, 0xa5, 0x7f, 0x7f,
+	0xff, 0xce, 0xbd, 0x4c, 0x5d, 0x8d, 0x62, 0x16, 0x76, 0xa3, 0x50, 0xb8, 0x7e, 0xc8, 0x38, 0xb6,
+	0x87, 0x0f, 0x3c, 0x09, 0x71, 0x14, 0x92, 0x6a, 0x09, 0xe4, 0x2d, 0x28, 0x29, 0xe9, 0xfa, 0xda,
+	0xeb, 0x9d, 0xfd, 0xbf, 0x15, 0xa8, 0x9f, 0xa1, 0x02, 0xa9, 0x2f, 0x1c, 0x80, 0x91, 0x0b, 0x4d,
+	0x63, 0xaa, 0x63, 0x33, 0x1c, 0xc4, 0x82, 0xca, 0x81, 0x0e, 0xb1, 0xbe, 0xae, 0xc3, 0x3d, 0xf9,
+	0x0a, 0x6a, 0xe9, 0xfa, 0x69, 0x2c, 0xcc, 0x82, 0xcc, 0x91, 0x87, 0x39, 0x39, 0x92, 0xd5, 0xc4,
+	0xc9, 0x40, 0x75, 0x86, 0x64, 0x28, 0xe4, 0x13, 0xb8, 0x7d, 0x78, 0x11, 0x47, 0x5c, 0xec, 0xba,
+	0xdd, 0xe7, 0x8c, 0x8e, 0x77, 0x81, 0xe2, 0x5a, 0xa1, 0x5d, 0xa5, 0x37, 0x33, 0x90, 0x0d, 0x78,
+	0xc3, 0x0d, 0x82, 0xe8, 0x52, 0x5f, 0x1a, 0x99, 0xfe, 0xe6, 0xca, 0x9a, 0xd1, 0xae, 0xd0, 0xeb,
+	0x1f, 0xc8, 0x87, 0x70, 0x2b, 0x43, 0x7c, 0xcc, 0xb9, 0x7b, 0x85, 0xf9, 0x52, 0x92, 0xfc, 0xd3,
+	0x3e, 0x61, 0x05, 0x3b, 0xf0, 0x43, 0x37, 0x30, 0x41, 0xf2, 0xa8, 0x0d, 0xb1, 0xa1, 0xbe, 0xff,
+	0x0a, 0x55, 0x62, 0xfc, 0xb1, 0x10, 0xdc, 0xac, 0xc9, 0x50, 0x8c, 0xd1, 0xc8, 0x29, 0xd4, 0xa5,
+	0xc2, 0x4a, 0xf7, 0xc4, 0xac, 0x4b, 0xa7, 0x6d, 0xe4, 0x38, 0x4d, 0xb2, 0x3f, 0x8d, 0x33, 0x57,
+	0x69, 0x4c, 0x02, 0xe9, 0x42, 0x33, 0x75, 0x9c, 0xba, 0x83, 0x66, 0x43, 0xca, 0x7c, 0xb4, 0x68,
+	0x20, 0x14, 0x5a, 0x1d, 0x31, 0x21, 0x12, 0xd3, 0x60, 0x1f, 0xaf, 0x9b, 0x2b, 0x98, 0xd9, 0x94,
+	0x36, 0x0f, 0xf7, 0xd6, 0xa7, 0xd0, 0x9a, 0x8c, 0xe5, 0x22, 0x45, 0xdf, 0xfa, 0x15, 0xdc, 0x9a,
+	0xa2, 0xc2, 0x8f, 0xaa, 0x07, 0x7f, 0x33, 0xe0, 0x8d, 0x6b, 0x7e, 0x23, 0x04, 0x8a, 0x5f, 0x5e,
+	0xc5, 0x4c, 0x8b, 0x94, 0x6b, 0x72, 0x0c, 0x2b, 0x18, 0x97, 0xc4, 0x5c, 0x96, 0x4e, 0xdb, 0x5a,
+	0x24, 0x10, 0x8e, 0x44, 0xca, 0x25, 0x55, 0x52, 0xac, 0x87, 0x00, 0x23, 0xe2, 0x42, 0xad, 0xef,
+	0x6b, 0x68, 0xe8, 0xa8, 0xe8, 0xf2, 0xd0, 0x52, 0x53, 0x8a, 0x06, 0xe3, 0x0c, 0x32, 0x6a, 0x17,
+	0x85, 0x05, 0xdb, 0x85, 0xfd, 0x2d, 0xac, 0x52, 0xe6, 0x7a, 0x07, 0x7e, 0xc0, 0x6e, 0xae, 0x8a,
+	0x78, 0xd7, 0xfd, 0x80, 0x9d, 0xba, 0xe2, 0xf9, 0xf0, 0xae, 0xeb, 0x3d, 0xd9, 0x86, 0x15, 0xea,
+	0x86, 0x7d, 0xa6, 0x8f, 0x7e, 0x2f, 0xe7, 0x68, 0x79, 0x08, 0xf2, 0x52, 0x05, 0xb1, 0x1f, 0x41,
+	0x75, 0x48, 0xc3, 0x4a, 0xf5, 0xb4, 0xd7, 0x4b, 0x98, 0xaa, 0x7a, 0x05, 0xaa, 0x77, 0x48, 0x3f,
+	0x62, 0x61, 0x5f, 0x1f, 0x5d, 0xa0, 0x7a, 0x67, 0xaf, 0x43, 0x6b, 0xa4, 0xb9, 0x76, 0x0d, 0x81,
+	0xe2, 0x1e, 0xce, 0x53, 0x86, 0xbc, 0x60, 0x72, 0x6d, 0x7b, 0xd8, 0xe6, 0x5c, 0x6f, 0xcf, 0xe7,
+	0x37, 0x1b, 0x68, 0x42, 0x79, 0xcf, 0xe7, 0x19, 0xfb, 0xd2, 0x2d, 0x59, 0xc7, 0x06, 0xd8, 0x0d,
+	0x06, 0x1e, 0x5a, 0x2b, 0x18, 0x0f, 0x75, 0xa5, 0x9f, 0xa0, 0xda, 0x9f, 0xc1, 0xea, 0xf0, 0x14,
+	0xad, 0xcc, 0x06, 0x94, 0x59, 0x28, 0xb8, 0xcf, 0xd2, 0x2e, 0x49, 0x1c, 0x35, 0x02, 0x3b, 0x72,
+	0x04, 0x96, 0xdd, 0x98, 0xa6, 0x2c, 0xf6, 0x16, 0xac, 0x22, 0x21, 0x3f, 0x10, 0x04, 0x8a, 0x19,
+	0x25, 0xe5, 0xda, 0xde, 0x86, 0xd6, 0x08, 0xa8, 0x8f, 0x5e, 0x87, 0x22, 0x0e, 0xd8, 0xba, 0x8c,
+	0x4f, 0x3b, 0x57, 0x7e, 0xb7, 0x1b, 0x50, 0x3b, 0xf5, 0xc3, 0xb4, 0x1f, 0xda, 0xaf, 0x0d, 0xa8,
+	0x9f, 0x46, 0xe1, 0xa8, 0x13, 0x9d, 0xc2, 0x6a, 0x7a, 0x03, 0x1f, 0x9f, 0x1e, 0xee, 0xba, 0x71,
+	0x6a, 0xca, 0xda, 0xf5, 0x30, 0xeb, 0xb7, 0x80, 0xa3, 0x18, 0x77, 0x8a, 0xd8, 0xb4, 0xe8, 0x24,
+	0x9c, 0xfc, 0x12, 0xca, 0x47, 0x47, 0x3b, 0x52, 0xd2, 0xf2, 0x42, 0x92, 0x52, 0x18, 0xf9, 0x14,
+	0xca, 0xcf, 0xe4, 0x13, 0x25, 0xd1, 0x8d, 0x65, 0x4a, 0xca, 0x29, 0x43, 0x15, 0x1b, 0x65, 0xdd,
+	0x88, 0x7b, 0x34, 0x05, 0xd9, 0xff, 0x35, 0xe0, 0xd6, 0x09, 0xbb, 0xdc, 0x4d, 0x9b, 0x67, 0xea,
+	0xed, 0x35, 0xa8, 0x0d, 0x69, 0x87, 0x7b, 0xda, 0xeb, 0x59, 0x12, 0x79, 0x17, 0x4a, 0xc7, 0xd1,
+	0x20, 0x14, 0xa9, 0xea, 0x55, 0xac, 0x33, 0x92, 0x42, 0xf5, 0x07, 0xf2, 0x33, 0x28, 0x9f, 0x30,
+	0x81, 0x4f, 0x28, 0x99, 0x27, 0xcd, 0xcd, 0x1a, 0xf2, 0x9c, 0x30, 0x81, 0x13, 0x01, 0x4d, 0xbf,
+	0xe1, 0x98, 0x11, 0xa7, 0x63, 0x46, 0x71, 0xda, 0x98, 0x91, 0x7e, 0x25, 0x5b, 0x50, 0xeb, 0x46,
+	0x61, 0x22, 0xb8, 0xeb, 0xe3, 0xc1, 0x2b, 0x92, 0xf9, 0x27, 0xc8, 0xac, 0xec, 0xd9, 0x1d, 0x7d,
+	0xa4, 0x59, 0x4e, 0xfb, 0x2d, 0x78, 0x73, 0xdc, 0x4a, 0x3d, 0xe3, 0x3d, 0x82, 0x9f, 0x52, 0x16,
+	0x30, 0x37, 0x61, 0x8b, 0x7b, 0xc0, 0xb6, 0xc0, 0xbc, 0x0e, 0xd6, 0x82, 0xff, 0x5e, 0x80, 0xda,
+	0xfe, 0x2b, 0xd6, 0x3d, 0x66, 0x49, 0xe2, 0xf6, 0x19, 0x79, 0x1b, 0xaa, 0xa7, 0x3c, 0xea, 0xb2,
+	0x24, 0x19, 0xca, 0x1a, 0x11, 0xc8, 0x27, 0x50, 0x3c, 0x0c, 0x7d, 0xa1, 0x2b, 0xf6, 0x7a, 0xee,
+	0xfc, 0xe8, 0x0b, 0x2d, 0x13, 0xdf, 0x4e, 0xb8, 0x25, 0xdb, 0x50, 0xc4, 0x7c, 0x9f, 0xa7, 0xe6,
+	0x78, 0x19, 0x2c, 0x62, 0xc8, 0x8e, 0x7c, 0x6d, 0xfa, 0xdf, 0x30, 0xed, 0xf9, 0x76, 0x7e, 0xb1,
+	0xf4, 0xbf, 0x61, 0x23, 0x09, 0x1a, 0x49, 0xf6, 0xa1, 0x7c, 0x26, 0x5c, 0x8e, 0x23, 0x87, 0x8a,
+	0xc8, 0xdd, 0xbc, 0x9e, 0xaa, 0x38, 0x47, 0x52, 0x52, 0x2c, 0x3a, 0x61, 0xff, 0x95, 0x2f, 0xcc,
+	0xd2, 0x4c, 0x27, 0x20, 0x5b, 0xc6, 0x10, 0xdc, 0x22, 0x7a, 0x2f, 0x0a, 0x99, 0x59, 0x9e, 0x89,
+	0x46, 0xb6, 0x0c, 0x1a, 0xb7, 0x3b, 0x65, 0x58, 0x91, 0x4d, 0xd5, 0xfe, 0x8b, 0x01, 0xb5, 0x8c,
+	0x8f, 0xe7, 0xb8, 0x07, 0x6f, 0x43, 0x11, 0x1f, 0x9b, 0x3a, 0x76, 0x15, 0x79, 0x0b, 0x98, 0x70,
+	0xa9, 0xa4, 0x62, 0xd5, 0x3a, 0xf0, 0xd4, 0xdd, 0x6c, 0x50, 0x5c, 0x22, 0xe5, 0x4b, 0x71, 0x25,
+	0xdd, 0x5d, 0xa1, 0xb8, 0x24, 0x1b, 0x50, 0x39, 0x63, 0xdd, 0x01, 0xf7, 0xc5, 0x95, 0x74, 0x60,
+	0x73, 0xb3, 0x85, 0x52, 0x52, 0x9a, 0xbc, 0x2c, 0x43, 0x0e, 0xfb, 0x0b, 0x4c, 0xac, 0x91, 0x82,
+	0x04, 0x8a, 0xbb, 0x38, 0x72, 0xa3, 0x66, 0x0d, 0x2a, 0xd7, 0xf8, 0xea, 0xd9, 0x9f, 0xf5, 0xea,
+	0xd9, 0x4f, 0x5f, 0x3d, 0xe3, 0x01, 0xc1, 0x22, 0x98, 0x71, 0x90, 0xfd, 0x18, 0xaa, 0xc3, 0xa4,
+	0xc1, 0x07, 0xe7, 0x81, 0xa7, 0x4f, 0x5a, 0x3e, 0xf0, 0xd0, 0x94, 0xfd, 0xa7, 0x07, 0xf2, 0x94,
+	0x0a, 0xc5, 0xe5, 0xb0, 0xe5, 0x14, 0x32, 0x2d, 0x67, 0x0b, 0x1a, 0x2a, 0x51, 0x32, 0x2a, 0xd3,
+	0xe8, 0x32, 0x49, 0x55, 0xc6, 0xb5, 0x32, 0x23, 0x48, 0xcc, 0xe5, 0xd4, 0x8c, 0x20, 0xb1, 0xff,
+	0x6f, 0x40, 0xed, 0x99, 0x3b, 0x7a, 0x07, 0x7e, 0x0e, 0x25, 0xef, 0x47, 0xbf, 0x04, 0xd4, 0x16,
+	0x27, 0x8c, 0x80, 0xbd, 0x64, 0x81, 0x6e, 0xa3, 0x6a, 0x83, 0xd4, 0xe4, 0x79, 0xc4, 0x85, 0xd6,
+	0x5f, 0x6d, 0xb0, 0xe7, 0x7a, 0x4c, 0xb8, 0x7e, 0x20, 0x27, 0xea, 0x3a, 0xd5, 0x3b, 0x34, 0x7f,
+	0xc0, 0x03, 0x19, 0xb2, 0x2a, 0xc5, 0x25, 0xb1, 0xa1, 0xe8, 0x87, 0xbd, 0xc8, 0x2c, 0x8d, 0x26,
+	0xaf, 0xb3, 0x68, 0xc0, 0xbb, 0xec, 0x30, 0xec, 0x45, 0x54, 0x7e, 0xc3, 0xba, 0xc9, 0xb1, 0xc5,
+	0x27, 0x66, 0x79, 0x54, 0x37, 0xd5, 0x20, 0xa0, 0x3f, 0xd8, 0x4d, 0xa8, 0x2b, 0xbb, 0x55, 0x31,
+	0xd9, 0xfc, 0x57, 0x15, 0xaa, 0x47, 0x47, 0x3b, 0x3b, 0xdc, 0xf7, 0xfa, 0x8c, 0xfc, 0xc1, 0x00,
+	0x72, 0xfd, 0xbd, 0x44, 0x3e, 0xca, 0xbf, 0xb9, 0xd3, 0x1f, 0x7d, 0xd6, 0xc7, 0x0b, 0xa2, 0x74,
+	0x2b, 0xfc, 0x0a, 0x56, 0xe4, 0x18, 0x46, 0xde, 0x9f, 0x73, 0x7c, 0xb6, 0xda, 0xb3, 0x19, 0xb5,
+	0xec, 0x2e, 0x54, 0xd2, 0x51, 0x86, 0xdc, 0xcb, 0x55, 0x6f, 0x6c, 0x52, 0xb3, 0xee, 0xcf, 0xc5,
+	0xab, 0x0f, 0xf9, 0x2d, 0x94, 0xf5, 0x84, 0x42, 0xee, 0xce, 0xc0, 0x8d, 0x66, 0x25, 0xeb, 0xde,
+	0x3c, 0xac, 0x23, 0x33, 0xd2, 0x49, 0x24, 0xd7, 0x8c, 0x89, 0x39, 0xc7, 0xba, 0x3f, 0x17, 0xaf,
+	0x3e, 0xe4, 0x19, 0x14, 0x71, 0x64, 0x21, 0x79, 0xf5, 0x2e, 0x33, 0xd3, 0x58, 0x79, 0xe1, 0x1a,
+	0x9b, 0x75, 0x7e, 0x03, 0x25, 0xfd, 0xec, 0xcb, 0xef, 0x08, 0x99, 0xff, 0x69, 0xac, 0xbb, 0x73,
+	0x70, 0x8e, 0xc4, 0xeb, 0x27, 0x53, 0x7b, 0x8e, 0x3f, 0x4b, 0x66, 0x8b, 0x9f, 0xf8, 0x5b, 0x26,
+	0x82, 0x7a, 0xb6, 0xdd, 0x13, 0x27, 0x07, 0x3a, 0x65, 0xfa, 0xb1, 0x3a, 0x73, 0xf3, 0xeb, 0x03,
+	0xbf, 0x85, 0xd6, 0xe4, 0x28, 0x40, 0x36, 0x73, 0xdd, 0x31, 0x75, 0xe8, 0xb0, 0x1e, 0x2c, 0x84,
+	0xd1, 0x87, 0xbb, 0x6a, 0xd4, 0xd0, 0xe3, 0x04, 0xc9, 0xef, 0x9c, 0xc3, 0x91, 0xc4, 0x9a, 0x93,
+	0xaf, 0x6d, 0x7c, 0x68, 0x60, 0x9e, 0x61, 0x45, 0xca, 0x95, 0x9d, 0x29, 0xd5, 0xd6, 0xfb, 0x33,
+	0xf9, 0x94, 0xee, 0x3b, 0xf5, 0xef, 0x5e, 0xdf, 0x31, 0xfe, 0xf9, 0xfa, 0x8e, 0xf1, 0x9f, 0xd7,
+	0x77, 0x8c, 0xf3, 0x92, 0xfc, 0x0f, 0xfc, 0xc1, 0x0f, 0x03, 0x00, 0xd9, 0xec, 0x26, 0xa7, 0x55,
+	0x18, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2211,6 +2347,8 @@ type LLBBridgeClient interface {
 	NewContainer(ctx context.Context, in *NewContainerRequest, opts ...grpc.CallOption) (*NewContainerResponse, error)
 	ReleaseContainer(ctx context.Context, in *ReleaseContainerRequest, opts ...grpc.CallOption) (*ReleaseContainerResponse, error)
 	ExecProcess(ctx context.Context, opts ...grpc.CallOption) (LLBBridge_ExecProcessClient, error)
+	// apicaps:CapGatewayWarnings
+	Warn(ctx context.Context, in *WarnRequest, opts ...grpc.CallOption) (*WarnResponse, error)
 }
 
 type lLBBridgeClient struct {
@@ -2342,6 +2480,15 @@ func (x *lLBBridgeExecProcessClient) Recv() (*ExecMessage, error) {
 	return m, nil
 }
 
+func (c *lLBBridgeClient) Warn(ctx context.Context, in *WarnRequest, opts ...grpc.CallOption) (*WarnResponse, error) {
+	out := new(WarnResponse)
+	err := c.cc.Invoke(ctx, "/moby.buildkit.v1.frontend.LLBBridge/Warn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LLBBridgeServer is the server API for LLBBridge service.
 type LLBBridgeServer interface {
 	// apicaps:CapResolveImage
@@ -2361,6 +2508,8 @@ type LLBBridgeServer interface {
 	NewContainer(context.Context, *NewContainerRequest) (*NewContainerResponse, error)
 	ReleaseContainer(context.Context, *ReleaseContainerRequest) (*ReleaseContainerResponse, error)
 	ExecProcess(LLBBridge_ExecProcessServer) error
+	// apicaps:CapGatewayWarnings
+	Warn(context.Context, *WarnRequest) (*WarnResponse, error)
 }
 
 // UnimplementedLLBBridgeServer can be embedded to have forward compatible implementations.
@@ -2400,6 +2549,9 @@ func (*UnimplementedLLBBridgeServer) ReleaseContainer(ctx context.Context, req *
 func (*UnimplementedLLBBridgeServer) ExecProcess(srv LLBBridge_ExecProcessServer) error {
 	return status.Errorf(codes.Unimplemented, "method ExecProcess not implemented")
 }
+func (*UnimplementedLLBBridgeServer) Warn(ctx context.Context, req *WarnRequest) (*WarnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Warn not implemented")
+}
 
 func RegisterLLBBridgeServer(s *grpc.Server, srv LLBBridgeServer) {
 	s.RegisterService(&_LLBBridge_serviceDesc, srv)
@@ -2611,6 +2763,24 @@ func (x *lLBBridgeExecProcessServer) Recv() (*ExecMessage, error) {
 	return m, nil
 }
 
+func _LLBBridge_Warn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WarnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLBBridgeServer).Warn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moby.buildkit.v1.frontend.LLBBridge/Warn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLBBridgeServer).Warn(ctx, req.(*WarnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _LLBBridge_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "moby.buildkit.v1.frontend.LLBBridge",
 	HandlerType: (*LLBBridgeServer)(nil),
@@ -2655,6 +2825,10 @@ var _LLBBridge_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReleaseContainer",
 			Handler:    _LLBBridge_ReleaseContainer_Handler,
 		},
+		{
+			MethodName: "Warn",
+			Handler:    _LLBBridge_Warn_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4451,6 +4625,121 @@ func (m *ResizeMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *WarnRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WarnRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WarnRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Ranges) > 0 {
+		for iNdEx := len(m.Ranges) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ranges[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGateway(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.Info != nil {
+		{
+			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGateway(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Url) > 0 {
+		i -= len(m.Url)
+		copy(dAtA[i:], m.Url)
+		i = encodeVarintGateway(dAtA, i, uint64(len(m.Url)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Detail) > 0 {
+		for iNdEx := len(m.Detail) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Detail[iNdEx])
+			copy(dAtA[i:], m.Detail[iNdEx])
+			i = encodeVarintGateway(dAtA, i, uint64(len(m.Detail[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Short) > 0 {
+		i -= len(m.Short)
+		copy(dAtA[i:], m.Short)
+		i = encodeVarintGateway(dAtA, i, uint64(len(m.Short)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Level != 0 {
+		i = encodeVarintGateway(dAtA, i, uint64(m.Level))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Digest) > 0 {
+		i -= len(m.Digest)
+		copy(dAtA[i:], m.Digest)
+		i = encodeVarintGateway(dAtA, i, uint64(len(m.Digest)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WarnResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WarnResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WarnResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintGateway(dAtA []byte, offset int, v uint64) int {
 	offset -= sovGateway(v)
 	base := offset
@@ -5288,6 +5577,61 @@ func (m *ResizeMessage) Size() (n int) {
 	return n
 }
 
+func (m *WarnRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Digest)
+	if l > 0 {
+		n += 1 + l + sovGateway(uint64(l))
+	}
+	if m.Level != 0 {
+		n += 1 + sovGateway(uint64(m.Level))
+	}
+	l = len(m.Short)
+	if l > 0 {
+		n += 1 + l + sovGateway(uint64(l))
+	}
+	if len(m.Detail) > 0 {
+		for _, b := range m.Detail {
+			l = len(b)
+			n += 1 + l + sovGateway(uint64(l))
+		}
+	}
+	l = len(m.Url)
+	if l > 0 {
+		n += 1 + l + sovGateway(uint64(l))
+	}
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovGateway(uint64(l))
+	}
+	if len(m.Ranges) > 0 {
+		for _, e := range m.Ranges {
+			l = e.Size()
+			n += 1 + l + sovGateway(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WarnResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovGateway(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -10079,6 +10423,333 @@ func (m *ResizeMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *WarnRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGateway
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WarnRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WarnRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Digest", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Digest = github_com_opencontainers_go_digest.Digest(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
+			}
+			m.Level = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Level |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Short", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Short = append(m.Short[:0], dAtA[iNdEx:postIndex]...)
+			if m.Short == nil {
+				m.Short = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Detail", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Detail = append(m.Detail, make([]byte, postIndex-iNdEx))
+			copy(m.Detail[len(m.Detail)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Url", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Url = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Info == nil {
+				m.Info = &pb.SourceInfo{}
+			}
+			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ranges", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ranges = append(m.Ranges, &pb.Range{})
+			if err := m.Ranges[len(m.Ranges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGateway(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WarnResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGateway
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WarnResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WarnResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGateway(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipGateway(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,38 @@
+package subrequests
+
+import "github.com/moby/buildkit/solver/pb"
+
+const RequestLint = "frontend.lint"
+
+var LintDefinition = Request{
+	Name:        RequestLint,
+	Version:     "1.0.0",
+	Type:        TypeRPC,
+	Description: "Lint the build definition and list the rule violations",
+	Opts: []Named{
+		{
+			Name:        "target",
+			Description: "Target build stage",
+		},
+	},
+	Metadata: []Named{
+		{
+			Name: "result.json",
+		},
+	},
+}
+
+// LintResults is the result of the lint subrequest
+type LintResults struct {
+	Warnings []LintWarning `json:"warnings"`
+	// Error is set if the violations fail the build
+	Error string `json:"error,omitempty"`
+}
+
+// LintWarning is a violation of a lint rule
+type LintWarning struct {
+	RuleName    string      `json:"ruleName"`
+	Description string      `json:"description,omitempty"`
+	Detail      string      `json:"detail,omitempty"`
+	Location    []*pb.Range `json:"location,omitempty"`
+}
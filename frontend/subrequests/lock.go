@@ -0,0 +1,50 @@
+package subrequests
+
+import digest "github.com/opencontainers/go-digest"
+
+const RequestLock = "frontend.lock"
+
+var LockDefinition = Request{
+	Name:        RequestLock,
+	Version:     "1.0.0",
+	Type:        TypeRPC,
+	Description: "Resolve the sources of the build target to immutable versions",
+	Opts: []Named{
+		{
+			Name:        "target",
+			Description: "Target build stage",
+		},
+	},
+	Metadata: []Named{
+		{
+			Name: "result.json",
+		},
+	},
+}
+
+// Lockfile pins the sources of a build. It is the result of the lock
+// subrequest and is passed back with the lockfile frontend option.
+type Lockfile struct {
+	Images []LockedImage `json:"images,omitempty"`
+	HTTP   []LockedHTTP  `json:"http,omitempty"`
+	Git    []LockedGit   `json:"git,omitempty"`
+}
+
+// LockedImage is an image reference resolved to a digest
+type LockedImage struct {
+	Ref    string        `json:"ref"`
+	Digest digest.Digest `json:"digest"`
+}
+
+// LockedHTTP is the checksum of the content of a URL
+type LockedHTTP struct {
+	URL      string        `json:"url"`
+	Checksum digest.Digest `json:"checksum"`
+}
+
+// LockedGit is a git ref resolved to a commit
+type LockedGit struct {
+	Remote string `json:"remote"`
+	Ref    string `json:"ref,omitempty"`
+	Commit string `json:"commit"`
+}
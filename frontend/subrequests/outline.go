@@ -0,0 +1,55 @@
+package subrequests
+
+import "github.com/moby/buildkit/solver/pb"
+
+const RequestOutline = "frontend.outline"
+
+var OutlineDefinition = Request{
+	Name:        RequestOutline,
+	Version:     "1.0.0",
+	Type:        TypeRPC,
+	Description: "List all parameters current build target supports",
+	Opts: []Named{
+		{
+			Name:        "target",
+			Description: "Target build stage",
+		},
+	},
+	Metadata: []Named{
+		{
+			Name: "result.json",
+		},
+	},
+}
+
+// Outline describes the parameters of a build target
+type Outline struct {
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Stages      []string        `json:"stages,omitempty"`
+	Args        []OutlineArg    `json:"args,omitempty"`
+	Secrets     []OutlineSecret `json:"secrets,omitempty"`
+	SSH         []OutlineSSH    `json:"ssh,omitempty"`
+}
+
+// OutlineArg is a build argument used by the target
+type OutlineArg struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Value       string      `json:"value,omitempty"`
+	Location    []*pb.Range `json:"location,omitempty"`
+}
+
+// OutlineSecret is a secret mounted by the target
+type OutlineSecret struct {
+	ID       string      `json:"id"`
+	Required bool        `json:"required,omitempty"`
+	Location []*pb.Range `json:"location,omitempty"`
+}
+
+// OutlineSSH is an SSH agent socket mounted by the target
+type OutlineSSH struct {
+	ID       string      `json:"id"`
+	Required bool        `json:"required,omitempty"`
+	Location []*pb.Range `json:"location,omitempty"`
+}
@@ -0,0 +1,32 @@
+package subrequests
+
+import "github.com/moby/buildkit/solver/pb"
+
+const RequestTargets = "frontend.targets"
+
+var TargetsDefinition = Request{
+	Name:        RequestTargets,
+	Version:     "1.0.0",
+	Type:        TypeRPC,
+	Description: "List all targets current build supports",
+	Metadata: []Named{
+		{
+			Name: "result.json",
+		},
+	},
+}
+
+// TargetsList is the result of the targets subrequest
+type TargetsList struct {
+	Targets []Target `json:"targets"`
+}
+
+// Target is a stage that can be built
+type Target struct {
+	Name        string      `json:"name,omitempty"`
+	Default     bool        `json:"default,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Base        string      `json:"base,omitempty"`
+	Platform    string      `json:"platform,omitempty"`
+	Location    []*pb.Range `json:"location,omitempty"`
+}
@@ -41,6 +41,10 @@ func NewStore(dbPath string) (*Store, error) {
 	return &Store{db: db}, nil
 }
 
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
 func (s *Store) Exists(id string) bool {
 	exists := false
 	err := s.db.View(func(tx *bolt.Tx) error {
@@ -360,6 +364,44 @@ func (s *Store) WalkLinks(id string, link solver.CacheInfoLink, fn func(id strin
 	return nil
 }
 
+// WalkAllLinks calls fn for every outgoing link of id. It is used for
+// migrating the cache index to another storage.
+func (s *Store) WalkAllLinks(id string, fn func(link solver.CacheInfoLink, target string) error) error {
+	var links []solver.CacheInfoLink
+	var targets []string
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(linksBucket))
+		if b == nil {
+			return nil
+		}
+		b = b.Bucket([]byte(id))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			parts := bytes.SplitN(k, []byte("@"), 2)
+			if len(parts) != 2 {
+				return errors.Errorf("invalid key %s", k)
+			}
+			var l solver.CacheInfoLink
+			if err := json.Unmarshal(parts[0], &l); err != nil {
+				return err
+			}
+			links = append(links, l)
+			targets = append(targets, string(parts[1]))
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	for i := range links {
+		if err := fn(links[i], targets[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *Store) HasLink(id string, link solver.CacheInfoLink, target string) bool {
 	var v bool
 	if err := s.db.View(func(tx *bolt.Tx) error {
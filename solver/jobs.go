@@ -3,6 +3,7 @@ package solver
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 	digest "github.com/opencontainers/go-digest"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/semaphore"
 )
 
 // ResolveOpFunc finds an Op implementation for a Vertex
@@ -56,17 +58,29 @@ type state struct {
 	clientVertex client.Vertex
 	origDigest   digest.Digest // original LLB digest. TODO: probably better to use string ID so this isn't needed
 
-	mu    sync.Mutex
-	op    *sharedOp
-	edges map[Index]*edge
-	opts  SolverOpt
-	index *edgeIndex
+	mu      sync.Mutex
+	op      *sharedOp
+	started *time.Time // set while the op is executing
+	edges   map[Index]*edge
+	opts    SolverOpt
+	index   *edgeIndex
 
 	cache     map[string]CacheManager
 	mainCache CacheManager
 	solver    *Solver
 }
 
+func (s *state) setStarted(v bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v {
+		now := time.Now()
+		s.started = &now
+	} else {
+		s.started = nil
+	}
+}
+
 func (s *state) SessionIterator() session.Iterator {
 	return s.sessionIterator()
 }
@@ -225,12 +239,13 @@ func (sb *subBuilder) EachValue(ctx context.Context, key string, fn func(interfa
 }
 
 type Job struct {
-	list   *Solver
-	pr     *progress.MultiReader
-	pw     progress.Writer
-	span   opentracing.Span
-	values sync.Map
-	id     string
+	list      *Solver
+	pr        *progress.MultiReader
+	pw        progress.Writer
+	span      opentracing.Span
+	values    sync.Map
+	id        string
+	createdAt time.Time
 
 	progressCloser func()
 	SessionID      string
@@ -437,11 +452,12 @@ func (jl *Solver) NewJob(id string) (*Job, error) {
 
 	j := &Job{
 		list:           jl,
-		pr:             progress.NewMultiReader(pr),
+		pr:             progress.NewMultiReader(pr, progress.WithLogReplayLimit(isLogProgress, maxReplayedLogs)),
 		pw:             pw,
 		progressCloser: progressCloser,
 		span:           (&opentracing.NoopTracer{}).StartSpan(""),
 		id:             id,
+		createdAt:      time.Now(),
 	}
 	jl.jobs[id] = j
 
@@ -450,6 +466,70 @@ func (jl *Solver) NewJob(id string) (*Job, error) {
 	return j, nil
 }
 
+// maxReplayedLogs is the number of log writes of a job that are sent to status
+// readers that attach after the logs were written
+const maxReplayedLogs = 1000
+
+func isLogProgress(p *progress.Progress) bool {
+	_, ok := p.Sys.(client.VertexLog)
+	return ok
+}
+
+// JobInfo describes an active job
+type JobInfo struct {
+	ID        string
+	CreatedAt time.Time
+	// Running are the vertices of the job that are currently executing
+	Running []VertexInfo
+}
+
+type VertexInfo struct {
+	Digest  digest.Digest
+	Name    string
+	Started time.Time
+}
+
+// Jobs returns the active jobs
+func (jl *Solver) Jobs() []JobInfo {
+	jl.mu.RLock()
+	defer jl.mu.RUnlock()
+
+	infos := make(map[*Job]*JobInfo, len(jl.jobs))
+	for _, j := range jl.jobs {
+		infos[j] = &JobInfo{
+			ID:        j.id,
+			CreatedAt: j.createdAt,
+		}
+	}
+	for _, st := range jl.actives {
+		st.mu.Lock()
+		if st.started != nil {
+			for j := range st.jobs {
+				if info, ok := infos[j]; ok {
+					info.Running = append(info.Running, VertexInfo{
+						Digest:  st.origDigest,
+						Name:    st.vtx.Name(),
+						Started: *st.started,
+					})
+				}
+			}
+		}
+		st.mu.Unlock()
+	}
+
+	out := make([]JobInfo, 0, len(infos))
+	for _, info := range infos {
+		sort.Slice(info.Running, func(i, j int) bool {
+			return info.Running[i].Started.Before(info.Running[j].Started)
+		})
+		out = append(out, *info)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out
+}
+
 func (jl *Solver) Get(id string) (*Job, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -539,6 +619,11 @@ func (j *Job) SetValue(key string, v interface{}) {
 	j.values.Store(key, v)
 }
 
+// SetFrontend records the frontend that the job was started with.
+func (j *Job) SetFrontend(frontend string) {
+	j.SetValue(keyFrontend, frontend)
+}
+
 func (j *Job) EachValue(ctx context.Context, key string, fn func(interface{}) error) error {
 	v, ok := j.values.Load(key)
 	if ok {
@@ -760,8 +845,20 @@ func (s *sharedOp) Exec(ctx context.Context, inputs []Result) (outputs []Result,
 
 		ctx = opentracing.ContextWithSpan(progress.WithProgress(ctx, s.st.mpw), s.st.mspan)
 		ctx = withAncestorCacheOpts(ctx, s.st)
+		ctx = withBuildRef(ctx, s.st)
+
+		// the vertex is only started once it has a free slot
+		if pop, ok := op.(ParallelismOp); ok {
+			release, err := acquireSemaphores(ctx, s.st.origDigest, pop.Semaphores(ctx))
+			if err != nil {
+				return nil, err
+			}
+			defer release()
+		}
 
 		// no cache hit. start evaluating the node
+		s.st.setStarted(true)
+		defer s.st.setStarted(false)
 		span, ctx := tracing.StartSpan(ctx, s.st.vtx.Name())
 		notifyStarted(ctx, &s.st.clientVertex, false)
 		defer func() {
@@ -804,6 +901,51 @@ func (s *sharedOp) Exec(ctx context.Context, inputs []Result) (outputs []Result,
 	return unwrapShared(r.execRes), r.execExporters, nil
 }
 
+type buildRefKey struct{}
+
+const keyFrontend = "solver.frontend"
+
+// BuildRefOf returns the reference of the build that the vertex currently
+// being executed belongs to. If the vertex is shared by multiple builds, the
+// lexically smallest reference is returned.
+func BuildRefOf(ctx context.Context) string {
+	if j, ok := ctx.Value(buildRefKey{}).(*Job); ok {
+		return j.id
+	}
+	return ""
+}
+
+// BuildValueOf returns the value set with Job.SetValue by the build returned
+// by BuildRefOf.
+func BuildValueOf(ctx context.Context, key string) (interface{}, bool) {
+	if j, ok := ctx.Value(buildRefKey{}).(*Job); ok {
+		return j.values.Load(key)
+	}
+	return nil, false
+}
+
+// BuildFrontendOf returns the frontend of the build returned by BuildRefOf.
+func BuildFrontendOf(ctx context.Context) string {
+	v, _ := BuildValueOf(ctx, keyFrontend)
+	frontend, _ := v.(string)
+	return frontend
+}
+
+func withBuildRef(ctx context.Context, st *state) context.Context {
+	var job *Job
+	st.mu.Lock()
+	for j := range st.jobs {
+		if job == nil || j.id < job.id {
+			job = j
+		}
+	}
+	st.mu.Unlock()
+	if job == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, buildRefKey{}, job)
+}
+
 func (s *sharedOp) getOp() (Op, error) {
 	s.opOnce.Do(func() {
 		s.subBuilder = s.st.builder()
@@ -865,6 +1007,45 @@ func (v *vertexWithCacheOptions) Inputs() []Edge {
 	return v.inputs
 }
 
+// acquireSemaphores acquires one slot of each semaphore. If a semaphore is
+// full, a "waiting" status is written to the progress of the vertex until all
+// of them have been acquired.
+func acquireSemaphores(ctx context.Context, dgst digest.Digest, sems []*semaphore.Weighted) (func(), error) {
+	var acquired []*semaphore.Weighted
+	release := func() {
+		for _, s := range acquired {
+			s.Release(1)
+		}
+	}
+	var waiting *progress.Status
+	id := "waiting." + dgst.String()
+	pw, _, _ := progress.FromContext(ctx)
+	defer pw.Close()
+	for _, s := range sems {
+		if s == nil {
+			continue
+		}
+		if !s.TryAcquire(1) {
+			if waiting == nil {
+				now := time.Now()
+				waiting = &progress.Status{Action: "waiting", Started: &now}
+				pw.Write(id, *waiting)
+			}
+			if err := s.Acquire(ctx, 1); err != nil {
+				release()
+				return nil, err
+			}
+		}
+		acquired = append(acquired, s)
+	}
+	if waiting != nil {
+		now := time.Now()
+		waiting.Completed = &now
+		pw.Write(id, *waiting)
+	}
+	return release, nil
+}
+
 func notifyStarted(ctx context.Context, v *client.Vertex, cached bool) {
 	pw, _, _ := progress.FromContext(ctx)
 	defer pw.Close()
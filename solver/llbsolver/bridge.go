@@ -10,15 +10,19 @@ import (
 	"github.com/containerd/containerd/platforms"
 	"github.com/mitchellh/hashstructure"
 	"github.com/moby/buildkit/cache/remotecache"
+	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/frontend"
 	gw "github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/solver"
 	"github.com/moby/buildkit/solver/errdefs"
 	llberrdefs "github.com/moby/buildkit/solver/llbsolver/errdefs"
+	"github.com/moby/buildkit/solver/llbsolver/mounts"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/flightcontrol"
+	"github.com/moby/buildkit/util/progress"
 	"github.com/moby/buildkit/worker"
 	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
@@ -45,6 +49,14 @@ func (b *llbBridge) loadResult(ctx context.Context, def *pb.Definition, cacheImp
 	if err != nil {
 		return nil, err
 	}
+	cgroupParent, err := loadCgroupParent(b.builder)
+	if err != nil {
+		return nil, err
+	}
+	cacheMountImports, err := loadCacheMountImports(b.builder)
+	if err != nil {
+		return nil, err
+	}
 	var cms []solver.CacheManager
 	for _, im := range cacheImports {
 		cmID, err := cmKey(im)
@@ -66,7 +78,7 @@ func (b *llbBridge) loadResult(ctx context.Context, def *pb.Definition, cacheImp
 						if err != nil {
 							return err
 						}
-						cmNew, err = ci.Resolve(ctx, desc, cmID, w)
+						cmNew, err = ci.Resolve(mounts.WithCacheMountImports(ctx, cacheMountImports), desc, cmID, w)
 						return err
 					}); err != nil {
 						logrus.Debugf("error while importing cache manifest from cmId=%s: %v", cmID, err)
@@ -84,7 +96,7 @@ func (b *llbBridge) loadResult(ctx context.Context, def *pb.Definition, cacheImp
 	}
 	dpc := &detectPrunedCacheID{}
 
-	edge, err := Load(def, dpc.Load, ValidateEntitlements(ent), WithCacheSources(cms), NormalizeRuntimePlatforms(), WithValidateCaps())
+	edge, err := Load(def, dpc.Load, ValidateEntitlements(ent, cgroupParent), WithCacheSources(cms), NormalizeRuntimePlatforms(), WithValidateCaps())
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load LLB")
 	}
@@ -137,6 +149,12 @@ func (b *llbBridge) Solve(ctx context.Context, req frontend.SolveRequest, sid st
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to solve with frontend %s", req.Frontend)
 		}
+		if req.Evaluate {
+			err = res.EachRef(func(ref solver.ResultProxy) error {
+				_, err := ref.Result(ctx)
+				return err
+			})
+		}
 	} else {
 		return &frontend.Result{}, nil
 	}
@@ -287,6 +305,25 @@ func (b *llbBridge) ResolveImageConfig(ctx context.Context, ref string, opt llb.
 	return dgst, config, err
 }
 
+func (b *llbBridge) Warn(ctx context.Context, dgst digest.Digest, msg string, opts frontend.WarnOpts) error {
+	return b.builder.InContext(ctx, func(ctx context.Context, g session.Group) error {
+		pw, ok, _ := progress.FromContext(ctx, progress.WithMetadata("vertex", dgst))
+		if !ok {
+			return nil
+		}
+		defer pw.Close()
+		return pw.Write(identity.NewID(), client.VertexWarning{
+			Vertex:     dgst,
+			Level:      int(opts.Level),
+			Short:      []byte(msg),
+			Detail:     opts.Detail,
+			URL:        opts.URL,
+			SourceInfo: opts.SourceInfo,
+			Range:      opts.Range,
+		})
+	})
+}
+
 type lazyCacheManager struct {
 	id   string
 	main solver.CacheManager
@@ -206,6 +206,9 @@ func docopy(ctx context.Context, src, dest string, action pb.FileActionCopy, u *
 				return nil
 			}
 		}
+		if needsFilter(action) {
+			return copyFiltered(ctx, src, srcPath, dest, destPath, action, ch, opt)
+		}
 		return copy.Copy(ctx, src, srcPath, dest, destPath, opt...)
 	}
 
@@ -229,6 +232,12 @@ func docopy(ctx context.Context, src, dest string, action pb.FileActionCopy, u *
 				continue
 			}
 		}
+		if needsFilter(action) {
+			if err := copyFiltered(ctx, src, s, dest, destPath, action, ch, opt); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := copy.Copy(ctx, src, s, dest, destPath, opt...); err != nil {
 			return err
 		}
@@ -0,0 +1,153 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/continuity/fs"
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+	copy "github.com/tonistiigi/fsutil/copy"
+)
+
+func needsFilter(action pb.FileActionCopy) bool {
+	return len(action.IncludePatterns) > 0 || len(action.ExcludePatterns) > 0 || action.Parents
+}
+
+// copyFiltered copies srcPath from src to destPath in dest keeping only the
+// files selected by the include and exclude patterns of the action. Patterns
+// are matched relative to srcPath if it is a directory and a single file is
+// matched by its path relative to the source root. If parents is set, srcPath
+// is recreated relative to the source root under destPath.
+func copyFiltered(ctx context.Context, src, srcPath, dest, destPath string, action pb.FileActionCopy, ch copy.Chowner, opt []copy.Opt) error {
+	include, err := newMatcher(action.IncludePatterns)
+	if err != nil {
+		return errors.Wrap(err, "invalid include patterns")
+	}
+	exclude, err := newMatcher(action.ExcludePatterns)
+	if err != nil {
+		return errors.Wrap(err, "invalid exclude patterns")
+	}
+
+	var srcFollowed string
+	if action.FollowSymlink {
+		srcFollowed, err = fs.RootPath(src, srcPath)
+	} else {
+		d, f := filepath.Split(filepath.Join("/", srcPath))
+		srcFollowed, err = fs.RootPath(src, d)
+		srcFollowed = filepath.Join(srcFollowed, f)
+	}
+	if err != nil {
+		return err
+	}
+	fi, err := os.Lstat(srcFollowed)
+	if err != nil {
+		return err
+	}
+
+	if !fi.IsDir() {
+		ok, err := matchPath(include, exclude, strings.TrimPrefix(filepath.Join("/", srcPath), "/"))
+		if err != nil || !ok {
+			return err
+		}
+		if action.Parents {
+			destPath = filepath.Join(destPath, srcPath)
+		}
+		return copy.Copy(ctx, src, srcPath, dest, destPath, opt...)
+	}
+
+	target := destPath
+	if action.Parents {
+		target = filepath.Join(destPath, srcPath)
+	} else if !action.DirCopyContents {
+		p, err := fs.RootPath(dest, destPath)
+		if err != nil {
+			return err
+		}
+		if st, err := os.Stat(p); err == nil && st.IsDir() {
+			target = filepath.Join(destPath, filepath.Base(srcFollowed))
+		}
+	}
+
+	targetRoot, err := fs.RootPath(dest, target)
+	if err != nil {
+		return err
+	}
+	utime := timestampToTime(action.Timestamp)
+	if err := copy.MkdirAll(targetRoot, fi.Mode().Perm(), ch, utime); err != nil {
+		return err
+	}
+
+	// entries inside the copied directory are copied as is, like copy.Copy does
+	opt = append(opt, func(ci *copy.CopyInfo) {
+		ci.FollowLinks = false
+	})
+
+	return filepath.Walk(srcFollowed, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcFollowed, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		if exclude != nil {
+			excluded, err := exclude.Matches(rel)
+			if err != nil {
+				return err
+			}
+			if excluded {
+				if fi.IsDir() && !exclude.Exclusions() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		if include != nil {
+			included, err := include.Matches(rel)
+			if err != nil {
+				return err
+			}
+			// parents of included files are created when copying them
+			if !included {
+				return nil
+			}
+		}
+
+		if fi.IsDir() {
+			p, err := fs.RootPath(dest, filepath.Join(target, rel))
+			if err != nil {
+				return err
+			}
+			return copy.MkdirAll(p, fi.Mode().Perm(), ch, utime)
+		}
+		return copy.Copy(ctx, src, filepath.Join(srcPath, rel), dest, filepath.Join(target, rel), opt...)
+	})
+}
+
+func newMatcher(patterns []string) (*fileutils.PatternMatcher, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	return fileutils.NewPatternMatcher(patterns)
+}
+
+func matchPath(include, exclude *fileutils.PatternMatcher, p string) (bool, error) {
+	if exclude != nil {
+		excluded, err := exclude.Matches(p)
+		if err != nil || excluded {
+			return false, err
+		}
+	}
+	if include != nil {
+		return include.Matches(p)
+	}
+	return true, nil
+}
@@ -0,0 +1,192 @@
+package file
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/stretchr/testify/require"
+	copy "github.com/tonistiigi/fsutil/copy"
+)
+
+func TestCopyFilteredExclude(t *testing.T) {
+	t.Parallel()
+
+	src := newFilterTestSource(t)
+	defer os.RemoveAll(src)
+
+	// only direct children match patterns without a directory
+	dest := copyFilteredTest(t, src, "dir", "/out", pb.FileActionCopy{
+		ExcludePatterns: []string{"*.go"},
+	})
+	defer os.RemoveAll(dest)
+	require.Equal(t, []string{"a.txt", "sub/", "sub/c.txt", "sub/d.go"}, listFiles(t, filepath.Join(dest, "out")))
+
+	dest2 := copyFilteredTest(t, src, "dir", "/out", pb.FileActionCopy{
+		ExcludePatterns: []string{"**/*.go", "sub/c.txt"},
+	})
+	defer os.RemoveAll(dest2)
+	require.Equal(t, []string{"a.txt", "sub/"}, listFiles(t, filepath.Join(dest2, "out")))
+
+	// excluded directories are skipped with their content
+	dest3 := copyFilteredTest(t, src, "dir", "/out", pb.FileActionCopy{
+		ExcludePatterns: []string{"sub"},
+	})
+	defer os.RemoveAll(dest3)
+	require.Equal(t, []string{"a.txt", "b.go"}, listFiles(t, filepath.Join(dest3, "out")))
+
+	// exceptions reinclude files of excluded directories
+	dest4 := copyFilteredTest(t, src, "dir", "/out", pb.FileActionCopy{
+		ExcludePatterns: []string{"sub", "!sub/d.go"},
+	})
+	defer os.RemoveAll(dest4)
+	require.Equal(t, []string{"a.txt", "b.go", "sub/", "sub/d.go"}, listFiles(t, filepath.Join(dest4, "out")))
+}
+
+func TestCopyFilteredInclude(t *testing.T) {
+	t.Parallel()
+
+	src := newFilterTestSource(t)
+	defer os.RemoveAll(src)
+
+	dest := copyFilteredTest(t, src, "dir", "/out", pb.FileActionCopy{
+		IncludePatterns: []string{"a.txt", "sub/*.go"},
+	})
+	defer os.RemoveAll(dest)
+	require.Equal(t, []string{"a.txt", "sub/", "sub/d.go"}, listFiles(t, filepath.Join(dest, "out")))
+
+	// included directories are copied with their content
+	dest2 := copyFilteredTest(t, src, "dir", "/out", pb.FileActionCopy{
+		IncludePatterns: []string{"sub"},
+	})
+	defer os.RemoveAll(dest2)
+	require.Equal(t, []string{"sub/", "sub/c.txt", "sub/d.go"}, listFiles(t, filepath.Join(dest2, "out")))
+
+	// exclude patterns take precedence
+	dest3 := copyFilteredTest(t, src, "dir", "/out", pb.FileActionCopy{
+		IncludePatterns: []string{"sub"},
+		ExcludePatterns: []string{"**/*.go"},
+	})
+	defer os.RemoveAll(dest3)
+	require.Equal(t, []string{"sub/", "sub/c.txt"}, listFiles(t, filepath.Join(dest3, "out")))
+}
+
+func TestCopyFilteredParents(t *testing.T) {
+	t.Parallel()
+
+	src := newFilterTestSource(t)
+	defer os.RemoveAll(src)
+
+	dest := copyFilteredTest(t, src, "dir/sub", "/out", pb.FileActionCopy{
+		Parents: true,
+	})
+	defer os.RemoveAll(dest)
+	require.Equal(t, []string{"dir/", "dir/sub/", "dir/sub/c.txt", "dir/sub/d.go"}, listFiles(t, filepath.Join(dest, "out")))
+
+	dest2 := copyFilteredTest(t, src, "dir/a.txt", "/out", pb.FileActionCopy{
+		Parents: true,
+	})
+	defer os.RemoveAll(dest2)
+	require.Equal(t, []string{"dir/", "dir/a.txt"}, listFiles(t, filepath.Join(dest2, "out")))
+
+	dest3 := copyFilteredTest(t, src, "dir", "/out", pb.FileActionCopy{
+		Parents:         true,
+		ExcludePatterns: []string{"sub"},
+	})
+	defer os.RemoveAll(dest3)
+	require.Equal(t, []string{"dir/", "dir/a.txt", "dir/b.go"}, listFiles(t, filepath.Join(dest3, "out")))
+}
+
+func TestCopyFilteredFile(t *testing.T) {
+	t.Parallel()
+
+	src := newFilterTestSource(t)
+	defer os.RemoveAll(src)
+
+	// file sources are matched by their path relative to the root
+	dest := copyFilteredTest(t, src, "file.txt", "/out/file.txt", pb.FileActionCopy{
+		ExcludePatterns: []string{"*.go"},
+	})
+	defer os.RemoveAll(dest)
+	require.Equal(t, []string{"file.txt"}, listFiles(t, filepath.Join(dest, "out")))
+
+	dest2 := copyFilteredTest(t, src, "dir/b.go", "/out/b.go", pb.FileActionCopy{
+		ExcludePatterns: []string{"dir/*.go"},
+	})
+	defer os.RemoveAll(dest2)
+	require.Equal(t, []string(nil), listFiles(t, filepath.Join(dest2, "out")))
+
+	dest4 := copyFilteredTest(t, src, "/dir/b.go", "/out/b.go", pb.FileActionCopy{
+		ExcludePatterns: []string{"*.go"},
+	})
+	defer os.RemoveAll(dest4)
+	require.Equal(t, []string{"b.go"}, listFiles(t, filepath.Join(dest4, "out")))
+
+	dest3 := copyFilteredTest(t, src, "dir/b.go", "/out/b.go", pb.FileActionCopy{
+		IncludePatterns: []string{"**/*.txt"},
+	})
+	defer os.RemoveAll(dest3)
+	require.Equal(t, []string(nil), listFiles(t, filepath.Join(dest3, "out")))
+
+	// directory sources are copied into existing directories
+	require.NoError(t, os.MkdirAll(filepath.Join(dest3, "out"), 0755))
+	require.NoError(t, copyFiltered(context.TODO(), src, "dir/sub", dest3, "/out", pb.FileActionCopy{
+		ExcludePatterns: []string{"*.go"},
+	}, nil, nil))
+	require.Equal(t, []string{"sub/", "sub/c.txt"}, listFiles(t, filepath.Join(dest3, "out")))
+}
+
+func newFilterTestSource(t *testing.T) string {
+	src, err := ioutil.TempDir("", "filter-src")
+	require.NoError(t, err)
+	for _, p := range []string{"dir/a.txt", "dir/b.go", "dir/sub/c.txt", "dir/sub/d.go", "file.txt"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(src, filepath.Dir(p)), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(src, p), []byte(p), 0644))
+	}
+	return src
+}
+
+func copyFilteredTest(t *testing.T, src, srcPath, destPath string, action pb.FileActionCopy) string {
+	dest, err := ioutil.TempDir("", "filter-dest")
+	require.NoError(t, err)
+	opt := []copy.Opt{
+		func(ci *copy.CopyInfo) {
+			ci.CopyDirContents = action.DirCopyContents
+			ci.FollowLinks = action.FollowSymlink
+		},
+	}
+	require.NoError(t, copyFiltered(context.TODO(), src, srcPath, dest, destPath, action, nil, opt))
+	return dest
+}
+
+// listFiles returns the paths under root, directories with a trailing slash
+func listFiles(t *testing.T, root string) []string {
+	var files []string
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil
+	}
+	err := filepath.Walk(root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if fi.IsDir() {
+			rel += "/"
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	require.NoError(t, err)
+	sort.Strings(files)
+	return files
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/moby/buildkit/session/secrets"
 	"github.com/moby/buildkit/session/sshforward"
 	"github.com/moby/buildkit/snapshot"
+	"github.com/moby/buildkit/solver"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/grpcerrors"
 	"github.com/moby/locker"
@@ -107,7 +108,7 @@ func (g *cacheRefGetter) getRefCacheDir(ctx context.Context, ref cache.Immutable
 
 func (g *cacheRefGetter) getRefCacheDirNoCache(ctx context.Context, key string, ref cache.ImmutableRef, id string, block bool) (cache.MutableRef, error) {
 	makeMutable := func(ref cache.ImmutableRef) (cache.MutableRef, error) {
-		return g.cm.New(ctx, ref, g.session, cache.WithRecordType(client.UsageRecordTypeCacheMount), cache.WithDescription(g.name), cache.CachePolicyRetain)
+		return g.cm.New(ctx, ref, g.session, cache.WithRecordType(client.UsageRecordTypeCacheMount), cache.WithDescription(g.name), cache.WithCacheMountID(id), cache.CachePolicyRetain, cache.WithBuildRef(solver.BuildRefOf(ctx)), cache.WithFrontend(solver.BuildFrontendOf(ctx)))
 	}
 
 	cacheRefsLocker.Lock(key)
@@ -119,7 +120,7 @@ func (g *cacheRefGetter) getRefCacheDirNoCache(ctx context.Context, key string,
 		}
 		locked := false
 		for _, si := range sis {
-			if mRef, err := g.cm.GetMutable(ctx, si.ID()); err == nil {
+			if mRef, err := g.cm.GetMutable(ctx, si.ID(), cache.WithBuildRef(solver.BuildRefOf(ctx))); err == nil {
 				logrus.Debugf("reusing ref for cache dir: %s", mRef.ID())
 				return mRef, nil
 			} else if errors.Is(err, cache.ErrLocked) {
@@ -157,6 +158,11 @@ func (g *cacheRefGetter) getRefCacheDirNoCache(ctx context.Context, key string,
 		mRef.Release(context.TODO())
 		return nil, err
 	}
+	if ref == nil {
+		if err := applyCacheMountImport(ctx, mRef, id, g.session); err != nil {
+			logrus.Warnf("%+v", err)
+		}
+	}
 	return mRef, nil
 }
 
@@ -385,3 +385,96 @@ func TestCacheMountSharedRefsDeadlock(t *testing.T) {
 		require.FailNow(t, "deadlock on releasing while getting new ref")
 	}
 }
+
+func TestCacheMountExportImport(t *testing.T) {
+	t.Parallel()
+	if os.Getuid() != 0 {
+		t.Skip("requires root for read-only mounts")
+	}
+	ctx := namespaces.WithNamespace(context.Background(), "buildkit-test")
+
+	co, cleanup, err := newCacheManager(ctx, cmOpt{})
+	require.NoError(t, err)
+	defer cleanup()
+
+	g := newRefGetter(co.manager, co.md, sharedCacheRefs)
+	ref, err := g.getRefCacheDir(ctx, nil, "export-import", pb.CacheSharingOpt_PRIVATE)
+	require.NoError(t, err)
+
+	writeCacheMountFile(ctx, t, ref, "foo", "bar")
+
+	// cache mount in use is not exported
+	remote, release, err := ExportCacheMount(ctx, co.manager, co.md, co.cs, leaseutil.WithNamespace(co.lm, "buildkit-test"), "export-import", nil)
+	require.NoError(t, err)
+	require.Nil(t, remote)
+	require.Nil(t, release)
+
+	err = ref.Release(context.TODO())
+	require.NoError(t, err)
+
+	remote, release, err = ExportCacheMount(ctx, co.manager, co.md, co.cs, leaseutil.WithNamespace(co.lm, "buildkit-test"), "export-import", nil)
+	require.NoError(t, err)
+	require.NotNil(t, remote)
+	require.Equal(t, 1, len(remote.Descriptors))
+	defer release(context.TODO())
+
+	// the cache mount is not locked while its content is read
+	ref, err = g.getRefCacheDir(ctx, nil, "export-import", pb.CacheSharingOpt_PRIVATE)
+	require.NoError(t, err)
+	err = ref.Release(context.TODO())
+	require.NoError(t, err)
+
+	remote2, _, err := ExportCacheMount(ctx, co.manager, co.md, co.cs, leaseutil.WithNamespace(co.lm, "buildkit-test"), "missing", nil)
+	require.NoError(t, err)
+	require.Nil(t, remote2)
+
+	co2, cleanup2, err := newCacheManager(ctx, cmOpt{})
+	require.NoError(t, err)
+	defer cleanup2()
+
+	// imports outside of a build are ignored
+	err = AddCacheMountImport(ctx, "export-import", remote)
+	require.NoError(t, err)
+
+	imports := NewCacheMountImports()
+	ictx := WithCacheMountImports(ctx, imports)
+	err = AddCacheMountImport(ictx, "export-import", remote)
+	require.NoError(t, err)
+
+	g2 := newRefGetter(co2.manager, co2.md, &cacheRefs{})
+	ref2, err := g2.getRefCacheDir(ictx, nil, "export-import", pb.CacheSharingOpt_PRIVATE)
+	require.NoError(t, err)
+	defer ref2.Release(context.TODO())
+
+	m, err := ref2.Mount(ctx, true, nil)
+	require.NoError(t, err)
+	lm := snapshot.LocalMounter(m)
+	dir, err := lm.Mount()
+	require.NoError(t, err)
+	defer lm.Unmount()
+
+	dt, err := ioutil.ReadFile(filepath.Join(dir, "foo"))
+	require.NoError(t, err)
+	require.Equal(t, "bar", string(dt))
+
+	// imported content is only applied once
+	require.Nil(t, imports.take("export-import"))
+
+	// unused content is dropped with the build
+	err = AddCacheMountImport(ictx, "unused", remote)
+	require.NoError(t, err)
+	imports.Release()
+	require.Nil(t, imports.take("unused"))
+}
+
+func writeCacheMountFile(ctx context.Context, t *testing.T, ref cache.MutableRef, name, data string) {
+	m, err := ref.Mount(ctx, false, nil)
+	require.NoError(t, err)
+	lm := snapshot.LocalMounter(m)
+	dir, err := lm.Mount()
+	require.NoError(t, err)
+	defer lm.Unmount()
+
+	err = ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0600)
+	require.NoError(t, err)
+}
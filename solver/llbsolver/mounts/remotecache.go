@@ -0,0 +1,234 @@
+package mounts
+
+import (
+	"compress/gzip"
+	"context"
+	"sync"
+
+	"github.com/containerd/containerd/archive"
+	"github.com/containerd/containerd/archive/compression"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/leases"
+	"github.com/moby/buildkit/cache"
+	"github.com/moby/buildkit/cache/metadata"
+	"github.com/moby/buildkit/identity"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/snapshot"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/util/leaseutil"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+type cacheMountImportsKey struct{}
+
+// CacheMountImports holds the content of cache mounts loaded from the remote
+// caches imported by a build. The content is extracted when the cache mount is
+// created for the first time.
+type CacheMountImports struct {
+	mu sync.Mutex
+	m  map[string]*solver.Remote
+}
+
+func NewCacheMountImports() *CacheMountImports {
+	return &CacheMountImports{m: map[string]*solver.Remote{}}
+}
+
+// WithCacheMountImports returns a context that cache mount imports are added
+// to and cache mounts are restored from.
+func WithCacheMountImports(ctx context.Context, c *CacheMountImports) context.Context {
+	if c == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, cacheMountImportsKey{}, c)
+}
+
+func cacheMountImportsOf(ctx context.Context) *CacheMountImports {
+	c, _ := ctx.Value(cacheMountImportsKey{}).(*CacheMountImports)
+	return c
+}
+
+// Release drops the content that hasn't been used by the build.
+func (c *CacheMountImports) Release() {
+	c.mu.Lock()
+	c.m = map[string]*solver.Remote{}
+	c.mu.Unlock()
+}
+
+// AddCacheMountImport registers the content of a cache mount imported from a
+// remote cache with the build of the context. Content for a cache mount that
+// already exists locally is ignored.
+func AddCacheMountImport(ctx context.Context, id string, remote *solver.Remote) error {
+	if len(remote.Descriptors) != 1 {
+		return errors.Errorf("invalid cache mount %s with %d blobs", id, len(remote.Descriptors))
+	}
+	c := cacheMountImportsOf(ctx)
+	if c == nil {
+		logrus.Debugf("skipping import of cache mount %s outside of a build", id)
+		return nil
+	}
+	c.mu.Lock()
+	c.m[id] = remote
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *CacheMountImports) take(id string) *solver.Remote {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.m[id]
+	if ok {
+		delete(c.m, id)
+	}
+	return r
+}
+
+func (c *CacheMountImports) restore(id string, r *solver.Remote) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.m[id]; !ok {
+		c.m[id] = r
+	}
+}
+
+// applyCacheMountImport extracts content imported by the build of the context
+// into a newly created cache mount.
+func applyCacheMountImport(ctx context.Context, mref cache.MutableRef, id string, g session.Group) error {
+	imports := cacheMountImportsOf(ctx)
+	remote := imports.take(id)
+	if remote == nil {
+		return nil
+	}
+	if err := extractCacheMount(ctx, mref, remote, g); err != nil {
+		imports.restore(id, remote)
+		return errors.Wrapf(err, "failed to restore cache mount %s", id)
+	}
+	logrus.Debugf("restored cache mount %s from %s", id, remote.Descriptors[0].Digest)
+	return nil
+}
+
+func extractCacheMount(ctx context.Context, mref cache.MutableRef, remote *solver.Remote, g session.Group) error {
+	desc := remote.Descriptors[0]
+	ra, err := remote.Provider.ReaderAt(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+
+	rc, err := compression.DecompressStream(content.NewReader(ra))
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	m, err := mref.Mount(ctx, false, g)
+	if err != nil {
+		return err
+	}
+	lm := snapshot.LocalMounter(m)
+	dir, err := lm.Mount()
+	if err != nil {
+		return err
+	}
+	defer lm.Unmount()
+
+	_, err = archive.Apply(ctx, dir, rc)
+	return err
+}
+
+// ExportCacheMount writes the content of the cache mount with the given ID to
+// the content store as a gzip compressed tar blob. It returns nil if the cache
+// mount does not exist or is currently in use. The blob is protected by a
+// lease until the returned release function is called.
+func ExportCacheMount(ctx context.Context, cm cache.Manager, md *metadata.Store, cs content.Store, lm leases.Manager, id string, g session.Group) (_ *solver.Remote, _ func(context.Context) error, retErr error) {
+	mref, err := cacheMountRef(ctx, cm, md, id)
+	if err != nil || mref == nil {
+		return nil, nil, err
+	}
+	defer mref.Release(context.TODO())
+
+	ctx, done, err := leaseutil.WithLease(ctx, lm, leaseutil.MakeTemporary)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			done(context.TODO())
+		}
+	}()
+
+	m, err := mref.Mount(ctx, true, g)
+	if err != nil {
+		return nil, nil, err
+	}
+	mounter := snapshot.LocalMounter(m)
+	dir, err := mounter.Mount()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer mounter.Unmount()
+
+	w, err := content.OpenWriter(ctx, cs, content.WithRef("cache-mount-"+identity.NewID()))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer w.Close()
+
+	gz := gzip.NewWriter(w)
+	if err := archive.WriteDiff(ctx, gz, "", dir); err != nil {
+		return nil, nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	st, err := w.Status()
+	if err != nil {
+		return nil, nil, err
+	}
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayerGzip,
+		Digest:    w.Digest(),
+		Size:      st.Offset,
+		Annotations: map[string]string{
+			"buildkit/cache-mount": id,
+		},
+	}
+	if err := w.Commit(ctx, desc.Size, desc.Digest); err != nil && !errdefs.IsAlreadyExists(err) {
+		return nil, nil, err
+	}
+	return &solver.Remote{
+		Descriptors: []ocispec.Descriptor{desc},
+		Provider:    cs,
+	}, done, nil
+}
+
+// cacheMountRef returns the ref of the cache mount with the given ID or nil if
+// the cache mount does not exist or is currently in use. The ref is held
+// exclusively until it is released so it can be read without holding the
+// cache mount lock.
+func cacheMountRef(ctx context.Context, cm cache.Manager, md *metadata.Store, id string) (cache.MutableRef, error) {
+	key := "cache-dir:" + id
+
+	cacheRefsLocker.Lock(key)
+	defer cacheRefsLocker.Unlock(key)
+
+	sis, err := md.Search(key)
+	if err != nil {
+		return nil, err
+	}
+	for _, si := range sis {
+		mref, err := cm.GetMutable(ctx, si.ID(), cache.WithBuildRef(solver.BuildRefOf(ctx)))
+		if err == nil {
+			return mref, nil
+		}
+		if errors.Is(err, cache.ErrLocked) {
+			logrus.Warnf("skipping export of cache mount %s: currently in use", id)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,107 @@
+package ops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/moby/buildkit/cache"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/llbsolver"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/worker"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+const diffCacheType = "buildkit.diff.v0"
+
+type diffOp struct {
+	op *pb.DiffOp
+	w  worker.Worker
+}
+
+func NewDiffOp(v solver.Vertex, op *pb.Op_Diff, w worker.Worker) (solver.Op, error) {
+	if err := llbsolver.ValidateOp(&pb.Op{Op: op}); err != nil {
+		return nil, err
+	}
+	return &diffOp{
+		op: op.Diff,
+		w:  w,
+	}, nil
+}
+
+func (d *diffOp) CacheMap(ctx context.Context, group session.Group, index int) (*solver.CacheMap, bool, error) {
+	dt, err := json.Marshal(struct {
+		Type string
+		Diff *pb.DiffOp
+	}{
+		Type: diffCacheType,
+		Diff: d.op,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var depCount int
+	if d.op.Lower.Input != pb.Empty {
+		depCount++
+	}
+	if d.op.Upper.Input != pb.Empty {
+		depCount++
+	}
+
+	cm := &solver.CacheMap{
+		Digest: digest.FromBytes(dt),
+		Deps: make([]struct {
+			Selector          digest.Digest
+			ComputeDigestFunc solver.ResultBasedCacheFunc
+			PreprocessFunc    solver.PreprocessFunc
+		}, depCount),
+	}
+
+	return cm, true, nil
+}
+
+func (d *diffOp) Exec(ctx context.Context, g session.Group, inputs []solver.Result) ([]solver.Result, error) {
+	lower, err := d.inputRef(d.op.Lower.Input, inputs)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := d.inputRef(d.op.Upper.Input, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	lowerID, upperID := "scratch", "scratch"
+	if lower != nil {
+		lowerID = lower.ID()
+	}
+	if upper != nil {
+		upperID = upper.ID()
+	}
+
+	ref, err := d.w.CacheManager().Diff(ctx, lower, upper, g, cache.WithDescription(fmt.Sprintf("diff %s -> %s", lowerID, upperID)))
+	if err != nil {
+		return nil, err
+	}
+	return []solver.Result{worker.NewWorkerRefResult(ref, d.w)}, nil
+}
+
+func (d *diffOp) inputRef(idx pb.InputIndex, inputs []solver.Result) (cache.ImmutableRef, error) {
+	if idx == pb.Empty {
+		return nil, nil
+	}
+	if int(idx) >= len(inputs) {
+		return nil, errors.Errorf("invalid diff input %d", idx)
+	}
+	if inputs[idx] == nil {
+		return nil, nil
+	}
+	wref, ok := inputs[idx].Sys().(*worker.WorkerRef)
+	if !ok {
+		return nil, errors.Errorf("invalid reference for diff %T", inputs[idx].Sys())
+	}
+	return wref.ImmutableRef, nil
+}
@@ -14,51 +14,64 @@ import (
 	"github.com/containerd/containerd/platforms"
 	"github.com/moby/buildkit/cache"
 	"github.com/moby/buildkit/cache/metadata"
+	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/executor"
 	"github.com/moby/buildkit/frontend/gateway"
+	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/solver"
 	"github.com/moby/buildkit/solver/llbsolver"
 	"github.com/moby/buildkit/solver/llbsolver/errdefs"
 	"github.com/moby/buildkit/solver/llbsolver/mounts"
 	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/util/progress"
 	"github.com/moby/buildkit/util/progress/logs"
 	utilsystem "github.com/moby/buildkit/util/system"
 	"github.com/moby/buildkit/worker"
 	digest "github.com/opencontainers/go-digest"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/semaphore"
 )
 
 const execCacheType = "buildkit.exec.v0"
 
 type execOp struct {
-	op        *pb.ExecOp
-	cm        cache.Manager
-	mm        *mounts.MountManager
-	exec      executor.Executor
-	w         worker.Worker
-	platform  *pb.Platform
-	numInputs int
+	op          *pb.ExecOp
+	cm          cache.Manager
+	mm          *mounts.MountManager
+	exec        executor.Executor
+	w           worker.Worker
+	platform    *pb.Platform
+	numInputs   int
+	parallelism *semaphore.Weighted
 }
 
-func NewExecOp(v solver.Vertex, op *pb.Op_Exec, platform *pb.Platform, cm cache.Manager, sm *session.Manager, md *metadata.Store, exec executor.Executor, w worker.Worker) (solver.Op, error) {
+func NewExecOp(v solver.Vertex, op *pb.Op_Exec, platform *pb.Platform, cm cache.Manager, sm *session.Manager, md *metadata.Store, exec executor.Executor, parallelism *semaphore.Weighted, w worker.Worker) (solver.Op, error) {
 	if err := llbsolver.ValidateOp(&pb.Op{Op: op}); err != nil {
 		return nil, err
 	}
 	name := fmt.Sprintf("exec %s", strings.Join(op.Exec.Meta.Args, " "))
 	return &execOp{
-		op:        op.Exec,
-		mm:        mounts.NewMountManager(name, cm, sm, md),
-		cm:        cm,
-		exec:      exec,
-		numInputs: len(v.Inputs()),
-		w:         w,
-		platform:  platform,
+		op:          op.Exec,
+		mm:          mounts.NewMountManager(name, cm, sm, md),
+		cm:          cm,
+		exec:        exec,
+		numInputs:   len(v.Inputs()),
+		w:           w,
+		platform:    platform,
+		parallelism: parallelism,
 	}, nil
 }
 
+// Semaphores limit the exec steps running at the same time in the build and
+// in the worker.
+func (e *execOp) Semaphores(ctx context.Context) []*semaphore.Weighted {
+	return []*semaphore.Weighted{llbsolver.ParallelismOf(ctx), e.parallelism}
+}
+
 func cloneExecOp(old *pb.ExecOp) pb.ExecOp {
 	n := *old
 	meta := *n.Meta
@@ -224,9 +237,13 @@ func (e *execOp) Exec(ctx context.Context, g session.Group, inputs []solver.Resu
 		}
 	}
 
+	// cache mounts created by the exec are restored from the content
+	// imported by the build
+	ctx = mounts.WithCacheMountImports(ctx, llbsolver.CacheMountImportsOf(ctx))
+
 	p, err := gateway.PrepareMounts(ctx, e.mm, e.cm, g, e.op.Mounts, refs, func(m *pb.Mount, ref cache.ImmutableRef) (cache.MutableRef, error) {
 		desc := fmt.Sprintf("mount %s from exec %s", m.Dest, strings.Join(e.op.Meta.Args, " "))
-		return e.cm.New(ctx, ref, g, cache.WithDescription(desc))
+		return e.cm.New(ctx, ref, g, cache.WithDescription(desc), cache.WithBuildRef(solver.BuildRefOf(ctx)), cache.WithFrontend(solver.BuildFrontendOf(ctx)))
 	})
 	defer func() {
 		if err != nil {
@@ -296,6 +313,11 @@ func (e *execOp) Exec(ctx context.Context, g session.Group, inputs []solver.Resu
 		ExtraHosts:     extraHosts,
 		NetMode:        e.op.Network,
 		SecurityMode:   e.op.Security,
+		Ulimit:         e.op.Meta.Ulimit,
+		ResourceLimits: e.op.Meta.Resources,
+		Devices:        e.op.Devices,
+		CgroupParent:   llbsolver.CgroupParentOf(ctx),
+		BuildRef:       solver.BuildRefOf(ctx),
 	}
 
 	if e.op.Meta.ProxyEnv != nil {
@@ -316,6 +338,9 @@ func (e *execOp) Exec(ctx context.Context, g session.Group, inputs []solver.Resu
 		Stdin:  nil,
 		Stdout: stdout,
 		Stderr: stderr,
+		ResourceUsage: func(u executor.ResourceUsage) {
+			reportResourceUsage(ctx, u)
+		},
 	}, nil)
 
 	for i, out := range p.OutputRefs {
@@ -334,6 +359,27 @@ func (e *execOp) Exec(ctx context.Context, g session.Group, inputs []solver.Resu
 	return results, errors.Wrapf(execErr, "executor failed running %v", e.op.Meta.Args)
 }
 
+// reportResourceUsage sends the resource usage of the exec to the progress
+// stream of the vertex and records it in the trace span of the vertex.
+func reportResourceUsage(ctx context.Context, u executor.ResourceUsage) {
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span.SetTag("resources.cpu_nanos", int64(u.CPUTime))
+		span.SetTag("resources.memory_peak", u.MemoryPeak)
+		span.SetTag("resources.io_read_bytes", u.IOReadBytes)
+		span.SetTag("resources.io_write_bytes", u.IOWriteBytes)
+		span.SetTag("resources.pids_peak", u.PidsPeak)
+	}
+	pw, _, _ := progress.FromContext(ctx)
+	defer pw.Close()
+	pw.Write(identity.NewID(), client.VertexResourceUsage{
+		CPUTime:      u.CPUTime,
+		MemoryPeak:   u.MemoryPeak,
+		IOReadBytes:  u.IOReadBytes,
+		IOWriteBytes: u.IOWriteBytes,
+		PidsPeak:     u.PidsPeak,
+	})
+}
+
 func proxyEnvList(p *pb.ProxyEnv) []string {
 	out := []string{}
 	if v := p.HttpProxy; v != "" {
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"reflect"
 	"runtime"
 	"sort"
 	"sync"
@@ -50,7 +51,7 @@ func NewFileOp(v solver.Vertex, op *pb.Op_File, cm cache.Manager, md *metadata.S
 }
 
 func (f *fileOp) CacheMap(ctx context.Context, g session.Group, index int) (*solver.CacheMap, bool, error) {
-	selectors := map[int]map[llbsolver.Selector]struct{}{}
+	selectors := map[int][]llbsolver.Selector{}
 	invalidSelectors := map[int]struct{}{}
 
 	actions := make([][]byte, 0, len(f.op.Actions))
@@ -93,7 +94,7 @@ func (f *fileOp) CacheMap(ctx context.Context, g session.Group, index int) (*sol
 			markInvalid(action.Input)
 			processOwner(p.Owner, selectors)
 			if action.SecondaryInput != -1 && int(action.SecondaryInput) < f.numInputs {
-				addSelector(selectors, int(action.SecondaryInput), p.Src, p.AllowWildcard, p.FollowSymlink)
+				addSelector(selectors, int(action.SecondaryInput), p.Src, p.AllowWildcard, p.FollowSymlink, p.IncludePatterns, p.ExcludePatterns)
 				p.Src = path.Base(p.Src)
 			}
 			dt, err = json.Marshal(p)
@@ -130,8 +131,8 @@ func (f *fileOp) CacheMap(ctx context.Context, g session.Group, index int) (*sol
 			continue
 		}
 		dgsts := make([][]byte, 0, len(m))
-		for k := range m {
-			dgsts = append(dgsts, []byte(k.Path))
+		for _, k := range m {
+			dgsts = append(dgsts, selectorKey(k))
 		}
 		sort.Slice(dgsts, func(i, j int) bool {
 			return bytes.Compare(dgsts[i], dgsts[j]) > 0
@@ -170,13 +171,12 @@ func (f *fileOp) Exec(ctx context.Context, g session.Group, inputs []solver.Resu
 	return outResults, nil
 }
 
-func addSelector(m map[int]map[llbsolver.Selector]struct{}, idx int, sel string, wildcard, followLinks bool) {
-	mm, ok := m[idx]
-	if !ok {
-		mm = map[llbsolver.Selector]struct{}{}
-		m[idx] = mm
+func addSelector(m map[int][]llbsolver.Selector, idx int, sel string, wildcard, followLinks bool, include, exclude []string) {
+	s := llbsolver.Selector{
+		Path:            sel,
+		IncludePatterns: include,
+		ExcludePatterns: exclude,
 	}
-	s := llbsolver.Selector{Path: sel}
 
 	if wildcard && containsWildcards(sel) {
 		s.Wildcard = true
@@ -184,7 +184,22 @@ func addSelector(m map[int]map[llbsolver.Selector]struct{}, idx int, sel string,
 	if followLinks {
 		s.FollowLinks = true
 	}
-	mm[s] = struct{}{}
+	for _, s2 := range m[idx] {
+		if reflect.DeepEqual(s, s2) {
+			return
+		}
+	}
+	m[idx] = append(m[idx], s)
+}
+
+// selectorKey returns the path of the selector with its patterns, so copies
+// of the same path with different patterns have different cache keys.
+func selectorKey(sel llbsolver.Selector) []byte {
+	if !sel.HasFilter() {
+		return []byte(sel.Path)
+	}
+	dt, _ := json.Marshal(sel) // error is impossible for strings and bools
+	return dt
 }
 
 func containsWildcards(name string) bool {
@@ -200,11 +215,11 @@ func containsWildcards(name string) bool {
 	return false
 }
 
-func dedupeSelectors(m map[llbsolver.Selector]struct{}) []llbsolver.Selector {
+func dedupeSelectors(m []llbsolver.Selector) []llbsolver.Selector {
 	paths := make([]string, 0, len(m))
 	pathsFollow := make([]string, 0, len(m))
-	for sel := range m {
-		if !sel.Wildcard {
+	for _, sel := range m {
+		if !sel.Wildcard && !sel.HasFilter() {
 			if sel.FollowLinks {
 				pathsFollow = append(pathsFollow, sel.Path)
 			} else {
@@ -223,8 +238,8 @@ func dedupeSelectors(m map[llbsolver.Selector]struct{}) []llbsolver.Selector {
 		selectors = append(selectors, llbsolver.Selector{Path: p, FollowLinks: true})
 	}
 
-	for sel := range m {
-		if sel.Wildcard {
+	for _, sel := range m {
+		if sel.Wildcard || sel.HasFilter() {
 			selectors = append(selectors, sel)
 		}
 	}
@@ -236,7 +251,7 @@ func dedupeSelectors(m map[llbsolver.Selector]struct{}) []llbsolver.Selector {
 	return selectors
 }
 
-func processOwner(chopt *pb.ChownOpt, selectors map[int]map[llbsolver.Selector]struct{}) error {
+func processOwner(chopt *pb.ChownOpt, selectors map[int][]llbsolver.Selector) error {
 	if chopt == nil {
 		return nil
 	}
@@ -245,7 +260,7 @@ func processOwner(chopt *pb.ChownOpt, selectors map[int]map[llbsolver.Selector]s
 			if u.ByName.Input < 0 {
 				return errors.Errorf("invalid user index %d", u.ByName.Input)
 			}
-			addSelector(selectors, int(u.ByName.Input), "/etc/passwd", false, true)
+			addSelector(selectors, int(u.ByName.Input), "/etc/passwd", false, true, nil, nil)
 		}
 	}
 	if chopt.Group != nil {
@@ -253,7 +268,7 @@ func processOwner(chopt *pb.ChownOpt, selectors map[int]map[llbsolver.Selector]s
 			if u.ByName.Input < 0 {
 				return errors.Errorf("invalid user index %d", u.ByName.Input)
 			}
-			addSelector(selectors, int(u.ByName.Input), "/etc/group", false, true)
+			addSelector(selectors, int(u.ByName.Input), "/etc/group", false, true, nil, nil)
 		}
 	}
 	return nil
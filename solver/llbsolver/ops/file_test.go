@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/solver/llbsolver"
 	"github.com/moby/buildkit/solver/llbsolver/ops/fileoptypes"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/pkg/errors"
@@ -57,6 +58,26 @@ func TestMkdirMkfile(t *testing.T) {
 	require.Equal(t, fo.Actions[1].Action.(*pb.FileAction_Mkfile).Mkfile, o.mount.chain[1].mkfile)
 }
 
+func TestSelectorsFilter(t *testing.T) {
+	t.Parallel()
+
+	m := map[int][]llbsolver.Selector{}
+	addSelector(m, 0, "/a", false, false, nil, nil)
+	addSelector(m, 0, "/a/b", false, false, nil, nil)
+	addSelector(m, 0, "/a", false, false, nil, []string{"*.go"})
+	addSelector(m, 0, "/a", false, false, nil, []string{"*.go"})
+	require.Equal(t, 3, len(m[0]))
+
+	// filtered selectors are not merged into their parent paths
+	require.Equal(t, []llbsolver.Selector{
+		{Path: "/a"},
+		{Path: "/a", ExcludePatterns: []string{"*.go"}},
+	}, dedupeSelectors(m[0]))
+
+	require.Equal(t, "/a", string(selectorKey(m[0][0])))
+	require.NotEqual(t, selectorKey(m[0][0]), selectorKey(m[0][2]))
+}
+
 func TestChownOpt(t *testing.T) {
 	t.Parallel()
 	fo := &pb.FileOp{
@@ -0,0 +1,84 @@
+package ops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/moby/buildkit/cache"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/llbsolver"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/worker"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+const mergeCacheType = "buildkit.merge.v0"
+
+type mergeOp struct {
+	op *pb.MergeOp
+	w  worker.Worker
+}
+
+func NewMergeOp(v solver.Vertex, op *pb.Op_Merge, w worker.Worker) (solver.Op, error) {
+	if err := llbsolver.ValidateOp(&pb.Op{Op: op}); err != nil {
+		return nil, err
+	}
+	return &mergeOp{
+		op: op.Merge,
+		w:  w,
+	}, nil
+}
+
+func (m *mergeOp) CacheMap(ctx context.Context, group session.Group, index int) (*solver.CacheMap, bool, error) {
+	dt, err := json.Marshal(struct {
+		Type  string
+		Merge *pb.MergeOp
+	}{
+		Type:  mergeCacheType,
+		Merge: m.op,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	cm := &solver.CacheMap{
+		Digest: digest.FromBytes(dt),
+		Deps: make([]struct {
+			Selector          digest.Digest
+			ComputeDigestFunc solver.ResultBasedCacheFunc
+			PreprocessFunc    solver.PreprocessFunc
+		}, len(m.op.Inputs)),
+	}
+
+	return cm, true, nil
+}
+
+func (m *mergeOp) Exec(ctx context.Context, g session.Group, inputs []solver.Result) ([]solver.Result, error) {
+	refs := make([]cache.ImmutableRef, len(m.op.Inputs))
+	ids := make([]string, 0, len(m.op.Inputs))
+	for i, inp := range m.op.Inputs {
+		if int(inp.Input) >= len(inputs) {
+			return nil, errors.Errorf("invalid merge input %d", inp.Input)
+		}
+		if inputs[inp.Input] == nil {
+			continue
+		}
+		wref, ok := inputs[inp.Input].Sys().(*worker.WorkerRef)
+		if !ok {
+			return nil, errors.Errorf("invalid reference for merge %T", inputs[inp.Input].Sys())
+		}
+		refs[i] = wref.ImmutableRef
+		if wref.ImmutableRef != nil {
+			ids = append(ids, wref.ImmutableRef.ID())
+		}
+	}
+
+	ref, err := m.w.CacheManager().Merge(ctx, refs, g, cache.WithDescription(fmt.Sprintf("merge %v", ids)))
+	if err != nil {
+		return nil, err
+	}
+	return []solver.Result{worker.NewWorkerRefResult(ref, m.w)}, nil
+}
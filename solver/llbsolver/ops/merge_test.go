@@ -0,0 +1,106 @@
+package ops
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/buildkit/cache"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/worker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeOpCacheMap(t *testing.T) {
+	t.Parallel()
+
+	op := func(inputs ...int64) *mergeOp {
+		m := &pb.MergeOp{}
+		for _, i := range inputs {
+			m.Inputs = append(m.Inputs, &pb.MergeInput{Input: pb.InputIndex(i)})
+		}
+		return &mergeOp{op: m}
+	}
+
+	cm1, _, err := op(0, 1).CacheMap(context.TODO(), nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(cm1.Deps))
+
+	cm2, _, err := op(0, 1).CacheMap(context.TODO(), nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, cm1.Digest, cm2.Digest)
+
+	// the order of the inputs changes the result
+	cm3, _, err := op(1, 0).CacheMap(context.TODO(), nil, 0)
+	require.NoError(t, err)
+	require.NotEqual(t, cm1.Digest, cm3.Digest)
+}
+
+func TestMergeOpExec(t *testing.T) {
+	t.Parallel()
+
+	cm := &testMergeManager{}
+	w := &testMergeWorker{cm: cm}
+	m := &mergeOp{
+		op: &pb.MergeOp{Inputs: []*pb.MergeInput{
+			{Input: 1},
+			{Input: 2},
+			{Input: 0},
+		}},
+		w: w,
+	}
+
+	inputs := []solver.Result{
+		worker.NewWorkerRefResult(&testMergeRef{id: "a"}, w),
+		worker.NewWorkerRefResult(nil, w),
+		worker.NewWorkerRefResult(&testMergeRef{id: "c"}, w),
+	}
+	res, err := m.Exec(context.TODO(), nil, inputs)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res))
+	require.Equal(t, "test::merged", res[0].ID())
+
+	// inputs are passed in the order of the merge, scratch inputs as nil
+	require.Equal(t, 3, len(cm.inputs))
+	require.Nil(t, cm.inputs[0])
+	require.Equal(t, "c", cm.inputs[1].ID())
+	require.Equal(t, "a", cm.inputs[2].ID())
+
+	m.op.Inputs = append(m.op.Inputs, &pb.MergeInput{Input: 3})
+	_, err = m.Exec(context.TODO(), nil, inputs)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid merge input 3")
+}
+
+type testMergeWorker struct {
+	worker.Worker
+	cm cache.Manager
+}
+
+func (w *testMergeWorker) ID() string {
+	return "test"
+}
+
+func (w *testMergeWorker) CacheManager() cache.Manager {
+	return w.cm
+}
+
+type testMergeManager struct {
+	cache.Manager
+	inputs []cache.ImmutableRef
+}
+
+func (cm *testMergeManager) Merge(ctx context.Context, inputs []cache.ImmutableRef, s session.Group, opts ...cache.RefOption) (cache.ImmutableRef, error) {
+	cm.inputs = inputs
+	return &testMergeRef{id: "merged"}, nil
+}
+
+type testMergeRef struct {
+	cache.ImmutableRef
+	id string
+}
+
+func (r *testMergeRef) ID() string {
+	return r.id
+}
@@ -19,6 +19,15 @@ type Selector struct {
 	Path        string
 	Wildcard    bool
 	FollowLinks bool
+	// IncludePatterns and ExcludePatterns select the files of Path that are
+	// part of the checksum, like in a filtered copy
+	IncludePatterns []string
+	ExcludePatterns []string
+}
+
+// HasFilter returns true if the selector has include or exclude patterns.
+func (sel Selector) HasFilter() bool {
+	return len(sel.IncludePatterns) > 0 || len(sel.ExcludePatterns) > 0
 }
 
 func UnlazyResultFunc(ctx context.Context, res solver.Result, g session.Group) error {
@@ -50,7 +59,18 @@ func NewContentHashFunc(selectors []Selector) solver.ResultBasedCacheFunc {
 		for i, sel := range selectors {
 			i, sel := i, sel
 			eg.Go(func() error {
-				if !sel.Wildcard {
+				if sel.HasFilter() {
+					dgst, err := contenthash.ChecksumFiltered(ctx, ref.ImmutableRef, path.Join("/", sel.Path), contenthash.FilterOpts{
+						IncludePatterns: sel.IncludePatterns,
+						ExcludePatterns: sel.ExcludePatterns,
+						Wildcard:        sel.Wildcard,
+						FollowLinks:     sel.FollowLinks,
+					}, s)
+					if err != nil {
+						return err
+					}
+					dgsts[i] = []byte(dgst)
+				} else if !sel.Wildcard {
 					dgst, err := contenthash.Checksum(ctx, ref.ImmutableRef, path.Join("/", sel.Path), sel.FollowLinks, s)
 					if err != nil {
 						return err
@@ -16,21 +16,41 @@ import (
 	"github.com/moby/buildkit/frontend/gateway"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/llbsolver/mounts"
 	"github.com/moby/buildkit/util/compression"
 	"github.com/moby/buildkit/util/entitlements"
 	"github.com/moby/buildkit/util/progress"
 	"github.com/moby/buildkit/worker"
 	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 )
 
-const keyEntitlements = "llb.entitlements"
+const (
+	keyEntitlements = "llb.entitlements"
+	keyCgroupParent = "llb.cgroupparent"
+	keyParallelism  = "llb.parallelism"
+
+	keyCacheMountImports = "llb.cachemountimports"
+)
+
+// JobOpt are the options of a build that apply to all of its steps
+type JobOpt struct {
+	// CgroupParent overrides the cgroup parent of the worker
+	CgroupParent string
+	// MaxParallelism limits the exec steps of the build running at the same
+	// time. 0 is unlimited.
+	MaxParallelism int
+}
 
 type ExporterRequest struct {
 	Exporter        exporter.ExporterInstance
 	CacheExporter   remotecache.Exporter
 	CacheExportMode solver.CacheExportMode
+	// CacheMounts are IDs of cache mounts exported with the cache
+	CacheMounts []string
 }
 
 // ResolveWorkerFunc returns default worker for the temporary default non-distributed use cases
@@ -89,7 +109,7 @@ func (s *Solver) Bridge(b solver.Builder) frontend.FrontendLLBBridge {
 	}
 }
 
-func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req frontend.SolveRequest, exp ExporterRequest, ent []entitlements.Entitlement) (*client.SolveResponse, error) {
+func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req frontend.SolveRequest, exp ExporterRequest, ent []entitlements.Entitlement, jobOpt JobOpt) (*client.SolveResponse, error) {
 	j, err := s.solver.NewJob(id)
 	if err != nil {
 		return nil, err
@@ -102,6 +122,16 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 		return nil, err
 	}
 	j.SetValue(keyEntitlements, set)
+	if jobOpt.CgroupParent != "" {
+		j.SetValue(keyCgroupParent, jobOpt.CgroupParent)
+	}
+	if jobOpt.MaxParallelism > 0 {
+		j.SetValue(keyParallelism, semaphore.NewWeighted(int64(jobOpt.MaxParallelism)))
+	}
+	cacheMountImports := mounts.NewCacheMountImports()
+	defer cacheMountImports.Release()
+	j.SetValue(keyCacheMountImports, cacheMountImports)
+	j.SetFrontend(req.Frontend)
 
 	j.SessionID = sessionID
 
@@ -234,6 +264,11 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 				return prepareDone(err)
 			}
 			prepareDone(nil)
+			release, err := s.exportCacheMounts(ctx, e, exp.CacheMounts, g)
+			defer release()
+			if err != nil {
+				return err
+			}
 			cacheExporterResponse, err = e.Finalize(ctx)
 			return err
 		}); err != nil {
@@ -261,6 +296,45 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 	}, nil
 }
 
+// exportCacheMounts adds the content of the cache mounts to the cache
+// exporter. The returned function releases the content after the exporter has
+// been finalized.
+func (s *Solver) exportCacheMounts(ctx context.Context, e remotecache.Exporter, ids []string, g session.Group) (func(), error) {
+	var releasers []func(context.Context) error
+	release := func() {
+		for _, r := range releasers {
+			if err := r(context.TODO()); err != nil {
+				logrus.Warnf("failed to release cache mount export: %v", err)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return release, nil
+	}
+	w, err := s.resolveWorker()
+	if err != nil {
+		return release, err
+	}
+	for _, id := range ids {
+		done := oneOffProgress(ctx, fmt.Sprintf("preparing cache mount %s for export", id))
+		remote, rel, err := w.ExportCacheMount(ctx, id, g)
+		if err != nil {
+			return release, done(err)
+		}
+		if remote == nil {
+			logrus.Warnf("cache mount %s not found, skipping export", id)
+			done(nil)
+			continue
+		}
+		releasers = append(releasers, rel)
+		if err := e.AddCacheMount(id, remote); err != nil {
+			return release, done(err)
+		}
+		done(nil)
+	}
+	return release, nil
+}
+
 func inlineCache(ctx context.Context, e remotecache.Exporter, res solver.CachedResult, g session.Group) ([]byte, error) {
 	if efl, ok := e.(interface {
 		ExportForLayers([]digest.Digest) ([]byte, error)
@@ -302,6 +376,11 @@ func (s *Solver) Status(ctx context.Context, id string, statusChan chan *client.
 	return j.Status(ctx, statusChan)
 }
 
+// Jobs returns the builds that are currently running
+func (s *Solver) Jobs() []solver.JobInfo {
+	return s.solver.Jobs()
+}
+
 func defaultResolver(wc *worker.Controller) ResolveWorkerFunc {
 	return func() (worker.Worker, error) {
 		return wc.GetDefault()
@@ -391,10 +470,50 @@ func supportedEntitlements(ents []string) []entitlements.Entitlement {
 		if e == string(entitlements.EntitlementSecurityInsecure) {
 			out = append(out, entitlements.EntitlementSecurityInsecure)
 		}
+		if e == string(entitlements.EntitlementDevice) {
+			out = append(out, entitlements.EntitlementDevice)
+		}
+		if e == string(entitlements.EntitlementCgroupParent) {
+			out = append(out, entitlements.EntitlementCgroupParent)
+		}
 	}
 	return out
 }
 
+// CgroupParentOf returns the cgroup parent requested for the build that the
+// vertex being executed belongs to.
+func CgroupParentOf(ctx context.Context) string {
+	if v, ok := solver.BuildValueOf(ctx, keyCgroupParent); ok {
+		if parent, ok := v.(string); ok {
+			return parent
+		}
+	}
+	return ""
+}
+
+// CacheMountImportsOf returns the cache mount content imported by the build
+// that the vertex being executed belongs to.
+func CacheMountImportsOf(ctx context.Context) *mounts.CacheMountImports {
+	if v, ok := solver.BuildValueOf(ctx, keyCacheMountImports); ok {
+		if c, ok := v.(*mounts.CacheMountImports); ok {
+			return c
+		}
+	}
+	return nil
+}
+
+// ParallelismOf returns the semaphore limiting the exec steps of the build
+// that the vertex being executed belongs to. It is nil if the build isn't
+// limited.
+func ParallelismOf(ctx context.Context) *semaphore.Weighted {
+	if v, ok := solver.BuildValueOf(ctx, keyParallelism); ok {
+		if sem, ok := v.(*semaphore.Weighted); ok {
+			return sem
+		}
+	}
+	return nil
+}
+
 func loadEntitlements(b solver.Builder) (entitlements.Set, error) {
 	var ent entitlements.Set = map[entitlements.Entitlement]struct{}{}
 	err := b.EachValue(context.TODO(), keyEntitlements, func(v interface{}) error {
@@ -412,3 +531,37 @@ func loadEntitlements(b solver.Builder) (entitlements.Set, error) {
 	}
 	return ent, nil
 }
+
+func loadCacheMountImports(b solver.Builder) (*mounts.CacheMountImports, error) {
+	var imports *mounts.CacheMountImports
+	err := b.EachValue(context.TODO(), keyCacheMountImports, func(v interface{}) error {
+		c, ok := v.(*mounts.CacheMountImports)
+		if !ok {
+			return errors.Errorf("invalid cache mount imports %T", v)
+		}
+		if imports == nil {
+			imports = c
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return imports, nil
+}
+
+func loadCgroupParent(b solver.Builder) (string, error) {
+	var parent string
+	err := b.EachValue(context.TODO(), keyCgroupParent, func(v interface{}) error {
+		p, ok := v.(string)
+		if !ok {
+			return errors.Errorf("invalid cgroup parent %T", v)
+		}
+		parent = p
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return parent, nil
+}
@@ -95,7 +95,10 @@ func NormalizeRuntimePlatforms() LoadOpt {
 	}
 }
 
-func ValidateEntitlements(ent entitlements.Set) LoadOpt {
+// ValidateEntitlements returns an error if an op uses a feature the
+// entitlements don't allow. cgroupParent is the cgroup parent requested for
+// the build, overriding it requires the cgroup.parent entitlement.
+func ValidateEntitlements(ent entitlements.Set, cgroupParent string) LoadOpt {
 	return func(op *pb.Op, _ *pb.OpMetadata, opt *solver.VertexOptions) error {
 		switch op := op.Op.(type) {
 		case *pb.Op_Exec:
@@ -110,6 +113,18 @@ func ValidateEntitlements(ent entitlements.Set) LoadOpt {
 					return errors.Errorf("%s is not allowed", entitlements.EntitlementSecurityInsecure)
 				}
 			}
+
+			if len(op.Exec.Devices) > 0 {
+				if !ent.Allowed(entitlements.EntitlementDevice) {
+					return errors.Errorf("%s is not allowed", entitlements.EntitlementDevice)
+				}
+			}
+
+			if cgroupParent != "" {
+				if !ent.Allowed(entitlements.EntitlementCgroupParent) {
+					return errors.Errorf("%s is not allowed", entitlements.EntitlementCgroupParent)
+				}
+			}
 		}
 		return nil
 	}
@@ -259,6 +274,10 @@ func llbOpName(op *pb.Op) string {
 		return fileOpName(op.File.Actions)
 	case *pb.Op_Build:
 		return "build"
+	case *pb.Op_Merge:
+		return "merge"
+	case *pb.Op_Diff:
+		return "diff"
 	default:
 		return "unknown"
 	}
@@ -309,6 +328,20 @@ func ValidateOp(op *pb.Op) error {
 		if op.Build == nil {
 			return errors.Errorf("invalid nil build op")
 		}
+	case *pb.Op_Merge:
+		if op.Merge == nil {
+			return errors.Errorf("invalid nil merge op")
+		}
+		if len(op.Merge.Inputs) == 0 {
+			return errors.Errorf("invalid merge op with no inputs")
+		}
+	case *pb.Op_Diff:
+		if op.Diff == nil {
+			return errors.Errorf("invalid nil diff op")
+		}
+		if op.Diff.Lower == nil || op.Diff.Upper == nil {
+			return errors.Errorf("invalid diff op with missing lower or upper input")
+		}
 	}
 	return nil
 }
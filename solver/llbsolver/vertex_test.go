@@ -0,0 +1,33 @@
+package llbsolver
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/util/entitlements"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEntitlementsCgroupParent(t *testing.T) {
+	t.Parallel()
+
+	exec := &pb.Op{Op: &pb.Op_Exec{Exec: &pb.ExecOp{Meta: &pb.Meta{Args: []string{"true"}}}}}
+	source := &pb.Op{Op: &pb.Op_Source{Source: &pb.SourceOp{Identifier: "docker-image://busybox"}}}
+
+	none := entitlements.Set{}
+	allowed := entitlements.Set{entitlements.EntitlementCgroupParent: {}}
+
+	// no override
+	require.NoError(t, ValidateEntitlements(none, "")(exec, nil, nil))
+
+	// override without the entitlement
+	err := ValidateEntitlements(none, "/other")(exec, nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cgroup.parent is not allowed")
+
+	// override with the entitlement
+	require.NoError(t, ValidateEntitlements(allowed, "/other")(exec, nil, nil))
+
+	// only exec ops run in the cgroup
+	require.NoError(t, ValidateEntitlements(none, "/other")(source, nil, nil))
+}
@@ -0,0 +1,51 @@
+package pb
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const defaultDevicePermissions = "rwm"
+
+// ValidateDevice checks that the device is an absolute clean path and that
+// its permissions are a combination of r, w and m.
+func ValidateDevice(d *Device) error {
+	if !path.IsAbs(d.Path) || path.Clean(d.Path) != d.Path {
+		return errors.Errorf("invalid device path %q, must be absolute", d.Path)
+	}
+	perms := DevicePermissions(d)
+	for _, c := range perms {
+		if !strings.ContainsRune(defaultDevicePermissions, c) || strings.Count(perms, string(c)) > 1 {
+			return errors.Errorf("invalid permissions %q of device %s", d.Permissions, d.Path)
+		}
+	}
+	return nil
+}
+
+// DevicePermissions returns the cgroup permissions of the device, rwm if
+// unset.
+func DevicePermissions(d *Device) string {
+	if d.Permissions == "" {
+		return defaultDevicePermissions
+	}
+	return d.Permissions
+}
+
+// ParseDevice parses a device in the "path[:permissions]" format. The
+// permissions default to rwm.
+func ParseDevice(val string) (*Device, error) {
+	parts := strings.SplitN(val, ":", 2)
+	d := &Device{Path: parts[0]}
+	if len(parts) == 2 {
+		if parts[1] == "" {
+			return nil, errors.Errorf("invalid device %q, empty permissions", val)
+		}
+		d.Permissions = parts[1]
+	}
+	if err := ValidateDevice(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,23 @@
+package pb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDevice(t *testing.T) {
+	d, err := ParseDevice("/dev/fuse")
+	require.NoError(t, err)
+	require.Equal(t, &Device{Path: "/dev/fuse"}, d)
+	require.Equal(t, "rwm", DevicePermissions(d))
+
+	d, err = ParseDevice("/dev/kvm:rw")
+	require.NoError(t, err)
+	require.Equal(t, &Device{Path: "/dev/kvm", Permissions: "rw"}, d)
+
+	for _, v := range []string{"fuse", "/dev/../fuse", "/dev/fuse:", "/dev/fuse:rx", "/dev/fuse:rr"} {
+		_, err = ParseDevice(v)
+		require.Error(t, err, v)
+	}
+}
@@ -158,6 +158,8 @@ type Op struct {
 	//	*Op_Source
 	//	*Op_File
 	//	*Op_Build
+	//	*Op_Merge
+	//	*Op_Diff
 	Op          isOp_Op            `protobuf_oneof:"op"`
 	Platform    *Platform          `protobuf:"bytes,10,opt,name=platform,proto3" json:"platform,omitempty"`
 	Constraints *WorkerConstraints `protobuf:"bytes,11,opt,name=constraints,proto3" json:"constraints,omitempty"`
@@ -210,11 +212,19 @@ type Op_File struct {
 type Op_Build struct {
 	Build *BuildOp `protobuf:"bytes,5,opt,name=build,proto3,oneof" json:"build,omitempty"`
 }
+type Op_Merge struct {
+	Merge *MergeOp `protobuf:"bytes,6,opt,name=merge,proto3,oneof" json:"merge,omitempty"`
+}
+type Op_Diff struct {
+	Diff *DiffOp `protobuf:"bytes,7,opt,name=diff,proto3,oneof" json:"diff,omitempty"`
+}
 
 func (*Op_Exec) isOp_Op()   {}
 func (*Op_Source) isOp_Op() {}
 func (*Op_File) isOp_Op()   {}
 func (*Op_Build) isOp_Op()  {}
+func (*Op_Merge) isOp_Op()  {}
+func (*Op_Diff) isOp_Op()   {}
 
 func (m *Op) GetOp() isOp_Op {
 	if m != nil {
@@ -258,6 +268,20 @@ func (m *Op) GetBuild() *BuildOp {
 	return nil
 }
 
+func (m *Op) GetMerge() *MergeOp {
+	if x, ok := m.GetOp().(*Op_Merge); ok {
+		return x.Merge
+	}
+	return nil
+}
+
+func (m *Op) GetDiff() *DiffOp {
+	if x, ok := m.GetOp().(*Op_Diff); ok {
+		return x.Diff
+	}
+	return nil
+}
+
 func (m *Op) GetPlatform() *Platform {
 	if m != nil {
 		return m.Platform
@@ -279,6 +303,8 @@ func (*Op) XXX_OneofWrappers() []interface{} {
 		(*Op_Source)(nil),
 		(*Op_File)(nil),
 		(*Op_Build)(nil),
+		(*Op_Merge)(nil),
+		(*Op_Diff)(nil),
 	}
 }
 
@@ -398,6 +424,7 @@ type ExecOp struct {
 	Mounts   []*Mount     `protobuf:"bytes,2,rep,name=mounts,proto3" json:"mounts,omitempty"`
 	Network  NetMode      `protobuf:"varint,3,opt,name=network,proto3,enum=pb.NetMode" json:"network,omitempty"`
 	Security SecurityMode `protobuf:"varint,4,opt,name=security,proto3,enum=pb.SecurityMode" json:"security,omitempty"`
+	Devices  []*Device    `protobuf:"bytes,5,rep,name=devices,proto3" json:"devices,omitempty"`
 }
 
 func (m *ExecOp) Reset()         { *m = ExecOp{} }
@@ -457,17 +484,26 @@ func (m *ExecOp) GetSecurity() SecurityMode {
 	return SecurityMode_SANDBOX
 }
 
+func (m *ExecOp) GetDevices() []*Device {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
 // Meta is a set of arguments for ExecOp.
 // Meta is unrelated to LLB metadata.
 // FIXME: rename (ExecContext? ExecArgs?)
 type Meta struct {
-	Args       []string  `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
-	Env        []string  `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty"`
-	Cwd        string    `protobuf:"bytes,3,opt,name=cwd,proto3" json:"cwd,omitempty"`
-	User       string    `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
-	ProxyEnv   *ProxyEnv `protobuf:"bytes,5,opt,name=proxy_env,json=proxyEnv,proto3" json:"proxy_env,omitempty"`
-	ExtraHosts []*HostIP `protobuf:"bytes,6,rep,name=extraHosts,proto3" json:"extraHosts,omitempty"`
-	Hostname   string    `protobuf:"bytes,7,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Args       []string        `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
+	Env        []string        `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty"`
+	Cwd        string          `protobuf:"bytes,3,opt,name=cwd,proto3" json:"cwd,omitempty"`
+	User       string          `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	ProxyEnv   *ProxyEnv       `protobuf:"bytes,5,opt,name=proxy_env,json=proxyEnv,proto3" json:"proxy_env,omitempty"`
+	ExtraHosts []*HostIP       `protobuf:"bytes,6,rep,name=extraHosts,proto3" json:"extraHosts,omitempty"`
+	Hostname   string          `protobuf:"bytes,7,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Ulimit     []*Ulimit       `protobuf:"bytes,8,rep,name=ulimit,proto3" json:"ulimit,omitempty"`
+	Resources  *ResourceLimits `protobuf:"bytes,9,opt,name=resources,proto3" json:"resources,omitempty"`
 }
 
 func (m *Meta) Reset()         { *m = Meta{} }
@@ -548,6 +584,20 @@ func (m *Meta) GetHostname() string {
 	return ""
 }
 
+func (m *Meta) GetUlimit() []*Ulimit {
+	if m != nil {
+		return m.Ulimit
+	}
+	return nil
+}
+
+func (m *Meta) GetResources() *ResourceLimits {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
 // Mount specifies how to mount an input Op as a filesystem.
 type Mount struct {
 	Input     InputIndex  `protobuf:"varint,1,opt,name=input,proto3,customtype=InputIndex" json:"input"`
@@ -856,6 +906,58 @@ func (m *SSHOpt) GetOptional() bool {
 	return false
 }
 
+// Device is a host device exposed to the container of ExecOp
+type Device struct {
+	// Path of the device on the host, also used inside the container
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Permissions are the cgroup permissions of the device, a combination
+	// of r, w and m. Defaults to rwm.
+	Permissions string `protobuf:"bytes,2,opt,name=permissions,proto3" json:"permissions,omitempty"`
+}
+
+func (m *Device) Reset()         { *m = Device{} }
+func (m *Device) String() string { return proto.CompactTextString(m) }
+func (*Device) ProtoMessage()    {}
+func (*Device) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{9}
+}
+func (m *Device) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Device) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Device) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Device.Merge(m, src)
+}
+func (m *Device) XXX_Size() int {
+	return m.Size()
+}
+func (m *Device) XXX_DiscardUnknown() {
+	xxx_messageInfo_Device.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Device proto.InternalMessageInfo
+
+func (m *Device) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Device) GetPermissions() string {
+	if m != nil {
+		return m.Permissions
+	}
+	return ""
+}
+
 // SourceOp specifies a source such as build contexts and images.
 type SourceOp struct {
 	// TODO: use source type or any type instead of URL protocol.
@@ -869,7 +971,7 @@ func (m *SourceOp) Reset()         { *m = SourceOp{} }
 func (m *SourceOp) String() string { return proto.CompactTextString(m) }
 func (*SourceOp) ProtoMessage()    {}
 func (*SourceOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{9}
+	return fileDescriptor_8de16154b2733812, []int{10}
 }
 func (m *SourceOp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -921,7 +1023,7 @@ func (m *BuildOp) Reset()         { *m = BuildOp{} }
 func (m *BuildOp) String() string { return proto.CompactTextString(m) }
 func (*BuildOp) ProtoMessage()    {}
 func (*BuildOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{10}
+	return fileDescriptor_8de16154b2733812, []int{11}
 }
 func (m *BuildOp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -967,6 +1069,199 @@ func (m *BuildOp) GetAttrs() map[string]string {
 	return nil
 }
 
+// MergeOp merges the filesystems of its inputs. Files in later inputs
+// overwrite files at the same path in earlier inputs.
+type MergeOp struct {
+	Inputs []*MergeInput `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+}
+
+func (m *MergeOp) Reset()         { *m = MergeOp{} }
+func (m *MergeOp) String() string { return proto.CompactTextString(m) }
+func (*MergeOp) ProtoMessage()    {}
+func (*MergeOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{12}
+}
+func (m *MergeOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MergeOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *MergeOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MergeOp.Merge(m, src)
+}
+func (m *MergeOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *MergeOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_MergeOp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MergeOp proto.InternalMessageInfo
+
+func (m *MergeOp) GetInputs() []*MergeInput {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+type MergeInput struct {
+	Input InputIndex `protobuf:"varint,1,opt,name=input,proto3,customtype=InputIndex" json:"input"`
+}
+
+func (m *MergeInput) Reset()         { *m = MergeInput{} }
+func (m *MergeInput) String() string { return proto.CompactTextString(m) }
+func (*MergeInput) ProtoMessage()    {}
+func (*MergeInput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{13}
+}
+func (m *MergeInput) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MergeInput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *MergeInput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MergeInput.Merge(m, src)
+}
+func (m *MergeInput) XXX_Size() int {
+	return m.Size()
+}
+func (m *MergeInput) XXX_DiscardUnknown() {
+	xxx_messageInfo_MergeInput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MergeInput proto.InternalMessageInfo
+
+// DiffOp returns the changes between its lower and upper inputs, including
+// deletions, as a single layer.
+type DiffOp struct {
+	Lower *LowerDiffInput `protobuf:"bytes,1,opt,name=lower,proto3" json:"lower,omitempty"`
+	Upper *UpperDiffInput `protobuf:"bytes,2,opt,name=upper,proto3" json:"upper,omitempty"`
+}
+
+func (m *DiffOp) Reset()         { *m = DiffOp{} }
+func (m *DiffOp) String() string { return proto.CompactTextString(m) }
+func (*DiffOp) ProtoMessage()    {}
+func (*DiffOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{14}
+}
+func (m *DiffOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiffOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *DiffOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffOp.Merge(m, src)
+}
+func (m *DiffOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiffOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffOp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiffOp proto.InternalMessageInfo
+
+func (m *DiffOp) GetLower() *LowerDiffInput {
+	if m != nil {
+		return m.Lower
+	}
+	return nil
+}
+
+func (m *DiffOp) GetUpper() *UpperDiffInput {
+	if m != nil {
+		return m.Upper
+	}
+	return nil
+}
+
+// LowerDiffInput is the lower input of a DiffOp. Empty is used for scratch.
+type LowerDiffInput struct {
+	Input InputIndex `protobuf:"varint,1,opt,name=input,proto3,customtype=InputIndex" json:"input"`
+}
+
+func (m *LowerDiffInput) Reset()         { *m = LowerDiffInput{} }
+func (m *LowerDiffInput) String() string { return proto.CompactTextString(m) }
+func (*LowerDiffInput) ProtoMessage()    {}
+func (*LowerDiffInput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{15}
+}
+func (m *LowerDiffInput) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LowerDiffInput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *LowerDiffInput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LowerDiffInput.Merge(m, src)
+}
+func (m *LowerDiffInput) XXX_Size() int {
+	return m.Size()
+}
+func (m *LowerDiffInput) XXX_DiscardUnknown() {
+	xxx_messageInfo_LowerDiffInput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LowerDiffInput proto.InternalMessageInfo
+
+// UpperDiffInput is the upper input of a DiffOp. Empty is used for scratch.
+type UpperDiffInput struct {
+	Input InputIndex `protobuf:"varint,1,opt,name=input,proto3,customtype=InputIndex" json:"input"`
+}
+
+func (m *UpperDiffInput) Reset()         { *m = UpperDiffInput{} }
+func (m *UpperDiffInput) String() string { return proto.CompactTextString(m) }
+func (*UpperDiffInput) ProtoMessage()    {}
+func (*UpperDiffInput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{16}
+}
+func (m *UpperDiffInput) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpperDiffInput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *UpperDiffInput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpperDiffInput.Merge(m, src)
+}
+func (m *UpperDiffInput) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpperDiffInput) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpperDiffInput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpperDiffInput proto.InternalMessageInfo
+
 // BuildInput is used for BuildOp.
 type BuildInput struct {
 	Input InputIndex `protobuf:"varint,1,opt,name=input,proto3,customtype=InputIndex" json:"input"`
@@ -976,7 +1271,7 @@ func (m *BuildInput) Reset()         { *m = BuildInput{} }
 func (m *BuildInput) String() string { return proto.CompactTextString(m) }
 func (*BuildInput) ProtoMessage()    {}
 func (*BuildInput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{11}
+	return fileDescriptor_8de16154b2733812, []int{17}
 }
 func (m *BuildInput) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1017,7 +1312,7 @@ func (m *OpMetadata) Reset()         { *m = OpMetadata{} }
 func (m *OpMetadata) String() string { return proto.CompactTextString(m) }
 func (*OpMetadata) ProtoMessage()    {}
 func (*OpMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{12}
+	return fileDescriptor_8de16154b2733812, []int{18}
 }
 func (m *OpMetadata) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1080,7 +1375,7 @@ func (m *Source) Reset()         { *m = Source{} }
 func (m *Source) String() string { return proto.CompactTextString(m) }
 func (*Source) ProtoMessage()    {}
 func (*Source) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{13}
+	return fileDescriptor_8de16154b2733812, []int{19}
 }
 func (m *Source) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1128,7 +1423,7 @@ func (m *Locations) Reset()         { *m = Locations{} }
 func (m *Locations) String() string { return proto.CompactTextString(m) }
 func (*Locations) ProtoMessage()    {}
 func (*Locations) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{14}
+	return fileDescriptor_8de16154b2733812, []int{20}
 }
 func (m *Locations) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1171,7 +1466,7 @@ func (m *SourceInfo) Reset()         { *m = SourceInfo{} }
 func (m *SourceInfo) String() string { return proto.CompactTextString(m) }
 func (*SourceInfo) ProtoMessage()    {}
 func (*SourceInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{15}
+	return fileDescriptor_8de16154b2733812, []int{21}
 }
 func (m *SourceInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1227,7 +1522,7 @@ func (m *Location) Reset()         { *m = Location{} }
 func (m *Location) String() string { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()    {}
 func (*Location) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{16}
+	return fileDescriptor_8de16154b2733812, []int{22}
 }
 func (m *Location) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1276,7 +1571,7 @@ func (m *Range) Reset()         { *m = Range{} }
 func (m *Range) String() string { return proto.CompactTextString(m) }
 func (*Range) ProtoMessage()    {}
 func (*Range) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{17}
+	return fileDescriptor_8de16154b2733812, []int{23}
 }
 func (m *Range) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1325,7 +1620,7 @@ func (m *Position) Reset()         { *m = Position{} }
 func (m *Position) String() string { return proto.CompactTextString(m) }
 func (*Position) ProtoMessage()    {}
 func (*Position) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{18}
+	return fileDescriptor_8de16154b2733812, []int{24}
 }
 func (m *Position) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1372,7 +1667,7 @@ func (m *ExportCache) Reset()         { *m = ExportCache{} }
 func (m *ExportCache) String() string { return proto.CompactTextString(m) }
 func (*ExportCache) ProtoMessage()    {}
 func (*ExportCache) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{19}
+	return fileDescriptor_8de16154b2733812, []int{25}
 }
 func (m *ExportCache) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1415,7 +1710,7 @@ func (m *ProxyEnv) Reset()         { *m = ProxyEnv{} }
 func (m *ProxyEnv) String() string { return proto.CompactTextString(m) }
 func (*ProxyEnv) ProtoMessage()    {}
 func (*ProxyEnv) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{20}
+	return fileDescriptor_8de16154b2733812, []int{26}
 }
 func (m *ProxyEnv) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1477,7 +1772,7 @@ func (m *WorkerConstraints) Reset()         { *m = WorkerConstraints{} }
 func (m *WorkerConstraints) String() string { return proto.CompactTextString(m) }
 func (*WorkerConstraints) ProtoMessage()    {}
 func (*WorkerConstraints) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{21}
+	return fileDescriptor_8de16154b2733812, []int{27}
 }
 func (m *WorkerConstraints) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1524,7 +1819,7 @@ func (m *Definition) Reset()         { *m = Definition{} }
 func (m *Definition) String() string { return proto.CompactTextString(m) }
 func (*Definition) ProtoMessage()    {}
 func (*Definition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{22}
+	return fileDescriptor_8de16154b2733812, []int{28}
 }
 func (m *Definition) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1579,7 +1874,7 @@ func (m *HostIP) Reset()         { *m = HostIP{} }
 func (m *HostIP) String() string { return proto.CompactTextString(m) }
 func (*HostIP) ProtoMessage()    {}
 func (*HostIP) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{23}
+	return fileDescriptor_8de16154b2733812, []int{29}
 }
 func (m *HostIP) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1618,20 +1913,24 @@ func (m *HostIP) GetIP() string {
 	return ""
 }
 
-type FileOp struct {
-	Actions []*FileAction `protobuf:"bytes,2,rep,name=actions,proto3" json:"actions,omitempty"`
+// Ulimit sets the soft and hard limit of a process resource. Name is the
+// lowercase name of the resource without the RLIMIT_ prefix, e.g. "nofile".
+type Ulimit struct {
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Soft int64  `protobuf:"varint,2,opt,name=Soft,proto3" json:"Soft,omitempty"`
+	Hard int64  `protobuf:"varint,3,opt,name=Hard,proto3" json:"Hard,omitempty"`
 }
 
-func (m *FileOp) Reset()         { *m = FileOp{} }
-func (m *FileOp) String() string { return proto.CompactTextString(m) }
-func (*FileOp) ProtoMessage()    {}
-func (*FileOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{24}
+func (m *Ulimit) Reset()         { *m = Ulimit{} }
+func (m *Ulimit) String() string { return proto.CompactTextString(m) }
+func (*Ulimit) ProtoMessage()    {}
+func (*Ulimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{30}
 }
-func (m *FileOp) XXX_Unmarshal(b []byte) error {
+func (m *Ulimit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FileOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Ulimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	b = b[:cap(b)]
 	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
@@ -1639,47 +1938,63 @@ func (m *FileOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	}
 	return b[:n], nil
 }
-func (m *FileOp) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FileOp.Merge(m, src)
+func (m *Ulimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ulimit.Merge(m, src)
 }
-func (m *FileOp) XXX_Size() int {
+func (m *Ulimit) XXX_Size() int {
 	return m.Size()
 }
-func (m *FileOp) XXX_DiscardUnknown() {
-	xxx_messageInfo_FileOp.DiscardUnknown(m)
+func (m *Ulimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ulimit.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FileOp proto.InternalMessageInfo
+var xxx_messageInfo_Ulimit proto.InternalMessageInfo
 
-func (m *FileOp) GetActions() []*FileAction {
+func (m *Ulimit) GetName() string {
 	if m != nil {
-		return m.Actions
+		return m.Name
 	}
-	return nil
+	return ""
 }
 
-type FileAction struct {
-	Input          InputIndex  `protobuf:"varint,1,opt,name=input,proto3,customtype=InputIndex" json:"input"`
-	SecondaryInput InputIndex  `protobuf:"varint,2,opt,name=secondaryInput,proto3,customtype=InputIndex" json:"secondaryInput"`
-	Output         OutputIndex `protobuf:"varint,3,opt,name=output,proto3,customtype=OutputIndex" json:"output"`
-	// Types that are valid to be assigned to Action:
-	//	*FileAction_Copy
-	//	*FileAction_Mkfile
-	//	*FileAction_Mkdir
-	//	*FileAction_Rm
-	Action isFileAction_Action `protobuf_oneof:"action"`
+func (m *Ulimit) GetSoft() int64 {
+	if m != nil {
+		return m.Soft
+	}
+	return 0
 }
 
-func (m *FileAction) Reset()         { *m = FileAction{} }
-func (m *FileAction) String() string { return proto.CompactTextString(m) }
-func (*FileAction) ProtoMessage()    {}
-func (*FileAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{25}
+func (m *Ulimit) GetHard() int64 {
+	if m != nil {
+		return m.Hard
+	}
+	return 0
 }
-func (m *FileAction) XXX_Unmarshal(b []byte) error {
+
+// ResourceLimits are the cgroup limits of the process run by an exec. Zero
+// values are not limited.
+type ResourceLimits struct {
+	// memory limit in bytes
+	Memory int64 `protobuf:"varint,1,opt,name=memory,proto3" json:"memory,omitempty"`
+	// relative cpu weight
+	CpuShares int64 `protobuf:"varint,2,opt,name=cpuShares,proto3" json:"cpuShares,omitempty"`
+	// cpu time in microseconds the process can use per cpuPeriod
+	CpuQuota  int64 `protobuf:"varint,3,opt,name=cpuQuota,proto3" json:"cpuQuota,omitempty"`
+	CpuPeriod int64 `protobuf:"varint,4,opt,name=cpuPeriod,proto3" json:"cpuPeriod,omitempty"`
+	// maximum number of processes
+	Pids int64 `protobuf:"varint,5,opt,name=pids,proto3" json:"pids,omitempty"`
+}
+
+func (m *ResourceLimits) Reset()         { *m = ResourceLimits{} }
+func (m *ResourceLimits) String() string { return proto.CompactTextString(m) }
+func (*ResourceLimits) ProtoMessage()    {}
+func (*ResourceLimits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{31}
+}
+func (m *ResourceLimits) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FileAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResourceLimits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	b = b[:cap(b)]
 	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
@@ -1687,21 +2002,137 @@ func (m *FileAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	}
 	return b[:n], nil
 }
-func (m *FileAction) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FileAction.Merge(m, src)
+func (m *ResourceLimits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceLimits.Merge(m, src)
 }
-func (m *FileAction) XXX_Size() int {
+func (m *ResourceLimits) XXX_Size() int {
 	return m.Size()
 }
-func (m *FileAction) XXX_DiscardUnknown() {
-	xxx_messageInfo_FileAction.DiscardUnknown(m)
+func (m *ResourceLimits) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceLimits.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FileAction proto.InternalMessageInfo
+var xxx_messageInfo_ResourceLimits proto.InternalMessageInfo
 
-type isFileAction_Action interface {
-	isFileAction_Action()
-	MarshalTo([]byte) (int, error)
+func (m *ResourceLimits) GetMemory() int64 {
+	if m != nil {
+		return m.Memory
+	}
+	return 0
+}
+
+func (m *ResourceLimits) GetCpuShares() int64 {
+	if m != nil {
+		return m.CpuShares
+	}
+	return 0
+}
+
+func (m *ResourceLimits) GetCpuQuota() int64 {
+	if m != nil {
+		return m.CpuQuota
+	}
+	return 0
+}
+
+func (m *ResourceLimits) GetCpuPeriod() int64 {
+	if m != nil {
+		return m.CpuPeriod
+	}
+	return 0
+}
+
+func (m *ResourceLimits) GetPids() int64 {
+	if m != nil {
+		return m.Pids
+	}
+	return 0
+}
+
+type FileOp struct {
+	Actions []*FileAction `protobuf:"bytes,2,rep,name=actions,proto3" json:"actions,omitempty"`
+}
+
+func (m *FileOp) Reset()         { *m = FileOp{} }
+func (m *FileOp) String() string { return proto.CompactTextString(m) }
+func (*FileOp) ProtoMessage()    {}
+func (*FileOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{32}
+}
+func (m *FileOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FileOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *FileOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileOp.Merge(m, src)
+}
+func (m *FileOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *FileOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileOp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileOp proto.InternalMessageInfo
+
+func (m *FileOp) GetActions() []*FileAction {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+type FileAction struct {
+	Input          InputIndex  `protobuf:"varint,1,opt,name=input,proto3,customtype=InputIndex" json:"input"`
+	SecondaryInput InputIndex  `protobuf:"varint,2,opt,name=secondaryInput,proto3,customtype=InputIndex" json:"secondaryInput"`
+	Output         OutputIndex `protobuf:"varint,3,opt,name=output,proto3,customtype=OutputIndex" json:"output"`
+	// Types that are valid to be assigned to Action:
+	//	*FileAction_Copy
+	//	*FileAction_Mkfile
+	//	*FileAction_Mkdir
+	//	*FileAction_Rm
+	Action isFileAction_Action `protobuf_oneof:"action"`
+}
+
+func (m *FileAction) Reset()         { *m = FileAction{} }
+func (m *FileAction) String() string { return proto.CompactTextString(m) }
+func (*FileAction) ProtoMessage()    {}
+func (*FileAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{33}
+}
+func (m *FileAction) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FileAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *FileAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileAction.Merge(m, src)
+}
+func (m *FileAction) XXX_Size() int {
+	return m.Size()
+}
+func (m *FileAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileAction proto.InternalMessageInfo
+
+type isFileAction_Action interface {
+	isFileAction_Action()
+	MarshalTo([]byte) (int, error)
 	Size() int
 }
 
@@ -1791,13 +2222,19 @@ type FileActionCopy struct {
 	AllowEmptyWildcard bool `protobuf:"varint,10,opt,name=allowEmptyWildcard,proto3" json:"allowEmptyWildcard,omitempty"`
 	// optional created time override
 	Timestamp int64 `protobuf:"varint,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// include only files/dirs matching at least one of these patterns
+	IncludePatterns []string `protobuf:"bytes,12,rep,name=include_patterns,json=includePatterns,proto3" json:"include_patterns,omitempty"`
+	// exclude files/dir matching any of these patterns (even if they match an include pattern)
+	ExcludePatterns []string `protobuf:"bytes,13,rep,name=exclude_patterns,json=excludePatterns,proto3" json:"exclude_patterns,omitempty"`
+	// parents keeps the parent directories of src relative to the source root in dest
+	Parents bool `protobuf:"varint,14,opt,name=parents,proto3" json:"parents,omitempty"`
 }
 
 func (m *FileActionCopy) Reset()         { *m = FileActionCopy{} }
 func (m *FileActionCopy) String() string { return proto.CompactTextString(m) }
 func (*FileActionCopy) ProtoMessage()    {}
 func (*FileActionCopy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{26}
+	return fileDescriptor_8de16154b2733812, []int{34}
 }
 func (m *FileActionCopy) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1899,6 +2336,27 @@ func (m *FileActionCopy) GetTimestamp() int64 {
 	return 0
 }
 
+func (m *FileActionCopy) GetIncludePatterns() []string {
+	if m != nil {
+		return m.IncludePatterns
+	}
+	return nil
+}
+
+func (m *FileActionCopy) GetExcludePatterns() []string {
+	if m != nil {
+		return m.ExcludePatterns
+	}
+	return nil
+}
+
+func (m *FileActionCopy) GetParents() bool {
+	if m != nil {
+		return m.Parents
+	}
+	return false
+}
+
 type FileActionMkFile struct {
 	// path for the new file
 	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
@@ -1916,7 +2374,7 @@ func (m *FileActionMkFile) Reset()         { *m = FileActionMkFile{} }
 func (m *FileActionMkFile) String() string { return proto.CompactTextString(m) }
 func (*FileActionMkFile) ProtoMessage()    {}
 func (*FileActionMkFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{27}
+	return fileDescriptor_8de16154b2733812, []int{35}
 }
 func (m *FileActionMkFile) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1993,7 +2451,7 @@ func (m *FileActionMkDir) Reset()         { *m = FileActionMkDir{} }
 func (m *FileActionMkDir) String() string { return proto.CompactTextString(m) }
 func (*FileActionMkDir) ProtoMessage()    {}
 func (*FileActionMkDir) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{28}
+	return fileDescriptor_8de16154b2733812, []int{36}
 }
 func (m *FileActionMkDir) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2066,7 +2524,7 @@ func (m *FileActionRm) Reset()         { *m = FileActionRm{} }
 func (m *FileActionRm) String() string { return proto.CompactTextString(m) }
 func (*FileActionRm) ProtoMessage()    {}
 func (*FileActionRm) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{29}
+	return fileDescriptor_8de16154b2733812, []int{37}
 }
 func (m *FileActionRm) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2121,7 +2579,7 @@ func (m *ChownOpt) Reset()         { *m = ChownOpt{} }
 func (m *ChownOpt) String() string { return proto.CompactTextString(m) }
 func (*ChownOpt) ProtoMessage()    {}
 func (*ChownOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{30}
+	return fileDescriptor_8de16154b2733812, []int{38}
 }
 func (m *ChownOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2171,7 +2629,7 @@ func (m *UserOpt) Reset()         { *m = UserOpt{} }
 func (m *UserOpt) String() string { return proto.CompactTextString(m) }
 func (*UserOpt) ProtoMessage()    {}
 func (*UserOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{31}
+	return fileDescriptor_8de16154b2733812, []int{39}
 }
 func (m *UserOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2250,7 +2708,7 @@ func (m *NamedUserOpt) Reset()         { *m = NamedUserOpt{} }
 func (m *NamedUserOpt) String() string { return proto.CompactTextString(m) }
 func (*NamedUserOpt) ProtoMessage()    {}
 func (*NamedUserOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{32}
+	return fileDescriptor_8de16154b2733812, []int{40}
 }
 func (m *NamedUserOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2296,11 +2754,17 @@ func init() {
 	proto.RegisterType((*CacheOpt)(nil), "pb.CacheOpt")
 	proto.RegisterType((*SecretOpt)(nil), "pb.SecretOpt")
 	proto.RegisterType((*SSHOpt)(nil), "pb.SSHOpt")
+	proto.RegisterType((*Device)(nil), "pb.Device")
 	proto.RegisterType((*SourceOp)(nil), "pb.SourceOp")
 	proto.RegisterMapType((map[string]string)(nil), "pb.SourceOp.AttrsEntry")
 	proto.RegisterType((*BuildOp)(nil), "pb.BuildOp")
 	proto.RegisterMapType((map[string]string)(nil), "pb.BuildOp.AttrsEntry")
 	proto.RegisterMapType((map[string]*BuildInput)(nil), "pb.BuildOp.InputsEntry")
+	proto.RegisterType((*MergeOp)(nil), "pb.MergeOp")
+	proto.RegisterType((*MergeInput)(nil), "pb.MergeInput")
+	proto.RegisterType((*DiffOp)(nil), "pb.DiffOp")
+	proto.RegisterType((*LowerDiffInput)(nil), "pb.LowerDiffInput")
+	proto.RegisterType((*UpperDiffInput)(nil), "pb.UpperDiffInput")
 	proto.RegisterType((*BuildInput)(nil), "pb.BuildInput")
 	proto.RegisterType((*OpMetadata)(nil), "pb.OpMetadata")
 	proto.RegisterMapType((map[github_com_moby_buildkit_util_apicaps.CapID]bool)(nil), "pb.OpMetadata.CapsEntry")
@@ -2318,6 +2782,8 @@ func init() {
 	proto.RegisterType((*Definition)(nil), "pb.Definition")
 	proto.RegisterMapType((map[github_com_opencontainers_go_digest.Digest]OpMetadata)(nil), "pb.Definition.MetadataEntry")
 	proto.RegisterType((*HostIP)(nil), "pb.HostIP")
+	proto.RegisterType((*Ulimit)(nil), "pb.Ulimit")
+	proto.RegisterType((*ResourceLimits)(nil), "pb.ResourceLimits")
 	proto.RegisterType((*FileOp)(nil), "pb.FileOp")
 	proto.RegisterType((*FileAction)(nil), "pb.FileAction")
 	proto.RegisterType((*FileActionCopy)(nil), "pb.FileActionCopy")
@@ -2332,146 +2798,165 @@ func init() {
 func init() { proto.RegisterFile("ops.proto", fileDescriptor_8de16154b2733812) }
 
 var fileDescriptor_8de16154b2733812 = []byte{
-	// 2217 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcd, 0x6f, 0x1b, 0xc7,
-	0x15, 0x17, 0xbf, 0xc9, 0x47, 0x49, 0x66, 0x27, 0x4e, 0xc2, 0xa8, 0xae, 0xa4, 0x6c, 0xdc, 0x40,
-	0x96, 0x6d, 0x0a, 0x50, 0x80, 0x38, 0x08, 0x8a, 0xa2, 0xe2, 0x87, 0x21, 0xc6, 0xb6, 0x28, 0x0c,
-	0xfd, 0xd1, 0x9b, 0xb1, 0x5a, 0x0e, 0xa9, 0x85, 0xc8, 0x9d, 0xc5, 0xec, 0xd0, 0x16, 0x2f, 0x3d,
-	0xf8, 0x2f, 0x08, 0x50, 0xa0, 0xb7, 0x16, 0xe8, 0xa5, 0x7f, 0x41, 0xaf, 0x3d, 0x16, 0xc8, 0x31,
-	0x87, 0x1e, 0x82, 0x1e, 0xd2, 0xc2, 0xbe, 0xf7, 0x3f, 0x28, 0x50, 0xbc, 0x37, 0xb3, 0x1f, 0x94,
-	0x65, 0xd8, 0x46, 0x8b, 0x9e, 0x76, 0xe6, 0xbd, 0xdf, 0xbc, 0x79, 0xf3, 0xbe, 0xe6, 0xcd, 0x42,
-	0x4d, 0x86, 0x51, 0x2b, 0x54, 0x52, 0x4b, 0x96, 0x0f, 0x4f, 0x36, 0x6e, 0x4f, 0x7c, 0x7d, 0x3a,
-	0x3f, 0x69, 0x79, 0x72, 0xb6, 0x37, 0x91, 0x13, 0xb9, 0x47, 0xac, 0x93, 0xf9, 0x98, 0x66, 0x34,
-	0xa1, 0x91, 0x59, 0xe2, 0xfc, 0x31, 0x0f, 0xf9, 0x41, 0xc8, 0x3e, 0x85, 0xb2, 0x1f, 0x84, 0x73,
-	0x1d, 0x35, 0x73, 0xdb, 0x85, 0x9d, 0xfa, 0x7e, 0xad, 0x15, 0x9e, 0xb4, 0xfa, 0x48, 0xe1, 0x96,
-	0xc1, 0xb6, 0xa1, 0x28, 0xce, 0x85, 0xd7, 0xcc, 0x6f, 0xe7, 0x76, 0xea, 0xfb, 0x80, 0x80, 0xde,
-	0xb9, 0xf0, 0x06, 0xe1, 0xe1, 0x0a, 0x27, 0x0e, 0xfb, 0x1c, 0xca, 0x91, 0x9c, 0x2b, 0x4f, 0x34,
-	0x0b, 0x84, 0x59, 0x45, 0xcc, 0x90, 0x28, 0x84, 0xb2, 0x5c, 0x94, 0x34, 0xf6, 0xa7, 0xa2, 0x59,
-	0x4c, 0x25, 0xdd, 0xf5, 0xa7, 0x06, 0x43, 0x1c, 0xf6, 0x19, 0x94, 0x4e, 0xe6, 0xfe, 0x74, 0xd4,
-	0x2c, 0x11, 0xa4, 0x8e, 0x90, 0x36, 0x12, 0x08, 0x63, 0x78, 0x6c, 0x07, 0xaa, 0xe1, 0xd4, 0xd5,
-	0x63, 0xa9, 0x66, 0x4d, 0x48, 0x37, 0x3c, 0xb6, 0x34, 0x9e, 0x70, 0xd9, 0x1d, 0xa8, 0x7b, 0x32,
-	0x88, 0xb4, 0x72, 0xfd, 0x40, 0x47, 0xcd, 0x3a, 0x81, 0x3f, 0x44, 0xf0, 0x13, 0xa9, 0xce, 0x84,
-	0xea, 0xa4, 0x4c, 0x9e, 0x45, 0xb6, 0x8b, 0x90, 0x97, 0xa1, 0xf3, 0xbb, 0x1c, 0x54, 0x63, 0xa9,
-	0xcc, 0x81, 0xd5, 0x03, 0xe5, 0x9d, 0xfa, 0x5a, 0x78, 0x7a, 0xae, 0x44, 0x33, 0xb7, 0x9d, 0xdb,
-	0xa9, 0xf1, 0x25, 0x1a, 0x5b, 0x87, 0xfc, 0x60, 0x48, 0x86, 0xaa, 0xf1, 0xfc, 0x60, 0xc8, 0x9a,
-	0x50, 0x79, 0xec, 0x2a, 0xdf, 0x0d, 0x34, 0x59, 0xa6, 0xc6, 0xe3, 0x29, 0xbb, 0x06, 0xb5, 0xc1,
-	0xf0, 0xb1, 0x50, 0x91, 0x2f, 0x03, 0xb2, 0x47, 0x8d, 0xa7, 0x04, 0xb6, 0x09, 0x30, 0x18, 0xde,
-	0x15, 0x2e, 0x0a, 0x8d, 0x9a, 0xa5, 0xed, 0xc2, 0x4e, 0x8d, 0x67, 0x28, 0xce, 0x6f, 0xa0, 0x44,
-	0x3e, 0x62, 0xdf, 0x40, 0x79, 0xe4, 0x4f, 0x44, 0xa4, 0x8d, 0x3a, 0xed, 0xfd, 0xef, 0x7e, 0xdc,
-	0x5a, 0xf9, 0xfb, 0x8f, 0x5b, 0xbb, 0x99, 0x60, 0x90, 0xa1, 0x08, 0x3c, 0x19, 0x68, 0xd7, 0x0f,
-	0x84, 0x8a, 0xf6, 0x26, 0xf2, 0xb6, 0x59, 0xd2, 0xea, 0xd2, 0x87, 0x5b, 0x09, 0xec, 0x06, 0x94,
-	0xfc, 0x60, 0x24, 0xce, 0x49, 0xff, 0x42, 0xfb, 0x03, 0x2b, 0xaa, 0x3e, 0x98, 0xeb, 0x70, 0xae,
-	0xfb, 0xc8, 0xe2, 0x06, 0xe1, 0xfc, 0x21, 0x07, 0x65, 0x13, 0x03, 0xec, 0x1a, 0x14, 0x67, 0x42,
-	0xbb, 0xb4, 0x7f, 0x7d, 0xbf, 0x8a, 0xb6, 0x7d, 0x20, 0xb4, 0xcb, 0x89, 0x8a, 0xe1, 0x35, 0x93,
-	0x73, 0xb4, 0x7d, 0x3e, 0x0d, 0xaf, 0x07, 0x48, 0xe1, 0x96, 0xc1, 0x7e, 0x0e, 0x95, 0x40, 0xe8,
-	0xe7, 0x52, 0x9d, 0x91, 0x8d, 0xd6, 0x8d, 0xd3, 0x8f, 0x84, 0x7e, 0x20, 0x47, 0x82, 0xc7, 0x3c,
-	0x76, 0x0b, 0xaa, 0x91, 0xf0, 0xe6, 0xca, 0xd7, 0x0b, 0xb2, 0xd7, 0xfa, 0x7e, 0x83, 0xa2, 0xcc,
-	0xd2, 0x08, 0x9c, 0x20, 0x9c, 0xbf, 0xe6, 0xa0, 0x88, 0x6a, 0x30, 0x06, 0x45, 0x57, 0x4d, 0x4c,
-	0x74, 0xd7, 0x38, 0x8d, 0x59, 0x03, 0x0a, 0x22, 0x78, 0x46, 0x1a, 0xd5, 0x38, 0x0e, 0x91, 0xe2,
-	0x3d, 0x1f, 0x59, 0x1f, 0xe1, 0x10, 0xd7, 0xcd, 0x23, 0xa1, 0xac, 0x6b, 0x68, 0xcc, 0x6e, 0x40,
-	0x2d, 0x54, 0xf2, 0x7c, 0xf1, 0x14, 0x57, 0x97, 0x32, 0x81, 0x87, 0xc4, 0x5e, 0xf0, 0x8c, 0x57,
-	0x43, 0x3b, 0x62, 0xbb, 0x00, 0xe2, 0x5c, 0x2b, 0xf7, 0x50, 0x46, 0x3a, 0x6a, 0x96, 0xe9, 0xec,
-	0x14, 0xef, 0x48, 0xe8, 0x1f, 0xf3, 0x0c, 0x97, 0x6d, 0x40, 0xf5, 0x54, 0x46, 0x3a, 0x70, 0x67,
-	0xa2, 0x59, 0xa1, 0xed, 0x92, 0xb9, 0xf3, 0xaf, 0x3c, 0x94, 0xc8, 0x5c, 0x6c, 0x07, 0xbd, 0x13,
-	0xce, 0x8d, 0xa3, 0x0b, 0x6d, 0x66, 0xbd, 0x03, 0x14, 0x07, 0x89, 0x73, 0x30, 0x26, 0x36, 0xd0,
-	0x52, 0x53, 0xe1, 0x69, 0xa9, 0x6c, 0x28, 0x26, 0x73, 0x3c, 0xd6, 0x08, 0xa3, 0xc5, 0x9c, 0x94,
-	0xc6, 0xec, 0x26, 0x94, 0x25, 0xb9, 0x98, 0x0e, 0xfb, 0x06, 0xc7, 0x5b, 0x08, 0x0a, 0x57, 0xc2,
-	0x1d, 0xc9, 0x60, 0xba, 0x20, 0x13, 0x54, 0x79, 0x32, 0x67, 0x37, 0xa1, 0x46, 0x3e, 0x7d, 0xb8,
-	0x08, 0x45, 0xb3, 0x4c, 0x3e, 0x5a, 0x4b, 0xfc, 0x8d, 0x44, 0x9e, 0xf2, 0x31, 0x89, 0x3d, 0xd7,
-	0x3b, 0x15, 0x83, 0x50, 0x37, 0xaf, 0xa6, 0xb6, 0xec, 0x58, 0x1a, 0x4f, 0xb8, 0x28, 0x36, 0x12,
-	0x9e, 0x12, 0x1a, 0xa1, 0x1f, 0x12, 0x74, 0xcd, 0xba, 0xde, 0x10, 0x79, 0xca, 0x67, 0x0e, 0x94,
-	0x87, 0xc3, 0x43, 0x44, 0x7e, 0x94, 0x16, 0x19, 0x43, 0xe1, 0x96, 0x63, 0xce, 0x10, 0xcd, 0xa7,
-	0xba, 0xdf, 0x6d, 0x7e, 0x6c, 0x0c, 0x14, 0xcf, 0x9d, 0x3e, 0x54, 0x63, 0x15, 0x30, 0x9b, 0xfb,
-	0x5d, 0x9b, 0xe7, 0xf9, 0x7e, 0x97, 0xdd, 0x86, 0x4a, 0x74, 0xea, 0x2a, 0x3f, 0x98, 0x90, 0x5d,
-	0xd7, 0xf7, 0x3f, 0x48, 0x34, 0x1e, 0x1a, 0x3a, 0xee, 0x12, 0x63, 0x1c, 0x09, 0xb5, 0x44, 0xc5,
-	0xd7, 0x64, 0x35, 0xa0, 0x30, 0xf7, 0x47, 0x24, 0x67, 0x8d, 0xe3, 0x10, 0x29, 0x13, 0xdf, 0xc4,
-	0xe0, 0x1a, 0xc7, 0x21, 0x3a, 0x6b, 0x26, 0x47, 0xa6, 0x5c, 0xae, 0x71, 0x1a, 0xa3, 0xee, 0x32,
-	0xd4, 0xbe, 0x0c, 0xdc, 0x69, 0x6c, 0xff, 0x78, 0xee, 0x4c, 0xe3, 0xb3, 0xff, 0x5f, 0x76, 0xfb,
-	0x6d, 0x0e, 0xaa, 0x71, 0x8d, 0xc7, 0x82, 0xe5, 0x8f, 0x44, 0xa0, 0xfd, 0xb1, 0x2f, 0x94, 0xdd,
-	0x38, 0x43, 0x61, 0xb7, 0xa1, 0xe4, 0x6a, 0xad, 0xe2, 0x32, 0xf0, 0x71, 0xf6, 0x82, 0x68, 0x1d,
-	0x20, 0xa7, 0x17, 0x68, 0xb5, 0xe0, 0x06, 0xb5, 0xf1, 0x15, 0x40, 0x4a, 0x44, 0x5d, 0xcf, 0xc4,
-	0xc2, 0x4a, 0xc5, 0x21, 0xbb, 0x0a, 0xa5, 0x67, 0xee, 0x74, 0x2e, 0x6c, 0x7c, 0x9b, 0xc9, 0xd7,
-	0xf9, 0xaf, 0x72, 0xce, 0x5f, 0xf2, 0x50, 0xb1, 0x17, 0x06, 0xbb, 0x05, 0x15, 0xba, 0x30, 0xac,
-	0x46, 0x97, 0x27, 0x4d, 0x0c, 0x61, 0x7b, 0xc9, 0x4d, 0x98, 0xd1, 0xd1, 0x8a, 0x32, 0x37, 0xa2,
-	0xd5, 0x31, 0xbd, 0x17, 0x0b, 0x23, 0x31, 0xb6, 0x57, 0xde, 0x3a, 0xa2, 0xbb, 0x62, 0xec, 0x07,
-	0x3e, 0xda, 0x87, 0x23, 0x8b, 0xdd, 0x8a, 0x4f, 0x5d, 0x24, 0x89, 0x1f, 0x65, 0x25, 0xbe, 0x7e,
-	0xe8, 0x3e, 0xd4, 0x33, 0xdb, 0x5c, 0x72, 0xea, 0xeb, 0xd9, 0x53, 0xdb, 0x2d, 0x49, 0x9c, 0xb9,
-	0xaf, 0x53, 0x2b, 0xfc, 0x17, 0xf6, 0xfb, 0x12, 0x20, 0x15, 0xf9, 0xee, 0x45, 0xc7, 0x79, 0x51,
-	0x00, 0x18, 0x84, 0x58, 0x72, 0x47, 0x2e, 0xd5, 0xfd, 0x55, 0x7f, 0x12, 0x48, 0x25, 0x9e, 0x52,
-	0x1a, 0xd3, 0xfa, 0x2a, 0xaf, 0x1b, 0x1a, 0x65, 0x0c, 0x3b, 0x80, 0xfa, 0x48, 0x44, 0x9e, 0xf2,
-	0x29, 0xa0, 0xac, 0xd1, 0xb7, 0xf0, 0x4c, 0xa9, 0x9c, 0x56, 0x37, 0x45, 0x18, 0x5b, 0x65, 0xd7,
-	0xb0, 0x7d, 0x58, 0x15, 0xe7, 0xa1, 0x54, 0xda, 0xee, 0x62, 0xfa, 0x8a, 0x2b, 0xa6, 0x43, 0x41,
-	0x3a, 0xed, 0xc4, 0xeb, 0x22, 0x9d, 0x30, 0x17, 0x8a, 0x9e, 0x1b, 0x9a, 0x4b, 0xb5, 0xbe, 0xdf,
-	0xbc, 0xb0, 0x5f, 0xc7, 0x0d, 0x8d, 0xd1, 0xda, 0x5f, 0xe0, 0x59, 0x5f, 0xfc, 0x63, 0xeb, 0x66,
-	0xe6, 0x26, 0x9d, 0xc9, 0x93, 0xc5, 0x1e, 0xc5, 0xcb, 0x99, 0xaf, 0xf7, 0xe6, 0xda, 0x9f, 0xee,
-	0xb9, 0xa1, 0x8f, 0xe2, 0x70, 0x61, 0xbf, 0xcb, 0x49, 0xf4, 0xc6, 0x2f, 0xa1, 0x71, 0x51, 0xef,
-	0xf7, 0xf1, 0xc1, 0xc6, 0x1d, 0xa8, 0x25, 0x7a, 0xbc, 0x6d, 0x61, 0x35, 0xeb, 0xbc, 0x3f, 0xe7,
-	0xa0, 0x6c, 0xb2, 0x8a, 0xdd, 0x81, 0xda, 0x54, 0x7a, 0x2e, 0x2a, 0x10, 0xb7, 0x76, 0x9f, 0xa4,
-	0x49, 0xd7, 0xba, 0x1f, 0xf3, 0x8c, 0x55, 0x53, 0x2c, 0x06, 0x99, 0x1f, 0x8c, 0x65, 0x9c, 0x05,
-	0xeb, 0xe9, 0xa2, 0x7e, 0x30, 0x96, 0xdc, 0x30, 0x37, 0xee, 0xc1, 0xfa, 0xb2, 0x88, 0x4b, 0xf4,
-	0xfc, 0x6c, 0x39, 0x5c, 0xa9, 0x66, 0x27, 0x8b, 0xb2, 0x6a, 0xdf, 0x81, 0x5a, 0x42, 0x67, 0xbb,
-	0xaf, 0x2b, 0xbe, 0x9a, 0x5d, 0x99, 0xd1, 0xd5, 0x99, 0x02, 0xa4, 0xaa, 0x61, 0xb1, 0xc2, 0x1e,
-	0x92, 0xee, 0x51, 0xa3, 0x46, 0x32, 0xa7, 0x7b, 0xcf, 0xd5, 0x2e, 0xa9, 0xb2, 0xca, 0x69, 0xcc,
-	0x5a, 0x00, 0xa3, 0x24, 0x61, 0xdf, 0x90, 0xc6, 0x19, 0x84, 0x33, 0x80, 0x6a, 0xac, 0x04, 0xdb,
-	0x86, 0x7a, 0x64, 0x77, 0xc6, 0x8e, 0x09, 0xb7, 0x2b, 0xf1, 0x2c, 0x09, 0x3b, 0x1f, 0xe5, 0x06,
-	0x13, 0xb1, 0xd4, 0xf9, 0x70, 0xa4, 0x70, 0xcb, 0x70, 0x9e, 0x40, 0x89, 0x08, 0x98, 0x66, 0x91,
-	0x76, 0x95, 0xb6, 0x4d, 0x94, 0x69, 0x2a, 0x64, 0x44, 0xdb, 0xb6, 0x8b, 0x18, 0x88, 0xdc, 0x00,
-	0xd8, 0x75, 0x6c, 0x5d, 0x46, 0xd6, 0xa2, 0x97, 0xe1, 0x90, 0xed, 0xfc, 0x02, 0xaa, 0x31, 0x19,
-	0x4f, 0x7e, 0xdf, 0x0f, 0x84, 0x55, 0x91, 0xc6, 0xd8, 0x7c, 0x76, 0x4e, 0x5d, 0xe5, 0x7a, 0x5a,
-	0x98, 0x16, 0xa1, 0xc4, 0x53, 0x82, 0xf3, 0x19, 0xd4, 0x33, 0xd9, 0x83, 0xe1, 0xf6, 0x98, 0xdc,
-	0x68, 0x72, 0xd8, 0x4c, 0x9c, 0x17, 0xd8, 0x1a, 0xc7, 0xdd, 0xce, 0xcf, 0x00, 0x4e, 0xb5, 0x0e,
-	0x9f, 0x52, 0xfb, 0x63, 0x6d, 0x5f, 0x43, 0x0a, 0x21, 0xd8, 0x16, 0xd4, 0x71, 0x12, 0x59, 0xbe,
-	0x89, 0x77, 0x5a, 0x11, 0x19, 0xc0, 0x4f, 0xa1, 0x36, 0x4e, 0x96, 0x17, 0xac, 0xeb, 0xe2, 0xd5,
-	0x9f, 0x40, 0x35, 0x90, 0x96, 0x67, 0xba, 0xb1, 0x4a, 0x20, 0x89, 0xe5, 0xdc, 0x84, 0x9f, 0xbc,
-	0xd6, 0xc7, 0xb3, 0x8f, 0xa0, 0x3c, 0xf6, 0xa7, 0x9a, 0x8a, 0x3e, 0x36, 0x78, 0x76, 0xe6, 0xfc,
-	0x3b, 0x07, 0x90, 0x7a, 0x16, 0xe3, 0x15, 0xab, 0x37, 0x62, 0x56, 0x4d, 0xb5, 0x9e, 0x42, 0x75,
-	0x66, 0xeb, 0x80, 0xf5, 0xd9, 0xb5, 0xe5, 0x68, 0x68, 0xc5, 0x65, 0xc2, 0x54, 0x88, 0x7d, 0x5b,
-	0x21, 0xde, 0xa7, 0xd7, 0x4e, 0x76, 0xa0, 0x46, 0x25, 0xfb, 0x66, 0x82, 0x34, 0xd1, 0xb8, 0xe5,
-	0x6c, 0xdc, 0x83, 0xb5, 0xa5, 0x2d, 0xdf, 0xf1, 0x4e, 0x48, 0xeb, 0x59, 0x36, 0xcb, 0x6e, 0x41,
-	0xd9, 0x34, 0x9f, 0x18, 0x12, 0x38, 0xb2, 0x62, 0x68, 0x4c, 0x1d, 0xc3, 0x71, 0xfc, 0x72, 0xe9,
-	0x1f, 0x3b, 0xfb, 0x50, 0x36, 0x4f, 0x33, 0xb6, 0x03, 0x15, 0xd7, 0x33, 0xe9, 0x98, 0x29, 0x09,
-	0xc8, 0x3c, 0x20, 0x32, 0x8f, 0xd9, 0xce, 0xdf, 0xf2, 0x00, 0x29, 0xfd, 0x3d, 0x3a, 0xd6, 0xaf,
-	0x61, 0x3d, 0x12, 0x9e, 0x0c, 0x46, 0xae, 0x5a, 0x10, 0xd7, 0x3e, 0x41, 0x2e, 0x5b, 0x72, 0x01,
-	0x99, 0xe9, 0x5e, 0x0b, 0x6f, 0xef, 0x5e, 0x77, 0xa0, 0xe8, 0xc9, 0x70, 0x61, 0x2f, 0x0a, 0xb6,
-	0x7c, 0x90, 0x8e, 0x0c, 0x17, 0xf8, 0x10, 0x45, 0x04, 0x6b, 0x41, 0x79, 0x76, 0x46, 0x8f, 0x55,
-	0xd3, 0xe8, 0x5f, 0x5d, 0xc6, 0x3e, 0x38, 0xc3, 0x31, 0x3e, 0x6d, 0x0d, 0x8a, 0xdd, 0x84, 0xd2,
-	0xec, 0x6c, 0xe4, 0x2b, 0xea, 0x7b, 0xeb, 0xa6, 0x33, 0xcc, 0xc2, 0xbb, 0xbe, 0xc2, 0x07, 0x2c,
-	0x61, 0x98, 0x03, 0x79, 0x35, 0xa3, 0x5e, 0xbf, 0x6e, 0x5e, 0x31, 0x19, 0x6b, 0xce, 0x0e, 0x57,
-	0x78, 0x5e, 0xcd, 0xda, 0x55, 0x28, 0x1b, 0xbb, 0x3a, 0x7f, 0x2a, 0xc0, 0xfa, 0xb2, 0x96, 0x18,
-	0x07, 0x91, 0xf2, 0xe2, 0x38, 0x88, 0x94, 0x97, 0x34, 0xf6, 0xf9, 0x4c, 0x63, 0xef, 0x40, 0x49,
-	0x3e, 0x0f, 0x84, 0xca, 0xbe, 0xca, 0x3b, 0xa7, 0xf2, 0x79, 0x80, 0x6d, 0xaa, 0x61, 0x2d, 0x75,
-	0x7d, 0x25, 0xdb, 0xf5, 0x5d, 0x87, 0xb5, 0xb1, 0x9c, 0x4e, 0xe5, 0xf3, 0xe1, 0x62, 0x36, 0xf5,
-	0x83, 0x33, 0xdb, 0xfa, 0x2d, 0x13, 0xd9, 0x0e, 0x5c, 0x19, 0xf9, 0x0a, 0xd5, 0xe9, 0xc8, 0x40,
-	0x8b, 0x80, 0xde, 0x39, 0x88, 0xbb, 0x48, 0x66, 0xdf, 0xc0, 0xb6, 0xab, 0xb5, 0x98, 0x85, 0xfa,
-	0x51, 0x10, 0xba, 0xde, 0x59, 0x57, 0x7a, 0x94, 0xb3, 0xb3, 0xd0, 0xd5, 0xfe, 0x89, 0x3f, 0xc5,
-	0x27, 0x5d, 0x85, 0x96, 0xbe, 0x15, 0xc7, 0x3e, 0x87, 0x75, 0x4f, 0x09, 0x57, 0x8b, 0xae, 0x88,
-	0xf4, 0xb1, 0xab, 0x4f, 0x9b, 0x55, 0x5a, 0x79, 0x81, 0x8a, 0x67, 0x70, 0x51, 0xdb, 0x27, 0xfe,
-	0x74, 0xe4, 0xb9, 0x6a, 0xd4, 0xac, 0x99, 0x33, 0x2c, 0x11, 0x59, 0x0b, 0x18, 0x11, 0x7a, 0xb3,
-	0x50, 0x2f, 0x12, 0x28, 0x10, 0xf4, 0x12, 0x0e, 0x16, 0x4e, 0xed, 0xcf, 0x44, 0xa4, 0xdd, 0x59,
-	0x48, 0x7f, 0x13, 0x0a, 0x3c, 0x25, 0x38, 0xdf, 0xe6, 0xa0, 0x71, 0x31, 0x44, 0xd0, 0xc0, 0x21,
-	0xaa, 0x69, 0x93, 0x0d, 0xc7, 0x89, 0xd1, 0xf3, 0x19, 0xa3, 0xc7, 0x37, 0x54, 0x21, 0x73, 0x43,
-	0x25, 0x0e, 0x2c, 0xbe, 0xd9, 0x81, 0x4b, 0x2a, 0x95, 0x2e, 0xaa, 0xf4, 0xfb, 0x1c, 0x5c, 0xb9,
-	0x10, 0x86, 0xef, 0xac, 0xd1, 0x36, 0xd4, 0x67, 0xee, 0x99, 0x38, 0x76, 0x15, 0x39, 0xb7, 0x60,
-	0x5a, 0xb8, 0x0c, 0xe9, 0x7f, 0xa0, 0x5f, 0x00, 0xab, 0xd9, 0xd8, 0xbf, 0x54, 0xb7, 0xd8, 0x95,
-	0x47, 0x52, 0xdf, 0x95, 0x73, 0x7b, 0xfb, 0xc5, 0xae, 0x8c, 0x89, 0xaf, 0x3b, 0xbc, 0x70, 0x89,
-	0xc3, 0x9d, 0x23, 0xa8, 0xc6, 0x0a, 0xb2, 0x2d, 0xfb, 0xc4, 0xcf, 0xa5, 0xbf, 0x9a, 0x1e, 0x45,
-	0x42, 0xa1, 0xee, 0xe6, 0xbd, 0xff, 0x29, 0x94, 0x26, 0x4a, 0xce, 0x43, 0x5b, 0x5b, 0x97, 0x10,
-	0x86, 0xe3, 0x0c, 0xa1, 0x62, 0x29, 0x6c, 0x17, 0xca, 0x27, 0x8b, 0xa3, 0xb8, 0xf9, 0xb0, 0x89,
-	0x8d, 0xf3, 0x91, 0x45, 0x60, 0xb5, 0x30, 0x08, 0x76, 0x15, 0x8a, 0x27, 0x8b, 0x7e, 0xd7, 0x3c,
-	0xc8, 0xb0, 0xe6, 0xe0, 0xac, 0x5d, 0x36, 0x0a, 0x39, 0xf7, 0x61, 0x35, 0xbb, 0x0e, 0x8d, 0x92,
-	0x69, 0x6a, 0x68, 0x9c, 0x16, 0xd7, 0xfc, 0x5b, 0x8a, 0xeb, 0xee, 0x0e, 0x54, 0xec, 0xcf, 0x14,
-	0x56, 0x83, 0xd2, 0xa3, 0xa3, 0x61, 0xef, 0x61, 0x63, 0x85, 0x55, 0xa1, 0x78, 0x38, 0x18, 0x3e,
-	0x6c, 0xe4, 0x70, 0x74, 0x34, 0x38, 0xea, 0x35, 0xf2, 0xbb, 0x37, 0x60, 0x35, 0xfb, 0x3b, 0x85,
-	0xd5, 0xa1, 0x32, 0x3c, 0x38, 0xea, 0xb6, 0x07, 0xbf, 0x6e, 0xac, 0xb0, 0x55, 0xa8, 0xf6, 0x8f,
-	0x86, 0xbd, 0xce, 0x23, 0xde, 0x6b, 0xe4, 0x76, 0x7f, 0x05, 0xb5, 0xe4, 0x55, 0x8f, 0x12, 0xda,
-	0xfd, 0xa3, 0x6e, 0x63, 0x85, 0x01, 0x94, 0x87, 0xbd, 0x0e, 0xef, 0xa1, 0xdc, 0x0a, 0x14, 0x86,
-	0xc3, 0xc3, 0x46, 0x1e, 0x77, 0xed, 0x1c, 0x74, 0x0e, 0x7b, 0x8d, 0x02, 0x0e, 0x1f, 0x3e, 0x38,
-	0xbe, 0x3b, 0x6c, 0x14, 0x77, 0xbf, 0x84, 0x2b, 0x17, 0x5e, 0xce, 0xb4, 0xfa, 0xf0, 0x80, 0xf7,
-	0x50, 0x52, 0x1d, 0x2a, 0xc7, 0xbc, 0xff, 0xf8, 0xe0, 0x61, 0xaf, 0x91, 0x43, 0xc6, 0xfd, 0x41,
-	0xe7, 0x5e, 0xaf, 0xdb, 0xc8, 0xb7, 0xaf, 0x7d, 0xf7, 0x72, 0x33, 0xf7, 0xfd, 0xcb, 0xcd, 0xdc,
-	0x0f, 0x2f, 0x37, 0x73, 0xff, 0x7c, 0xb9, 0x99, 0xfb, 0xf6, 0xd5, 0xe6, 0xca, 0xf7, 0xaf, 0x36,
-	0x57, 0x7e, 0x78, 0xb5, 0xb9, 0x72, 0x52, 0xa6, 0x9f, 0x9b, 0x5f, 0xfc, 0x27, 0x00, 0x00, 0xff,
-	0xff, 0xa4, 0x50, 0x4f, 0x17, 0x1c, 0x15, 0x00, 0x00,
+	// 2526 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0xcf, 0x6f, 0x1b, 0xc7,
+	0xf5, 0x17, 0x97, 0xbf, 0x1f, 0x25, 0x9a, 0xdf, 0x89, 0x93, 0x6c, 0xf4, 0x75, 0x65, 0x65, 0xe3,
+	0x06, 0xb2, 0x6c, 0x4b, 0xad, 0x02, 0xc4, 0x81, 0x51, 0x04, 0x95, 0x44, 0x1a, 0x62, 0x62, 0x8b,
+	0xea, 0xd0, 0x76, 0x7a, 0x28, 0x60, 0xac, 0x96, 0x43, 0x69, 0x21, 0x72, 0x67, 0x31, 0x3b, 0xb4,
+	0xc5, 0x4b, 0x0f, 0xf9, 0x0b, 0x02, 0xb4, 0xc8, 0xa5, 0xe8, 0x7f, 0xd1, 0x6b, 0x6f, 0x3d, 0xe4,
+	0x98, 0x43, 0x0f, 0x41, 0x0f, 0x69, 0xe1, 0x5c, 0x7a, 0xea, 0x7f, 0x50, 0xa0, 0x78, 0x6f, 0x66,
+	0x7f, 0x50, 0xb6, 0x6b, 0x1b, 0x2d, 0x7a, 0xe2, 0xcc, 0xe7, 0x7d, 0xde, 0x9b, 0xb7, 0x33, 0x6f,
+	0xde, 0xbc, 0x19, 0x42, 0x53, 0xc6, 0xc9, 0x56, 0xac, 0xa4, 0x96, 0xcc, 0x89, 0x8f, 0x57, 0x6f,
+	0x9d, 0x84, 0xfa, 0x74, 0x76, 0xbc, 0x15, 0xc8, 0xe9, 0xf6, 0x89, 0x3c, 0x91, 0xdb, 0x24, 0x3a,
+	0x9e, 0x8d, 0xa9, 0x47, 0x1d, 0x6a, 0x19, 0x15, 0xef, 0xef, 0x0e, 0x38, 0x83, 0x98, 0xbd, 0x0f,
+	0xb5, 0x30, 0x8a, 0x67, 0x3a, 0x71, 0x4b, 0xeb, 0xe5, 0x8d, 0xd6, 0x4e, 0x73, 0x2b, 0x3e, 0xde,
+	0xea, 0x23, 0xc2, 0xad, 0x80, 0xad, 0x43, 0x45, 0x9c, 0x8b, 0xc0, 0x75, 0xd6, 0x4b, 0x1b, 0xad,
+	0x1d, 0x40, 0x42, 0xef, 0x5c, 0x04, 0x83, 0xf8, 0x60, 0x89, 0x93, 0x84, 0x7d, 0x08, 0xb5, 0x44,
+	0xce, 0x54, 0x20, 0xdc, 0x32, 0x71, 0x96, 0x91, 0x33, 0x24, 0x84, 0x58, 0x56, 0x8a, 0x96, 0xc6,
+	0xe1, 0x44, 0xb8, 0x95, 0xdc, 0xd2, 0xdd, 0x70, 0x62, 0x38, 0x24, 0x61, 0x1f, 0x40, 0xf5, 0x78,
+	0x16, 0x4e, 0x46, 0x6e, 0x95, 0x28, 0x2d, 0xa4, 0xec, 0x21, 0x40, 0x1c, 0x23, 0x43, 0xd2, 0x54,
+	0xa8, 0x13, 0xe1, 0xd6, 0x72, 0xd2, 0x7d, 0x04, 0x0c, 0x89, 0x64, 0x38, 0xd6, 0x28, 0x1c, 0x8f,
+	0xdd, 0x7a, 0x3e, 0x56, 0x37, 0x1c, 0x8f, 0xcd, 0x58, 0x28, 0x61, 0x1b, 0xd0, 0x88, 0x27, 0xbe,
+	0x1e, 0x4b, 0x35, 0x75, 0x21, 0xf7, 0xfb, 0xc8, 0x62, 0x3c, 0x93, 0xb2, 0xdb, 0xd0, 0x0a, 0x64,
+	0x94, 0x68, 0xe5, 0x87, 0x91, 0x4e, 0xdc, 0x16, 0x91, 0xdf, 0x46, 0xf2, 0x17, 0x52, 0x9d, 0x09,
+	0xb5, 0x9f, 0x0b, 0x79, 0x91, 0xb9, 0x57, 0x01, 0x47, 0xc6, 0xde, 0xd7, 0x25, 0x68, 0xa4, 0x56,
+	0x99, 0x07, 0xcb, 0xbb, 0x2a, 0x38, 0x0d, 0xb5, 0x08, 0xf4, 0x4c, 0x09, 0xb7, 0xb4, 0x5e, 0xda,
+	0x68, 0xf2, 0x05, 0x8c, 0xb5, 0xc1, 0x19, 0x0c, 0x69, 0xbe, 0x9b, 0xdc, 0x19, 0x0c, 0x99, 0x0b,
+	0xf5, 0x47, 0xbe, 0x0a, 0xfd, 0x48, 0xd3, 0x04, 0x37, 0x79, 0xda, 0x65, 0x57, 0xa0, 0x39, 0x18,
+	0x3e, 0x12, 0x2a, 0x09, 0x65, 0x44, 0xd3, 0xda, 0xe4, 0x39, 0xc0, 0xd6, 0x00, 0x06, 0xc3, 0xbb,
+	0xc2, 0x47, 0xa3, 0x89, 0x5b, 0x5d, 0x2f, 0x6f, 0x34, 0x79, 0x01, 0xf1, 0x7e, 0x0d, 0x55, 0x5a,
+	0x6a, 0xf6, 0x19, 0xd4, 0x46, 0xe1, 0x89, 0x48, 0xb4, 0x71, 0x67, 0x6f, 0xe7, 0x9b, 0xef, 0xaf,
+	0x2e, 0xfd, 0xe5, 0xfb, 0xab, 0x9b, 0x85, 0x98, 0x92, 0xb1, 0x88, 0x02, 0x19, 0x69, 0x3f, 0x8c,
+	0x84, 0x4a, 0xb6, 0x4f, 0xe4, 0x2d, 0xa3, 0xb2, 0xd5, 0xa5, 0x1f, 0x6e, 0x2d, 0xb0, 0xeb, 0x50,
+	0x0d, 0xa3, 0x91, 0x38, 0x27, 0xff, 0xcb, 0x7b, 0x6f, 0x59, 0x53, 0xad, 0xc1, 0x4c, 0xc7, 0x33,
+	0xdd, 0x47, 0x11, 0x37, 0x0c, 0xef, 0x4f, 0x25, 0xa8, 0x99, 0x50, 0x62, 0x57, 0xa0, 0x32, 0x15,
+	0xda, 0xa7, 0xf1, 0x5b, 0x3b, 0x0d, 0xb3, 0xa4, 0xda, 0xe7, 0x84, 0x62, 0x94, 0x4e, 0xe5, 0x0c,
+	0xe7, 0xde, 0xc9, 0xa3, 0xf4, 0x3e, 0x22, 0xdc, 0x0a, 0xd8, 0x8f, 0xa1, 0x1e, 0x09, 0xfd, 0x54,
+	0xaa, 0x33, 0x9a, 0xa3, 0xb6, 0x09, 0x8b, 0x43, 0xa1, 0xef, 0xcb, 0x91, 0xe0, 0xa9, 0x8c, 0xdd,
+	0x84, 0x46, 0x22, 0x82, 0x99, 0x0a, 0xf5, 0x9c, 0xe6, 0xab, 0xbd, 0xd3, 0xa1, 0x60, 0xb5, 0x18,
+	0x91, 0x33, 0x06, 0xbb, 0x06, 0xf5, 0x91, 0x78, 0x12, 0x06, 0x76, 0xf6, 0xd2, 0x38, 0x22, 0x88,
+	0xa7, 0x22, 0xef, 0x77, 0x0e, 0x54, 0xd0, 0x59, 0xc6, 0xa0, 0xe2, 0xab, 0x13, 0xb3, 0x95, 0x9a,
+	0x9c, 0xda, 0xac, 0x03, 0x65, 0x11, 0x3d, 0x21, 0xbf, 0x9b, 0x1c, 0x9b, 0x88, 0x04, 0x4f, 0x47,
+	0x76, 0x25, 0xb1, 0x89, 0x7a, 0xb3, 0x44, 0x28, 0xbb, 0x80, 0xd4, 0x66, 0xd7, 0xa1, 0x19, 0x2b,
+	0x79, 0x3e, 0x7f, 0x8c, 0xda, 0xd5, 0x42, 0x78, 0x22, 0xd8, 0x8b, 0x9e, 0xf0, 0x46, 0x6c, 0x5b,
+	0x6c, 0x13, 0x40, 0x9c, 0x6b, 0xe5, 0x1f, 0xc8, 0x44, 0x27, 0x6e, 0x2d, 0x77, 0x14, 0x81, 0xfe,
+	0x11, 0x2f, 0x48, 0xd9, 0x2a, 0x34, 0x4e, 0x65, 0xa2, 0x23, 0x7f, 0x2a, 0x68, 0x6b, 0x34, 0x79,
+	0xd6, 0x67, 0x1e, 0xd4, 0x66, 0x93, 0x70, 0x1a, 0x6a, 0xb7, 0x91, 0xdb, 0x78, 0x48, 0x08, 0xb7,
+	0x12, 0xf6, 0x13, 0x68, 0x2a, 0x61, 0xb6, 0x73, 0xe2, 0x36, 0xc9, 0x2d, 0x86, 0x34, 0x6e, 0xc1,
+	0x7b, 0xc8, 0x4a, 0x78, 0x4e, 0xf2, 0xfe, 0xe1, 0x40, 0x95, 0x96, 0x8a, 0x6d, 0x60, 0x64, 0xc4,
+	0x33, 0x13, 0x64, 0xe5, 0x3d, 0x66, 0x23, 0x03, 0xfa, 0x51, 0x31, 0x30, 0x30, 0x1e, 0x57, 0x71,
+	0x95, 0x26, 0x22, 0xd0, 0x52, 0xd9, 0x6d, 0x90, 0xf5, 0x71, 0xb2, 0x46, 0x18, 0xa9, 0x66, 0xfe,
+	0xa8, 0xcd, 0x6e, 0x40, 0x4d, 0x52, 0x78, 0xb9, 0x95, 0x97, 0x07, 0x9d, 0xa5, 0xa0, 0x71, 0x25,
+	0xfc, 0x91, 0x8c, 0x26, 0x73, 0x9a, 0xd8, 0x06, 0xcf, 0xfa, 0xec, 0x06, 0x34, 0x29, 0x9e, 0x1e,
+	0xcc, 0x63, 0x93, 0x5e, 0xda, 0x3b, 0x2b, 0x59, 0xac, 0x21, 0xc8, 0x73, 0x39, 0x26, 0x90, 0xc0,
+	0x0f, 0x4e, 0xc5, 0x20, 0xd6, 0xee, 0xe5, 0x7c, 0x85, 0xf6, 0x2d, 0xc6, 0x33, 0x29, 0x9a, 0x4d,
+	0x44, 0xa0, 0x84, 0x46, 0xea, 0xdb, 0x44, 0x5d, 0xb1, 0x61, 0x67, 0x40, 0x9e, 0xcb, 0x71, 0x19,
+	0x86, 0xc3, 0x03, 0x64, 0xbe, 0x93, 0xe7, 0x2e, 0x83, 0x70, 0x2b, 0x31, 0xdf, 0x90, 0xcc, 0x26,
+	0xba, 0xdf, 0x75, 0xdf, 0x35, 0x13, 0x94, 0xf6, 0xbd, 0x3e, 0x34, 0x52, 0x17, 0x30, 0x93, 0xf4,
+	0xbb, 0x36, 0xc7, 0x38, 0xfd, 0x2e, 0xbb, 0x05, 0xf5, 0xe4, 0xd4, 0x57, 0x61, 0x74, 0x42, 0xf3,
+	0xda, 0xde, 0x79, 0x2b, 0xf3, 0x78, 0x68, 0x70, 0x1c, 0x25, 0xe5, 0x78, 0x12, 0x9a, 0x99, 0x8b,
+	0xcf, 0xd9, 0xea, 0x40, 0x79, 0x16, 0x8e, 0xc8, 0xce, 0x0a, 0xc7, 0x26, 0x22, 0x27, 0xa1, 0x89,
+	0xec, 0x15, 0x8e, 0x4d, 0x5c, 0xac, 0xa9, 0x1c, 0x99, 0x8c, 0xbf, 0xc2, 0xa9, 0x8d, 0xbe, 0xcb,
+	0x58, 0x87, 0x32, 0xf2, 0x27, 0xe9, 0xfc, 0xa7, 0x7d, 0x6f, 0x92, 0x7e, 0xfb, 0xff, 0x64, 0xb4,
+	0x4f, 0xa1, 0x66, 0xf6, 0x32, 0x6a, 0xc6, 0xbe, 0x3e, 0xb5, 0xe3, 0x51, 0x9b, 0xad, 0x43, 0x2b,
+	0x16, 0x6a, 0x1a, 0x26, 0x98, 0x4b, 0x13, 0x1b, 0x87, 0x45, 0xc8, 0xfb, 0x4d, 0x09, 0x1a, 0xe9,
+	0x31, 0x87, 0xc9, 0x36, 0x1c, 0x89, 0x48, 0x87, 0xe3, 0x50, 0x28, 0x6b, 0xa8, 0x80, 0xb0, 0x5b,
+	0x50, 0xf5, 0xb5, 0x56, 0x69, 0x0a, 0x7b, 0xb7, 0x78, 0x46, 0x6e, 0xed, 0xa2, 0xa4, 0x17, 0x69,
+	0x35, 0xe7, 0x86, 0xb5, 0xfa, 0x09, 0x40, 0x0e, 0xe2, 0xb7, 0x9e, 0x89, 0xb9, 0xb5, 0x8a, 0x4d,
+	0x76, 0x19, 0xaa, 0x4f, 0xfc, 0xc9, 0x4c, 0x58, 0xbf, 0x4c, 0xe7, 0x8e, 0xf3, 0x49, 0xc9, 0xfb,
+	0xa3, 0x03, 0x75, 0x7b, 0x66, 0xb2, 0x9b, 0x50, 0xa7, 0x33, 0x53, 0xa8, 0x7f, 0xb3, 0xe9, 0x52,
+	0x0a, 0xdb, 0xce, 0x8a, 0x81, 0x82, 0x8f, 0xd6, 0x94, 0x29, 0x0a, 0xac, 0x8f, 0x79, 0x69, 0x50,
+	0x1e, 0x89, 0xb1, 0x3d, 0xf5, 0xdb, 0x26, 0x37, 0x8e, 0xc3, 0x28, 0xc4, 0xf9, 0xe5, 0x28, 0x62,
+	0x37, 0xd3, 0xaf, 0xae, 0x90, 0xc5, 0x77, 0x8a, 0x16, 0x9f, 0xff, 0xe8, 0x3e, 0xb4, 0x0a, 0xc3,
+	0xbc, 0xe0, 0xab, 0xaf, 0x15, 0xbf, 0xda, 0x0e, 0x49, 0xe6, 0x48, 0xad, 0x30, 0x0b, 0xff, 0xc1,
+	0xfc, 0xfd, 0x14, 0xea, 0xb6, 0x9a, 0xc0, 0xc2, 0x66, 0xa1, 0x3a, 0x6a, 0x67, 0xa5, 0xc6, 0x42,
+	0x89, 0xe4, 0x7d, 0x0c, 0x90, 0xa3, 0xaf, 0x9f, 0xe7, 0xbc, 0x5f, 0x41, 0xcd, 0x14, 0x25, 0xa8,
+	0x33, 0x91, 0x4f, 0xed, 0x32, 0xd9, 0x9c, 0x7a, 0x0f, 0x01, 0x94, 0xdb, 0x8f, 0x23, 0x02, 0x32,
+	0x67, 0x71, 0x2c, 0x94, 0xeb, 0xe4, 0xcc, 0x87, 0x71, 0xbc, 0xc0, 0x24, 0x82, 0x77, 0x07, 0xda,
+	0x8b, 0x26, 0xde, 0xc0, 0xb3, 0x3b, 0xd0, 0x5e, 0x34, 0xfa, 0x06, 0xba, 0x1f, 0x03, 0xe4, 0x6b,
+	0xf2, 0x06, 0x7a, 0x5f, 0x96, 0x01, 0x06, 0x31, 0x9e, 0xa4, 0x23, 0x9f, 0x0e, 0xfd, 0xe5, 0xf0,
+	0x24, 0x92, 0x4a, 0x3c, 0xa6, 0x3c, 0x4a, 0xfa, 0x0d, 0xde, 0x32, 0x18, 0xa5, 0x2c, 0xb6, 0x0b,
+	0xad, 0x91, 0x48, 0x02, 0x15, 0xd2, 0x8e, 0xb6, 0x51, 0x7b, 0x15, 0x67, 0x24, 0xb7, 0xb3, 0xd5,
+	0xcd, 0x19, 0x26, 0xd8, 0x8a, 0x3a, 0x6c, 0x07, 0x96, 0xc5, 0x79, 0x2c, 0x95, 0xb6, 0xa3, 0x98,
+	0xda, 0xf4, 0x92, 0xa9, 0x72, 0x11, 0xa7, 0x91, 0x78, 0x4b, 0xe4, 0x1d, 0xe6, 0x43, 0x25, 0xf0,
+	0xe3, 0xb4, 0x26, 0x70, 0x2f, 0x8c, 0xb7, 0xef, 0xc7, 0x26, 0xea, 0xf6, 0x3e, 0xc2, 0x6f, 0xfd,
+	0xf2, 0xaf, 0x57, 0x6f, 0x14, 0xca, 0xa8, 0xa9, 0x3c, 0x9e, 0x6f, 0xd3, 0x86, 0x3b, 0x0b, 0xf5,
+	0xf6, 0x4c, 0x87, 0x93, 0x6d, 0x3f, 0x0e, 0xd1, 0x1c, 0x2a, 0xf6, 0xbb, 0x9c, 0x4c, 0xaf, 0x7e,
+	0x0a, 0x9d, 0x8b, 0x7e, 0xbf, 0x49, 0x10, 0xaf, 0xde, 0x86, 0x66, 0xe6, 0xc7, 0xab, 0x14, 0x1b,
+	0xc5, 0xe8, 0xff, 0x43, 0x09, 0x6a, 0x26, 0x2d, 0xb1, 0xdb, 0xd0, 0x9c, 0xc8, 0xc0, 0xd7, 0x94,
+	0xfe, 0xcc, 0x06, 0x78, 0x2f, 0xcf, 0x5a, 0x5b, 0xf7, 0x52, 0x99, 0x99, 0xd5, 0x9c, 0x8b, 0xbb,
+	0x34, 0x8c, 0xc6, 0x32, 0x4d, 0x23, 0xed, 0x5c, 0xa9, 0x1f, 0x8d, 0x25, 0x37, 0xc2, 0xd5, 0xcf,
+	0x31, 0x3c, 0x8b, 0x26, 0x5e, 0xe0, 0xe7, 0x07, 0x8b, 0xfb, 0x7d, 0xc5, 0x6c, 0x0b, 0xab, 0x54,
+	0x74, 0xfb, 0x36, 0x34, 0x33, 0x9c, 0x6d, 0x3e, 0xef, 0xf8, 0x72, 0x51, 0xb3, 0xe0, 0xab, 0x37,
+	0x01, 0xc8, 0x5d, 0xc3, 0xd3, 0x02, 0xef, 0x21, 0x54, 0x1e, 0x19, 0x37, 0xb2, 0x3e, 0x15, 0x1e,
+	0xbe, 0xf6, 0xc9, 0x95, 0x65, 0x4e, 0x6d, 0xb6, 0x05, 0x30, 0xca, 0x32, 0xde, 0x4b, 0xf2, 0x60,
+	0x81, 0xe1, 0x0d, 0xa0, 0x91, 0x3a, 0x81, 0xe7, 0x4b, 0x62, 0x47, 0xc6, 0x72, 0x19, 0x87, 0xab,
+	0xf2, 0x22, 0x84, 0x65, 0xaf, 0xf2, 0xa3, 0x13, 0xb1, 0x50, 0xf6, 0x72, 0x44, 0xb8, 0x15, 0x78,
+	0x5f, 0x40, 0x95, 0x00, 0xdc, 0x66, 0x89, 0xf6, 0x95, 0xb6, 0x09, 0xc4, 0xd4, 0x8a, 0x32, 0xa1,
+	0x61, 0xf7, 0x2a, 0x18, 0x88, 0xdc, 0x10, 0xd8, 0x35, 0xac, 0x48, 0x47, 0xae, 0xf3, 0x52, 0x1e,
+	0x8a, 0xbd, 0x9f, 0x41, 0x23, 0x85, 0xf1, 0xcb, 0xef, 0x85, 0x91, 0xb0, 0x2e, 0x52, 0x1b, 0x6f,
+	0x1e, 0xfb, 0xa7, 0xbe, 0xf2, 0x03, 0x6d, 0x53, 0x51, 0x95, 0xe7, 0x80, 0xf7, 0x01, 0xb4, 0x0a,
+	0xbb, 0x07, 0xc3, 0xed, 0x11, 0x2d, 0xa3, 0xd9, 0xc3, 0xa6, 0xe3, 0x7d, 0x89, 0xf7, 0xa2, 0xb4,
+	0x88, 0xfd, 0x11, 0xc0, 0xa9, 0xd6, 0xf1, 0x63, 0xaa, 0x6a, 0xed, 0xdc, 0x37, 0x11, 0x21, 0x06,
+	0xbb, 0x0a, 0x2d, 0xec, 0x24, 0x56, 0x6e, 0xe2, 0x9d, 0x34, 0x12, 0x43, 0xf8, 0x7f, 0x68, 0x8e,
+	0x33, 0xf5, 0xb2, 0x5d, 0xba, 0x54, 0xfb, 0x3d, 0x68, 0x44, 0xd2, 0xca, 0x4c, 0x91, 0x5d, 0x8f,
+	0x24, 0x89, 0xbc, 0x1b, 0xf0, 0x7f, 0xcf, 0x5d, 0xe2, 0xd8, 0x3b, 0x50, 0x1b, 0x87, 0x13, 0x4d,
+	0xe9, 0x18, 0xeb, 0x76, 0xdb, 0xf3, 0xfe, 0x59, 0x02, 0xc8, 0x57, 0x96, 0x75, 0xcc, 0xf1, 0x87,
+	0x9c, 0x65, 0x73, 0xdc, 0x4d, 0xa0, 0x31, 0xb5, 0x79, 0xc0, 0xae, 0xd9, 0x95, 0xc5, 0x68, 0xd8,
+	0x4a, 0xd3, 0x84, 0xc9, 0x10, 0x3b, 0x36, 0x43, 0xbc, 0xc9, 0x45, 0x2b, 0x1b, 0x81, 0x2a, 0xc5,
+	0xe2, 0xbd, 0x1b, 0xf2, 0x8d, 0xc6, 0xad, 0x64, 0xf5, 0x73, 0x58, 0x59, 0x18, 0xf2, 0x35, 0x0f,
+	0xd5, 0x3c, 0x9f, 0x15, 0x77, 0xd9, 0x4d, 0xa8, 0x99, 0x3b, 0x05, 0x86, 0x04, 0xb6, 0xd2, 0x82,
+	0x09, 0xdb, 0x54, 0xb2, 0x1d, 0xa5, 0xd7, 0xd6, 0xfe, 0x91, 0xd7, 0x85, 0x9a, 0xb9, 0x3d, 0x20,
+	0xfb, 0x30, 0xdf, 0x52, 0xd4, 0x46, 0x6c, 0x28, 0xc7, 0x9a, 0xf8, 0x65, 0x4e, 0x6d, 0xb2, 0xea,
+	0x2b, 0x53, 0xd3, 0x95, 0x39, 0xb5, 0xbd, 0xdf, 0x96, 0xa0, 0xbd, 0x78, 0xbb, 0xc0, 0xe5, 0x99,
+	0x8a, 0xa9, 0x54, 0xe6, 0x2b, 0xca, 0xdc, 0xf6, 0x30, 0x26, 0x83, 0x78, 0x86, 0x85, 0xac, 0x48,
+	0xac, 0xdd, 0x1c, 0xc0, 0xbd, 0x1d, 0xc4, 0xb3, 0x5f, 0xcc, 0xa4, 0xf6, 0xed, 0x00, 0x59, 0xdf,
+	0x6a, 0x1e, 0x09, 0x15, 0xca, 0x91, 0x5b, 0xc9, 0x34, 0x0d, 0x40, 0xd5, 0x61, 0x38, 0x4a, 0xa8,
+	0x7e, 0x2c, 0x73, 0x6a, 0x7b, 0x3b, 0x50, 0x33, 0x6f, 0x17, 0x6c, 0x03, 0xea, 0x7e, 0xa0, 0x6d,
+	0x8d, 0x98, 0xe5, 0x3b, 0x14, 0xee, 0x12, 0xcc, 0x53, 0xb1, 0xf7, 0x67, 0x07, 0x20, 0xc7, 0xdf,
+	0xe0, 0x3e, 0x74, 0x07, 0xda, 0x89, 0x08, 0x64, 0x34, 0xf2, 0xd5, 0x9c, 0xa4, 0xae, 0xf3, 0x52,
+	0x95, 0x0b, 0xcc, 0xc2, 0xdd, 0xa8, 0xfc, 0xea, 0xbb, 0xd1, 0x06, 0x54, 0x02, 0x19, 0xcf, 0xdd,
+	0x4a, 0x5e, 0x5b, 0xe4, 0x0e, 0xef, 0xcb, 0x78, 0x8e, 0xaf, 0x27, 0xc8, 0x60, 0x5b, 0x50, 0x9b,
+	0x9e, 0xd1, 0x6b, 0x8e, 0xb9, 0x9c, 0x5e, 0x5e, 0xe4, 0xde, 0x3f, 0xc3, 0x36, 0xbe, 0xfd, 0x18,
+	0x16, 0xbb, 0x01, 0xd5, 0xe9, 0xd9, 0x28, 0x54, 0xf6, 0xd1, 0xe6, 0xad, 0x8b, 0xf4, 0x6e, 0xa8,
+	0xe8, 0xf1, 0x06, 0x39, 0xcc, 0x03, 0x47, 0x4d, 0xed, 0xd3, 0x4d, 0xe7, 0xc2, 0x6c, 0x4e, 0x0f,
+	0x96, 0xb8, 0xa3, 0xa6, 0x7b, 0x0d, 0xa8, 0x99, 0x79, 0xf5, 0xbe, 0xae, 0x40, 0x7b, 0xd1, 0x4b,
+	0x0c, 0xf2, 0x44, 0x05, 0x69, 0x90, 0x27, 0x2a, 0xc8, 0xae, 0x8d, 0x4e, 0xe1, 0xda, 0xe8, 0x41,
+	0x55, 0x3e, 0x8d, 0x84, 0x2a, 0x3e, 0x5b, 0xed, 0x9f, 0xca, 0xa7, 0x11, 0x5e, 0x82, 0x8c, 0x68,
+	0xe1, 0x4e, 0x51, 0xb5, 0x77, 0x8a, 0x6b, 0xb0, 0x32, 0x96, 0x93, 0x89, 0x7c, 0x3a, 0x9c, 0x4f,
+	0x27, 0x61, 0x74, 0x66, 0x2f, 0x16, 0x8b, 0x20, 0xdb, 0x80, 0x4b, 0xa3, 0x50, 0xa1, 0x3b, 0xfb,
+	0x32, 0xd2, 0x22, 0xa2, 0xbb, 0x39, 0xf2, 0x2e, 0xc2, 0xec, 0x33, 0x58, 0xf7, 0xb5, 0x16, 0xd3,
+	0x58, 0x3f, 0x8c, 0x62, 0x3f, 0x38, 0xeb, 0xca, 0x80, 0x12, 0xd2, 0x34, 0xf6, 0x75, 0x78, 0x1c,
+	0x4e, 0xf0, 0xb1, 0xa2, 0x4e, 0xaa, 0xaf, 0xe4, 0xb1, 0x0f, 0xa1, 0x1d, 0x28, 0xe1, 0x6b, 0xd1,
+	0x15, 0x89, 0x3e, 0xc2, 0x3b, 0x4d, 0x83, 0x34, 0x2f, 0xa0, 0xf8, 0x0d, 0x3e, 0x7a, 0xfb, 0x45,
+	0x38, 0x19, 0x05, 0xb8, 0xe7, 0x9a, 0xe6, 0x1b, 0x16, 0x40, 0xb6, 0x05, 0x8c, 0x80, 0xde, 0x34,
+	0xd6, 0xf3, 0x8c, 0x0a, 0x44, 0x7d, 0x81, 0x04, 0xf7, 0x91, 0x0e, 0xa7, 0x22, 0xd1, 0xfe, 0x34,
+	0xa6, 0x77, 0xb2, 0x32, 0xcf, 0x01, 0x76, 0x1d, 0x3a, 0x61, 0x14, 0x4c, 0x66, 0x23, 0xf1, 0x38,
+	0xc6, 0x0f, 0x51, 0x51, 0xe2, 0x2e, 0x53, 0x82, 0xbd, 0x64, 0xf1, 0x23, 0x0b, 0x23, 0x55, 0x9c,
+	0x5f, 0xa0, 0xae, 0x18, 0xaa, 0x38, 0x5f, 0xa4, 0xba, 0x50, 0x8f, 0x7d, 0x45, 0xf3, 0xdb, 0x26,
+	0xc7, 0xd2, 0xae, 0xf7, 0x55, 0x09, 0x3a, 0x17, 0x43, 0xf2, 0x85, 0x57, 0xbd, 0x74, 0x91, 0x9d,
+	0xc2, 0x22, 0xa7, 0xc7, 0x7d, 0xb9, 0x70, 0xdc, 0x67, 0x01, 0x53, 0x79, 0x79, 0xc0, 0x2c, 0x4c,
+	0x41, 0xf5, 0xc2, 0x14, 0x78, 0xbf, 0x2f, 0xc1, 0xa5, 0x0b, 0x61, 0xff, 0xda, 0x1e, 0xad, 0x43,
+	0x6b, 0xea, 0x9f, 0x89, 0x23, 0xfb, 0xb1, 0x65, 0x53, 0x0f, 0x17, 0xa0, 0xff, 0x82, 0x7f, 0x11,
+	0x2c, 0x17, 0xf7, 0xda, 0x0b, 0x7d, 0x4b, 0x43, 0xe7, 0x50, 0xea, 0xbb, 0x72, 0x66, 0x4b, 0x89,
+	0x06, 0x5f, 0x04, 0x9f, 0x0f, 0xb0, 0xf2, 0x0b, 0x02, 0xcc, 0x3b, 0x84, 0x46, 0xea, 0x20, 0xbb,
+	0x6a, 0x9f, 0xc1, 0x4a, 0xf9, 0xb3, 0xee, 0xc3, 0x44, 0x28, 0xf4, 0x9d, 0x04, 0xec, 0x7d, 0xa8,
+	0x9e, 0x28, 0x39, 0x8b, 0x5d, 0xe7, 0x79, 0x86, 0x91, 0x78, 0x43, 0xa8, 0x5b, 0x84, 0x6d, 0x42,
+	0xed, 0x78, 0x9e, 0x1d, 0x3b, 0x36, 0x91, 0x60, 0x7f, 0x64, 0x19, 0x98, 0x9d, 0x0c, 0x83, 0x5d,
+	0x86, 0xca, 0xf1, 0xbc, 0xdf, 0x35, 0xcf, 0x0b, 0x98, 0xe3, 0xb0, 0xb7, 0x57, 0x33, 0x0e, 0x79,
+	0xf7, 0x60, 0xb9, 0xa8, 0x87, 0x93, 0x52, 0xa8, 0x10, 0xa9, 0x9d, 0x27, 0x73, 0xe7, 0x15, 0xc9,
+	0x7c, 0x73, 0x03, 0xea, 0xf6, 0x59, 0x92, 0x35, 0xa1, 0xfa, 0xf0, 0x70, 0xd8, 0x7b, 0xd0, 0x59,
+	0x62, 0x0d, 0xa8, 0x1c, 0x0c, 0x86, 0x0f, 0x3a, 0x25, 0x6c, 0x1d, 0x0e, 0x0e, 0x7b, 0x1d, 0x67,
+	0xf3, 0x3a, 0x2c, 0x17, 0x1f, 0x26, 0x59, 0x0b, 0xea, 0xc3, 0xdd, 0xc3, 0xee, 0xde, 0xe0, 0x97,
+	0x9d, 0x25, 0xb6, 0x0c, 0x8d, 0xfe, 0xe1, 0xb0, 0xb7, 0xff, 0x90, 0xf7, 0x3a, 0xa5, 0xcd, 0x9f,
+	0x43, 0x33, 0x7b, 0xa3, 0x42, 0x0b, 0x7b, 0xfd, 0xc3, 0x6e, 0x67, 0x89, 0x01, 0xd4, 0x86, 0xbd,
+	0x7d, 0xde, 0x43, 0xbb, 0x75, 0x28, 0x0f, 0x87, 0x07, 0x1d, 0x07, 0x47, 0xdd, 0xdf, 0xdd, 0x3f,
+	0xe8, 0x75, 0xca, 0xd8, 0x7c, 0x70, 0xff, 0xe8, 0xee, 0xb0, 0x53, 0xd9, 0xfc, 0x18, 0x2e, 0x5d,
+	0x78, 0x07, 0x22, 0xed, 0x83, 0x5d, 0xde, 0x43, 0x4b, 0x2d, 0xa8, 0x1f, 0xf1, 0xfe, 0xa3, 0xdd,
+	0x07, 0xbd, 0x4e, 0x09, 0x05, 0xf7, 0x06, 0xfb, 0x9f, 0xf7, 0xba, 0x1d, 0x67, 0xef, 0xca, 0x37,
+	0xcf, 0xd6, 0x4a, 0xdf, 0x3e, 0x5b, 0x2b, 0x7d, 0xf7, 0x6c, 0xad, 0xf4, 0xb7, 0x67, 0x6b, 0xa5,
+	0xaf, 0x7e, 0x58, 0x5b, 0xfa, 0xf6, 0x87, 0xb5, 0xa5, 0xef, 0x7e, 0x58, 0x5b, 0x3a, 0xae, 0xd1,
+	0xbf, 0x0d, 0x1f, 0xfd, 0x6b, 0x00, 0xaf, 0x3b, 0xcb, 0x8b, 0xad, 0x18, 0x00, 0x00,
 }
 
 func (m *Op) Marshal() (dAtA []byte, err error) {
@@ -2628,6 +3113,48 @@ func (m *Op_Build) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Op_Merge) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Op_Merge) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Merge != nil {
+		{
+			size, err := m.Merge.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Op_Diff) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Op_Diff) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Diff != nil {
+		{
+			size, err := m.Diff.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *Platform) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2743,6 +3270,20 @@ func (m *ExecOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Devices) > 0 {
+		for iNdEx := len(m.Devices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Devices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOps(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if m.Security != 0 {
 		i = encodeVarintOps(dAtA, i, uint64(m.Security))
 		i--
@@ -2802,6 +3343,32 @@ func (m *Meta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Resources != nil {
+		{
+			size, err := m.Resources.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.Ulimit) > 0 {
+		for iNdEx := len(m.Ulimit) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ulimit[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOps(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
 	if len(m.Hostname) > 0 {
 		i -= len(m.Hostname)
 		copy(dAtA[i:], m.Hostname)
@@ -3128,6 +3695,43 @@ func (m *SSHOpt) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Device) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Device) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Device) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Permissions) > 0 {
+		i -= len(m.Permissions)
+		copy(dAtA[i:], m.Permissions)
+		i = encodeVarintOps(dAtA, i, uint64(len(m.Permissions)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintOps(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SourceOp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3277,7 +3881,7 @@ func (m *BuildOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *BuildInput) Marshal() (dAtA []byte, err error) {
+func (m *MergeOp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3287,25 +3891,34 @@ func (m *BuildInput) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BuildInput) MarshalTo(dAtA []byte) (int, error) {
+func (m *MergeOp) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *BuildInput) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *MergeOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Input != 0 {
-		i = encodeVarintOps(dAtA, i, uint64(m.Input))
-		i--
-		dAtA[i] = 0x8
+	if len(m.Inputs) > 0 {
+		for iNdEx := len(m.Inputs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Inputs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOps(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *OpMetadata) Marshal() (dAtA []byte, err error) {
+func (m *MergeInput) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3315,25 +3928,184 @@ func (m *OpMetadata) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *OpMetadata) MarshalTo(dAtA []byte) (int, error) {
+func (m *MergeInput) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *OpMetadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *MergeInput) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Caps) > 0 {
-		keysForCaps := make([]string, 0, len(m.Caps))
-		for k := range m.Caps {
-			keysForCaps = append(keysForCaps, string(k))
-		}
-		github_com_gogo_protobuf_sortkeys.Strings(keysForCaps)
-		for iNdEx := len(keysForCaps) - 1; iNdEx >= 0; iNdEx-- {
-			v := m.Caps[github_com_moby_buildkit_util_apicaps.CapID(keysForCaps[iNdEx])]
-			baseI := i
+	if m.Input != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Input))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiffOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiffOp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiffOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Upper != nil {
+		{
+			size, err := m.Upper.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Lower != nil {
+		{
+			size, err := m.Lower.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LowerDiffInput) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LowerDiffInput) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LowerDiffInput) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Input != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Input))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpperDiffInput) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpperDiffInput) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpperDiffInput) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Input != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Input))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BuildInput) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BuildInput) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BuildInput) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Input != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Input))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *OpMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OpMetadata) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OpMetadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Caps) > 0 {
+		keysForCaps := make([]string, 0, len(m.Caps))
+		for k := range m.Caps {
+			keysForCaps = append(keysForCaps, string(k))
+		}
+		github_com_gogo_protobuf_sortkeys.Strings(keysForCaps)
+		for iNdEx := len(keysForCaps) - 1; iNdEx >= 0; iNdEx-- {
+			v := m.Caps[github_com_moby_buildkit_util_apicaps.CapID(keysForCaps[iNdEx])]
+			baseI := i
 			i--
 			if v {
 				dAtA[i] = 1
@@ -3899,6 +4671,94 @@ func (m *HostIP) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Ulimit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Ulimit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Ulimit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Hard != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Hard))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Soft != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Soft))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintOps(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResourceLimits) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResourceLimits) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResourceLimits) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Pids != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Pids))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.CpuPeriod != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.CpuPeriod))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.CpuQuota != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.CpuQuota))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.CpuShares != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.CpuShares))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Memory != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Memory))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *FileOp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4087,6 +4947,34 @@ func (m *FileActionCopy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Parents {
+		i--
+		if m.Parents {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x70
+	}
+	if len(m.ExcludePatterns) > 0 {
+		for iNdEx := len(m.ExcludePatterns) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ExcludePatterns[iNdEx])
+			copy(dAtA[i:], m.ExcludePatterns[iNdEx])
+			i = encodeVarintOps(dAtA, i, uint64(len(m.ExcludePatterns[iNdEx])))
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if len(m.IncludePatterns) > 0 {
+		for iNdEx := len(m.IncludePatterns) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.IncludePatterns[iNdEx])
+			copy(dAtA[i:], m.IncludePatterns[iNdEx])
+			i = encodeVarintOps(dAtA, i, uint64(len(m.IncludePatterns[iNdEx])))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
 	if m.Timestamp != 0 {
 		i = encodeVarintOps(dAtA, i, uint64(m.Timestamp))
 		i--
@@ -4589,6 +5477,30 @@ func (m *Op_Build) Size() (n int) {
 	}
 	return n
 }
+func (m *Op_Merge) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Merge != nil {
+		l = m.Merge.Size()
+		n += 1 + l + sovOps(uint64(l))
+	}
+	return n
+}
+func (m *Op_Diff) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Diff != nil {
+		l = m.Diff.Size()
+		n += 1 + l + sovOps(uint64(l))
+	}
+	return n
+}
 func (m *Platform) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4658,8 +5570,14 @@ func (m *ExecOp) Size() (n int) {
 	if m.Security != 0 {
 		n += 1 + sovOps(uint64(m.Security))
 	}
-	return n
-}
+	if len(m.Devices) > 0 {
+		for _, e := range m.Devices {
+			l = e.Size()
+			n += 1 + l + sovOps(uint64(l))
+		}
+	}
+	return n
+}
 
 func (m *Meta) Size() (n int) {
 	if m == nil {
@@ -4701,6 +5619,16 @@ func (m *Meta) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovOps(uint64(l))
 	}
+	if len(m.Ulimit) > 0 {
+		for _, e := range m.Ulimit {
+			l = e.Size()
+			n += 1 + l + sovOps(uint64(l))
+		}
+	}
+	if m.Resources != nil {
+		l = m.Resources.Size()
+		n += 1 + l + sovOps(uint64(l))
+	}
 	return n
 }
 
@@ -4815,6 +5743,23 @@ func (m *SSHOpt) Size() (n int) {
 	return n
 }
 
+func (m *Device) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovOps(uint64(l))
+	}
+	l = len(m.Permissions)
+	if l > 0 {
+		n += 1 + l + sovOps(uint64(l))
+	}
+	return n
+}
+
 func (m *SourceOp) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4873,6 +5818,74 @@ func (m *BuildOp) Size() (n int) {
 	return n
 }
 
+func (m *MergeOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Inputs) > 0 {
+		for _, e := range m.Inputs {
+			l = e.Size()
+			n += 1 + l + sovOps(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MergeInput) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Input != 0 {
+		n += 1 + sovOps(uint64(m.Input))
+	}
+	return n
+}
+
+func (m *DiffOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Lower != nil {
+		l = m.Lower.Size()
+		n += 1 + l + sovOps(uint64(l))
+	}
+	if m.Upper != nil {
+		l = m.Upper.Size()
+		n += 1 + l + sovOps(uint64(l))
+	}
+	return n
+}
+
+func (m *LowerDiffInput) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Input != 0 {
+		n += 1 + sovOps(uint64(m.Input))
+	}
+	return n
+}
+
+func (m *UpperDiffInput) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Input != 0 {
+		n += 1 + sovOps(uint64(m.Input))
+	}
+	return n
+}
+
 func (m *BuildInput) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5124,6 +6137,49 @@ func (m *HostIP) Size() (n int) {
 	return n
 }
 
+func (m *Ulimit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovOps(uint64(l))
+	}
+	if m.Soft != 0 {
+		n += 1 + sovOps(uint64(m.Soft))
+	}
+	if m.Hard != 0 {
+		n += 1 + sovOps(uint64(m.Hard))
+	}
+	return n
+}
+
+func (m *ResourceLimits) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Memory != 0 {
+		n += 1 + sovOps(uint64(m.Memory))
+	}
+	if m.CpuShares != 0 {
+		n += 1 + sovOps(uint64(m.CpuShares))
+	}
+	if m.CpuQuota != 0 {
+		n += 1 + sovOps(uint64(m.CpuQuota))
+	}
+	if m.CpuPeriod != 0 {
+		n += 1 + sovOps(uint64(m.CpuPeriod))
+	}
+	if m.Pids != 0 {
+		n += 1 + sovOps(uint64(m.Pids))
+	}
+	return n
+}
+
 func (m *FileOp) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5250,6 +6306,21 @@ func (m *FileActionCopy) Size() (n int) {
 	if m.Timestamp != 0 {
 		n += 1 + sovOps(uint64(m.Timestamp))
 	}
+	if len(m.IncludePatterns) > 0 {
+		for _, s := range m.IncludePatterns {
+			l = len(s)
+			n += 1 + l + sovOps(uint64(l))
+		}
+	}
+	if len(m.ExcludePatterns) > 0 {
+		for _, s := range m.ExcludePatterns {
+			l = len(s)
+			n += 1 + l + sovOps(uint64(l))
+		}
+	}
+	if m.Parents {
+		n += 2
+	}
 	return n
 }
 
@@ -5600,6 +6671,76 @@ func (m *Op) Unmarshal(dAtA []byte) error {
 			}
 			m.Op = &Op_Build{v}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Merge", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &MergeOp{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Op = &Op_Merge{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Diff", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DiffOp{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Op = &Op_Diff{v}
+			iNdEx = postIndex
 		case 10:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Platform", wireType)
@@ -6150,6 +7291,40 @@ func (m *ExecOp) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Devices = append(m.Devices, &Device{})
+			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOps(dAtA[iNdEx:])
@@ -6433,30 +7608,100 @@ func (m *Meta) Unmarshal(dAtA []byte) error {
 			}
 			m.Hostname = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipOps(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ulimit", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthOps
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthOps
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
+			m.Ulimit = append(m.Ulimit, &Ulimit{})
+			if err := m.Ulimit[len(m.Ulimit)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Resources == nil {
+				m.Resources = &ResourceLimits{}
+			}
+			if err := m.Resources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Mount) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -7219,6 +8464,123 @@ func (m *SSHOpt) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Device) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permissions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Permissions = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *SourceOp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -7795,7 +9157,7 @@ func (m *BuildOp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BuildInput) Unmarshal(dAtA []byte) error {
+func (m *MergeOp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7818,17 +9180,17 @@ func (m *BuildInput) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BuildInput: wiretype end group for non-group")
+			return fmt.Errorf("proto: MergeOp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BuildInput: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MergeOp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Input", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Inputs", wireType)
 			}
-			m.Input = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOps
@@ -7838,11 +9200,26 @@ func (m *BuildInput) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Input |= InputIndex(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Inputs = append(m.Inputs, &MergeInput{})
+			if err := m.Inputs[len(m.Inputs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOps(dAtA[iNdEx:])
@@ -7867,7 +9244,7 @@ func (m *BuildInput) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *OpMetadata) Unmarshal(dAtA []byte) error {
+func (m *MergeInput) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7890,17 +9267,17 @@ func (m *OpMetadata) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: OpMetadata: wiretype end group for non-group")
+			return fmt.Errorf("proto: MergeInput: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: OpMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MergeInput: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IgnoreCache", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Input", wireType)
 			}
-			var v int
+			m.Input = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOps
@@ -7910,45 +9287,458 @@ func (m *OpMetadata) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Input |= InputIndex(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IgnoreCache = bool(v != 0)
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOps
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthOps
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthOps
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Description == nil {
-				m.Description = make(map[string]string)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DiffOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
 			}
-			var mapkey string
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DiffOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DiffOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lower", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Lower == nil {
+				m.Lower = &LowerDiffInput{}
+			}
+			if err := m.Lower.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upper", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Upper == nil {
+				m.Upper = &UpperDiffInput{}
+			}
+			if err := m.Upper.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LowerDiffInput) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LowerDiffInput: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LowerDiffInput: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Input", wireType)
+			}
+			m.Input = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Input |= InputIndex(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpperDiffInput) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpperDiffInput: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpperDiffInput: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Input", wireType)
+			}
+			m.Input = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Input |= InputIndex(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BuildInput) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BuildInput: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BuildInput: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Input", wireType)
+			}
+			m.Input = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Input |= InputIndex(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OpMetadata) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OpMetadata: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OpMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IgnoreCache", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IgnoreCache = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Description == nil {
+				m.Description = make(map[string]string)
+			}
+			var mapkey string
 			var mapvalue string
 			for iNdEx < postIndex {
 				entryPreIndex := iNdEx
@@ -9519,13 +11309,134 @@ func (m *Definition) Unmarshal(dAtA []byte) error {
 					iNdEx += skippy
 				}
 			}
-			m.Metadata[github_com_opencontainers_go_digest.Digest(mapkey)] = *mapvalue
+			m.Metadata[github_com_opencontainers_go_digest.Digest(mapkey)] = *mapvalue
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Source == nil {
+				m.Source = &Source{}
+			}
+			if err := m.Source.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HostIP) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HostIP: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HostIP: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Host", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Host = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IP", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOps
@@ -9535,27 +11446,23 @@ func (m *Definition) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthOps
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthOps
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Source == nil {
-				m.Source = &Source{}
-			}
-			if err := m.Source.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.IP = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9581,7 +11488,7 @@ func (m *Definition) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostIP) Unmarshal(dAtA []byte) error {
+func (m *Ulimit) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9604,15 +11511,15 @@ func (m *HostIP) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostIP: wiretype end group for non-group")
+			return fmt.Errorf("proto: Ulimit: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostIP: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Ulimit: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Host", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9640,13 +11547,13 @@ func (m *HostIP) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Host = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IP", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Soft", wireType)
 			}
-			var stringLen uint64
+			m.Soft = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOps
@@ -9656,24 +11563,178 @@ func (m *HostIP) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Soft |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hard", wireType)
+			}
+			m.Hard = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hard |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthOps
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthOps
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IP = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResourceLimits) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResourceLimits: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResourceLimits: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Memory", wireType)
+			}
+			m.Memory = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Memory |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuShares", wireType)
+			}
+			m.CpuShares = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CpuShares |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuQuota", wireType)
+			}
+			m.CpuQuota = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CpuQuota |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuPeriod", wireType)
+			}
+			m.CpuPeriod = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CpuPeriod |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pids", wireType)
+			}
+			m.Pids = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Pids |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOps(dAtA[iNdEx:])
@@ -10322,6 +12383,90 @@ func (m *FileActionCopy) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludePatterns", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IncludePatterns = append(m.IncludePatterns, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludePatterns", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExcludePatterns = append(m.ExcludePatterns, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parents", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Parents = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOps(dAtA[iNdEx:])
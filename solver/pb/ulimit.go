@@ -0,0 +1,82 @@
+package pb
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Resources that can be limited with Ulimit
+const (
+	UlimitCore       = "core"
+	UlimitCPU        = "cpu"
+	UlimitData       = "data"
+	UlimitFsize      = "fsize"
+	UlimitLocks      = "locks"
+	UlimitMemlock    = "memlock"
+	UlimitMsgqueue   = "msgqueue"
+	UlimitNice       = "nice"
+	UlimitNofile     = "nofile"
+	UlimitNproc      = "nproc"
+	UlimitRss        = "rss"
+	UlimitRtprio     = "rtprio"
+	UlimitRttime     = "rttime"
+	UlimitSigpending = "sigpending"
+	UlimitStack      = "stack"
+)
+
+var ulimitNames = map[string]struct{}{
+	UlimitCore:       {},
+	UlimitCPU:        {},
+	UlimitData:       {},
+	UlimitFsize:      {},
+	UlimitLocks:      {},
+	UlimitMemlock:    {},
+	UlimitMsgqueue:   {},
+	UlimitNice:       {},
+	UlimitNofile:     {},
+	UlimitNproc:      {},
+	UlimitRss:        {},
+	UlimitRtprio:     {},
+	UlimitRttime:     {},
+	UlimitSigpending: {},
+	UlimitStack:      {},
+}
+
+// ValidateUlimit checks that the ulimit names a known resource and that the
+// soft limit doesn't exceed the hard limit. -1 is unlimited.
+func ValidateUlimit(u *Ulimit) error {
+	if _, ok := ulimitNames[u.Name]; !ok {
+		return errors.Errorf("invalid ulimit %q", u.Name)
+	}
+	if u.Hard != -1 && (u.Soft == -1 || u.Soft > u.Hard) {
+		return errors.Errorf("soft limit %d of ulimit %s exceeds the hard limit %d", u.Soft, u.Name, u.Hard)
+	}
+	return nil
+}
+
+// ParseUlimit parses a ulimit in the "name=soft[:hard]" format. The hard limit
+// defaults to the soft limit.
+func ParseUlimit(val string) (*Ulimit, error) {
+	parts := strings.SplitN(val, "=", 2)
+	if len(parts) != 2 {
+		return nil, errors.Errorf("invalid ulimit %q, expected name=soft[:hard]", val)
+	}
+	limits := strings.SplitN(parts[1], ":", 2)
+	soft, err := strconv.ParseInt(limits[0], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid soft limit of ulimit %q", val)
+	}
+	hard := soft
+	if len(limits) == 2 {
+		if hard, err = strconv.ParseInt(limits[1], 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "invalid hard limit of ulimit %q", val)
+		}
+	}
+	u := &Ulimit{Name: parts[0], Soft: soft, Hard: hard}
+	if err := ValidateUlimit(u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
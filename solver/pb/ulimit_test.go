@@ -0,0 +1,26 @@
+package pb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUlimit(t *testing.T) {
+	u, err := ParseUlimit("nofile=1024:2048")
+	require.NoError(t, err)
+	require.Equal(t, &Ulimit{Name: "nofile", Soft: 1024, Hard: 2048}, u)
+
+	u, err = ParseUlimit("nproc=100")
+	require.NoError(t, err)
+	require.Equal(t, &Ulimit{Name: "nproc", Soft: 100, Hard: 100}, u)
+
+	u, err = ParseUlimit("core=-1:-1")
+	require.NoError(t, err)
+	require.Equal(t, &Ulimit{Name: "core", Soft: -1, Hard: -1}, u)
+
+	for _, v := range []string{"nofile", "foo=1", "nofile=a", "nofile=2048:1024", "nofile=-1:1024"} {
+		_, err = ParseUlimit(v)
+		require.Error(t, err, v)
+	}
+}
@@ -61,6 +61,22 @@ func (j *Job) Status(ctx context.Context, ch chan *client.SolveStatus) error {
 				v.Vertex = vtx.(digest.Digest)
 				v.Timestamp = p.Timestamp
 				ss.Logs = append(ss.Logs, &v)
+			case client.VertexWarning:
+				vtx, ok := p.Meta("vertex")
+				if !ok {
+					logrus.Warnf("progress %s warning without vertex info", p.ID)
+					continue
+				}
+				v.Vertex = vtx.(digest.Digest)
+				ss.Warnings = append(ss.Warnings, &v)
+			case client.VertexResourceUsage:
+				vtx, ok := p.Meta("vertex")
+				if !ok {
+					logrus.Warnf("progress %s resource usage without vertex info", p.ID)
+					continue
+				}
+				v.Vertex = vtx.(digest.Digest)
+				ss.Resources = append(ss.Resources, &v)
 			}
 		}
 		select {
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/session"
 	digest "github.com/opencontainers/go-digest"
@@ -19,6 +20,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 )
 
 func init() {
@@ -3330,6 +3332,216 @@ func TestInputRequestDeadlock(t *testing.T) {
 	j2 = nil
 }
 
+func TestParallelismLimit(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	s := NewSolver(SolverOpt{
+		ResolveOpFunc: testOpResolver,
+	})
+	defer s.Close()
+
+	j0, err := s.NewJob("job0")
+	require.NoError(t, err)
+
+	defer func() {
+		if j0 != nil {
+			j0.Discard()
+		}
+	}()
+
+	sem := semaphore.NewWeighted(1)
+	var running, maxRunning int64
+	execPre := func(context.Context) error {
+		n := atomic.AddInt64(&running, 1)
+		for {
+			m := atomic.LoadInt64(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt64(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt64(&running, -1)
+		return nil
+	}
+
+	var inputs []Edge
+	for i := 0; i < 3; i++ {
+		inputs = append(inputs, Edge{Vertex: vtx(vtxOpt{
+			name:         fmt.Sprintf("v%d", i+1),
+			cacheKeySeed: fmt.Sprintf("seed%d", i+1),
+			value:        fmt.Sprintf("result%d", i+1),
+			execPreFunc:  execPre,
+			semaphores:   []*semaphore.Weighted{nil, sem},
+		})})
+	}
+	g0 := Edge{
+		Vertex: vtx(vtxOpt{
+			name:         "v0",
+			cacheKeySeed: "seed0",
+			value:        "result0",
+			inputs:       inputs,
+			semaphores:   []*semaphore.Weighted{sem},
+		}),
+	}
+
+	res, err := j0.Build(ctx, g0)
+	require.NoError(t, err)
+	require.Equal(t, unwrap(res), "result0")
+	require.Equal(t, int64(1), maxRunning)
+
+	// all slots are released after the build
+	require.True(t, sem.TryAcquire(1))
+	sem.Release(1)
+
+	require.NoError(t, j0.Discard())
+	j0 = nil
+}
+
+func TestParallelismWaitingStatus(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	s := NewSolver(SolverOpt{
+		ResolveOpFunc: testOpResolver,
+	})
+	defer s.Close()
+
+	j0, err := s.NewJob("job0")
+	require.NoError(t, err)
+
+	defer func() {
+		if j0 != nil {
+			j0.Discard()
+		}
+	}()
+
+	sem := semaphore.NewWeighted(1)
+	require.NoError(t, sem.Acquire(ctx, 1))
+
+	ch := make(chan *client.SolveStatus)
+	waiting := make(chan struct{})
+	statusDone := make(chan struct{})
+	var statuses []*client.SolveStatus
+	go func() {
+		defer close(statusDone)
+		isWaiting := false
+		for ss := range ch {
+			statuses = append(statuses, ss)
+			for _, st := range ss.Statuses {
+				if st.Name == "waiting" && !isWaiting {
+					isWaiting = true
+					close(waiting)
+				}
+			}
+		}
+	}()
+	go j0.Status(ctx, ch)
+
+	// the slot is freed once the vertex reports that it's waiting
+	go func() {
+		<-waiting
+		sem.Release(1)
+	}()
+
+	g0 := Edge{
+		Vertex: vtx(vtxOpt{
+			name:         "v0",
+			cacheKeySeed: "seed0",
+			value:        "result0",
+			semaphores:   []*semaphore.Weighted{sem},
+		}),
+	}
+
+	res, err := j0.Build(ctx, g0)
+	require.NoError(t, err)
+	require.Equal(t, unwrap(res), "result0")
+
+	require.NoError(t, j0.Discard())
+	j0 = nil
+	<-statusDone
+
+	var waitStatus *client.VertexStatus
+	var started *time.Time
+	for _, ss := range statuses {
+		for _, st := range ss.Statuses {
+			if st.Name == "waiting" {
+				waitStatus = st
+			}
+		}
+		for _, v := range ss.Vertexes {
+			if v.Name == "v0" && v.Started != nil {
+				started = v.Started
+			}
+		}
+	}
+	require.NotNil(t, waitStatus)
+	require.NotNil(t, waitStatus.Started)
+	require.NotNil(t, waitStatus.Completed)
+	require.Equal(t, g0.Vertex.Digest(), waitStatus.Vertex)
+
+	// the vertex is started after it stopped waiting
+	require.NotNil(t, started)
+	require.False(t, started.Before(*waitStatus.Completed))
+}
+
+func TestJobsRunningVertices(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	s := NewSolver(SolverOpt{
+		ResolveOpFunc: testOpResolver,
+	})
+	defer s.Close()
+
+	j0, err := s.NewJob("job0")
+	require.NoError(t, err)
+
+	defer func() {
+		if j0 != nil {
+			j0.Discard()
+		}
+	}()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	g0 := Edge{
+		Vertex: vtx(vtxOpt{
+			name:  "v0",
+			value: "result0",
+			execPreFunc: func(context.Context) error {
+				close(started)
+				<-release
+				return nil
+			},
+		}),
+	}
+
+	eg, _ := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		_, err := j0.Build(ctx, g0)
+		return err
+	})
+
+	<-started
+	jobs := s.Jobs()
+	require.Equal(t, 1, len(jobs))
+	require.Equal(t, "job0", jobs[0].ID)
+	require.Equal(t, 1, len(jobs[0].Running))
+	require.Equal(t, "v0", jobs[0].Running[0].Name)
+
+	close(release)
+	require.NoError(t, eg.Wait())
+
+	jobs = s.Jobs()
+	require.Equal(t, 1, len(jobs))
+	require.Equal(t, 0, len(jobs[0].Running))
+
+	require.NoError(t, j0.Discard())
+	j0 = nil
+	require.Equal(t, 0, len(s.Jobs()))
+}
+
 func generateSubGraph(nodes int) (Edge, int) {
 	if nodes == 1 {
 		value := rand.Int() % 500
@@ -3376,6 +3588,7 @@ type vtxOpt struct {
 	selectors        map[int]digest.Digest
 	cacheSource      CacheManager
 	ignoreCache      bool
+	semaphores       []*semaphore.Weighted
 }
 
 func vtx(opt vtxOpt) *vertex {
@@ -3511,6 +3724,10 @@ func (v *vertex) Exec(ctx context.Context, g session.Group, inputs []Result) (ou
 	return []Result{&dummyResult{id: identity.NewID(), value: v.opt.value}}, nil
 }
 
+func (v *vertex) Semaphores(ctx context.Context) []*semaphore.Weighted {
+	return v.opt.semaphores
+}
+
 func (v *vertex) makeCacheMap() *CacheMap {
 	m := &CacheMap{
 		Digest: digest.FromBytes([]byte(fmt.Sprintf("seed:%s", v.opt.cacheKeySeed))),
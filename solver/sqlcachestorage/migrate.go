@@ -0,0 +1,38 @@
+package sqlcachestorage
+
+import (
+	"database/sql"
+
+	"github.com/moby/buildkit/solver"
+	"github.com/pkg/errors"
+)
+
+// MigrateSource is a cache key storage that can enumerate all outgoing links
+// of a key, e.g. *bboltcachestorage.Store.
+type MigrateSource interface {
+	solver.CacheKeyStorage
+	WalkAllLinks(id string, fn func(link solver.CacheInfoLink, target string) error) error
+}
+
+// Migrate copies all keys, results and links from src into the store in a
+// single transaction. Existing records in the store are kept.
+func (s *Store) Migrate(src MigrateSource) error {
+	return s.update(func(tx *sql.Tx) error {
+		return src.Walk(func(id string) error {
+			if _, err := tx.Exec(`INSERT OR IGNORE INTO keys (id) VALUES (?)`, id); err != nil {
+				return err
+			}
+			if err := src.WalkResults(id, func(res solver.CacheResult) error {
+				return addResult(tx, id, res)
+			}); err != nil {
+				return errors.Wrapf(err, "failed to migrate results for %s", id)
+			}
+			if err := src.WalkAllLinks(id, func(link solver.CacheInfoLink, target string) error {
+				return addLink(tx, id, link, target)
+			}); err != nil {
+				return errors.Wrapf(err, "failed to migrate links for %s", id)
+			}
+			return nil
+		})
+	})
+}
@@ -0,0 +1,355 @@
+// Package sqlcachestorage implements solver.CacheKeyStorage on SQLite.
+//
+// It uses github.com/mattn/go-sqlite3, which requires cgo. The pure Go port,
+// modernc.org/sqlite, was not used because it vendors about 2M lines of
+// transpiled C, eight times the size of the cgo binding.
+package sqlcachestorage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // register sqlite3 driver
+	"github.com/moby/buildkit/solver"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS keys (
+	id TEXT NOT NULL PRIMARY KEY
+) WITHOUT ROWID;
+CREATE TABLE IF NOT EXISTS results (
+	id TEXT NOT NULL,
+	result_id TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	PRIMARY KEY (id, result_id)
+) WITHOUT ROWID;
+CREATE INDEX IF NOT EXISTS results_by_result_id ON results (result_id);
+CREATE TABLE IF NOT EXISTS links (
+	id TEXT NOT NULL,
+	link TEXT NOT NULL,
+	target TEXT NOT NULL,
+	PRIMARY KEY (id, link, target)
+) WITHOUT ROWID;
+CREATE INDEX IF NOT EXISTS links_by_target ON links (target);
+`
+
+// Store is a solver.CacheKeyStorage backed by an embedded SQLite database.
+// Unlike the bbolt storage, lookups by result and by link target use
+// indexes instead of separate buckets.
+type Store struct {
+	db *sql.DB
+}
+
+var _ solver.CacheKeyStorage = &Store{}
+
+func NewStore(dbPath string) (*Store, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_busy_timeout=10000&_journal_mode=WAL&_sync=OFF", dbPath))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open database file %s", dbPath)
+	}
+	// sqlite only supports a single writer, serialize access instead of
+	// failing with SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "failed to initialize database file %s", dbPath)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) Exists(id string) bool {
+	var v int
+	if err := s.db.QueryRow(`SELECT 1 FROM keys WHERE id = ?`, id).Scan(&v); err != nil {
+		return false
+	}
+	return true
+}
+
+func (s *Store) Walk(fn func(id string) error) error {
+	ids, err := queryStrings(s.db, `SELECT id FROM keys`)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := fn(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) WalkResults(id string, fn func(solver.CacheResult) error) error {
+	rows, err := s.db.Query(`SELECT result_id, created_at FROM results WHERE id = ?`, id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	var list []solver.CacheResult
+	for rows.Next() {
+		res, err := scanResult(rows)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		list = append(list, res)
+	}
+	if err := closeRows(rows); err != nil {
+		return err
+	}
+	for _, res := range list {
+		if err := fn(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) Load(id string, resultID string) (solver.CacheResult, error) {
+	row := s.db.QueryRow(`SELECT result_id, created_at FROM results WHERE id = ? AND result_id = ?`, id, resultID)
+	res, err := scanResult(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return solver.CacheResult{}, errors.WithStack(solver.ErrNotFound)
+		}
+		return solver.CacheResult{}, err
+	}
+	return res, nil
+}
+
+func (s *Store) AddResult(id string, res solver.CacheResult) error {
+	return s.update(func(tx *sql.Tx) error {
+		return addResult(tx, id, res)
+	})
+}
+
+func addResult(tx *sql.Tx, id string, res solver.CacheResult) error {
+	createdAt, err := res.CreatedAt.MarshalText()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT OR IGNORE INTO keys (id) VALUES (?)`, id); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT OR REPLACE INTO results (id, result_id, created_at) VALUES (?, ?, ?)`, id, res.ID, string(createdAt))
+	return err
+}
+
+func (s *Store) WalkIDsByResult(resultID string, fn func(string) error) error {
+	ids, err := queryStrings(s.db, `SELECT DISTINCT id FROM results WHERE result_id = ?`, resultID)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := fn(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) Release(resultID string) error {
+	return s.update(func(tx *sql.Tx) error {
+		ids, err := queryStrings(tx, `SELECT id FROM results WHERE result_id = ?`, resultID)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return errors.WithStack(solver.ErrNotFound)
+		}
+		if _, err := tx.Exec(`DELETE FROM results WHERE result_id = ?`, resultID); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := emptyBranchWithParents(tx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// emptyBranchWithParents removes a key that has no results and no links left
+// together with the links pointing to it, and then does the same for the
+// parents that became empty.
+func emptyBranchWithParents(tx *sql.Tx, id string) error {
+	var v int
+	err := tx.QueryRow(`SELECT 1 FROM results WHERE id = ? UNION ALL SELECT 1 FROM links WHERE id = ? LIMIT 1`, id, id).Scan(&v)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return errors.WithStack(err)
+	}
+
+	parents, err := queryStrings(tx, `SELECT DISTINCT id FROM links WHERE target = ?`, id)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM links WHERE target = ?`, id); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := tx.Exec(`DELETE FROM keys WHERE id = ?`, id); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, p := range parents {
+		if _, err := tx.Exec(`DELETE FROM keys WHERE id = ? AND NOT EXISTS (SELECT 1 FROM links WHERE id = ?)`, p, p); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := emptyBranchWithParents(tx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) AddLink(id string, link solver.CacheInfoLink, target string) error {
+	return s.update(func(tx *sql.Tx) error {
+		return addLink(tx, id, link, target)
+	})
+}
+
+func addLink(tx *sql.Tx, id string, link solver.CacheInfoLink, target string) error {
+	dt, err := json.Marshal(link)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT OR IGNORE INTO keys (id) VALUES (?)`, id); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT OR IGNORE INTO links (id, link, target) VALUES (?, ?, ?)`, id, string(dt), target)
+	return err
+}
+
+func (s *Store) WalkLinks(id string, link solver.CacheInfoLink, fn func(id string) error) error {
+	dt, err := json.Marshal(link)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	targets, err := queryStrings(s.db, `SELECT target FROM links WHERE id = ? AND link = ?`, id, string(dt))
+	if err != nil {
+		return err
+	}
+	for _, t := range targets {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) HasLink(id string, link solver.CacheInfoLink, target string) bool {
+	dt, err := json.Marshal(link)
+	if err != nil {
+		return false
+	}
+	var v int
+	if err := s.db.QueryRow(`SELECT 1 FROM links WHERE id = ? AND link = ? AND target = ?`, id, string(dt), target).Scan(&v); err != nil {
+		return false
+	}
+	return true
+}
+
+func (s *Store) WalkBacklinks(id string, fn func(id string, link solver.CacheInfoLink) error) error {
+	var outIDs []string
+	var outLinks []solver.CacheInfoLink
+
+	rows, err := s.db.Query(`SELECT id, link FROM links WHERE target = ?`, id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for rows.Next() {
+		var bid, dt string
+		if err := rows.Scan(&bid, &dt); err != nil {
+			rows.Close()
+			return errors.WithStack(err)
+		}
+		var l solver.CacheInfoLink
+		if err := json.Unmarshal([]byte(dt), &l); err != nil {
+			rows.Close()
+			return errors.WithStack(err)
+		}
+		l.Digest = digest.FromBytes([]byte(fmt.Sprintf("%s@%d", l.Digest, l.Output)))
+		l.Output = 0
+		outIDs = append(outIDs, bid)
+		outLinks = append(outLinks, l)
+	}
+	if err := closeRows(rows); err != nil {
+		return err
+	}
+
+	for i := range outIDs {
+		if err := fn(outIDs[i], outLinks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) update(fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(tx.Commit())
+}
+
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func queryStrings(q queryer, query string, args ...interface{}) ([]string, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var out []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			rows.Close()
+			return nil, errors.WithStack(err)
+		}
+		out = append(out, v)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanResult(s scanner) (solver.CacheResult, error) {
+	var res solver.CacheResult
+	var createdAt string
+	if err := s.Scan(&res.ID, &createdAt); err != nil {
+		return res, errors.WithStack(err)
+	}
+	var t time.Time
+	if err := t.UnmarshalText([]byte(createdAt)); err != nil {
+		return res, errors.WithStack(err)
+	}
+	res.CreatedAt = t
+	return res, nil
+}
+
+func closeRows(rows *sql.Rows) error {
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(rows.Close())
+}
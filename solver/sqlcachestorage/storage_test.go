@@ -0,0 +1,90 @@
+package sqlcachestorage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/bboltcachestorage"
+	"github.com/moby/buildkit/solver/testutil"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLCacheStorage(t *testing.T) {
+	testutil.RunCacheStorageTests(t, func() (solver.CacheKeyStorage, func()) {
+		tmpDir, err := ioutil.TempDir("", "storage")
+		require.NoError(t, err)
+
+		st, err := NewStore(filepath.Join(tmpDir, "cache.sqlite"))
+		if err != nil {
+			os.RemoveAll(tmpDir)
+		}
+		require.NoError(t, err)
+
+		cleanup := func() {
+			st.Close()
+			os.RemoveAll(tmpDir)
+		}
+
+		return st, cleanup
+	})
+}
+
+func TestMigrateFromBolt(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "storage")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	src, err := bboltcachestorage.NewStore(filepath.Join(tmpDir, "cache.db"))
+	require.NoError(t, err)
+
+	createdAt := time.Now().UTC().Round(time.Millisecond)
+	err = src.AddResult("foo", solver.CacheResult{ID: "foo-result", CreatedAt: createdAt})
+	require.NoError(t, err)
+	err = src.AddResult("sub0", solver.CacheResult{ID: "sub0-result", CreatedAt: createdAt})
+	require.NoError(t, err)
+
+	link := solver.CacheInfoLink{Input: 0, Output: 1, Digest: digest.FromBytes([]byte("to-sub0"))}
+	err = src.AddLink("foo", link, "sub0")
+	require.NoError(t, err)
+
+	dst, err := NewStore(filepath.Join(tmpDir, "cache.sqlite"))
+	require.NoError(t, err)
+	defer dst.Close()
+
+	err = dst.Migrate(src)
+	require.NoError(t, err)
+
+	require.True(t, dst.Exists("foo"))
+	require.True(t, dst.Exists("sub0"))
+
+	res, err := dst.Load("foo", "foo-result")
+	require.NoError(t, err)
+	require.True(t, createdAt.Equal(res.CreatedAt))
+
+	require.True(t, dst.HasLink("foo", link, "sub0"))
+
+	var backlinks []string
+	err = dst.WalkBacklinks("sub0", func(id string, l solver.CacheInfoLink) error {
+		backlinks = append(backlinks, id)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo"}, backlinks)
+
+	// migrating again doesn't duplicate records
+	err = dst.Migrate(src)
+	require.NoError(t, err)
+
+	var results []string
+	err = dst.WalkIDsByResult("sub0-result", func(id string) error {
+		results = append(results, id)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"sub0"}, results)
+}
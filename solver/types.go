@@ -9,6 +9,7 @@ import (
 	"github.com/moby/buildkit/solver/pb"
 	digest "github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/sync/semaphore"
 )
 
 // Vertex is a node in a build graph. It defines an interface for a
@@ -147,6 +148,14 @@ type Op interface {
 	Exec(ctx context.Context, g session.Group, inputs []Result) (outputs []Result, err error)
 }
 
+// ParallelismOp is implemented by ops that limit how many of them can execute
+// at the same time.
+type ParallelismOp interface {
+	// Semaphores returns the semaphores that are acquired while the op is
+	// executing. They are always acquired in the returned order.
+	Semaphores(ctx context.Context) []*semaphore.Weighted
+}
+
 type ResultBasedCacheFunc func(context.Context, Result, session.Group) (digest.Digest, error)
 type PreprocessFunc func(context.Context, Result, session.Group) error
 
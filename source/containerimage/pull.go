@@ -296,7 +296,7 @@ func (p *puller) Snapshot(ctx context.Context, g session.Group) (ir cache.Immuta
 	for _, layerDesc := range p.manifest.Descriptors {
 		parent = current
 		current, err = p.CacheAccessor.GetByBlob(ctx, layerDesc, parent,
-			p.descHandlers, cache.WithImageRef(p.manifest.Ref))
+			p.descHandlers, cache.WithImageRef(p.manifest.Ref), cache.WithSourceOp(source.DockerImageScheme+"://"+p.manifest.Ref), cache.WithBuildRef(solver.BuildRefOf(ctx)), cache.WithFrontend(solver.BuildFrontendOf(ctx)))
 		if parent != nil {
 			parent.Release(context.TODO())
 		}
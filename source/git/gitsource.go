@@ -80,7 +80,7 @@ func (gs *gitSource) mountRemote(ctx context.Context, remote string, auth []stri
 
 	var remoteRef cache.MutableRef
 	for _, si := range sis {
-		remoteRef, err = gs.cache.GetMutable(ctx, si.ID())
+		remoteRef, err = gs.cache.GetMutable(ctx, si.ID(), cache.WithBuildRef(solver.BuildRefOf(ctx)))
 		if err != nil {
 			if errors.Is(err, cache.ErrLocked) {
 				// should never really happen as no other function should access this metadata, but lets be graceful
@@ -94,7 +94,7 @@ func (gs *gitSource) mountRemote(ctx context.Context, remote string, auth []stri
 
 	initializeRepo := false
 	if remoteRef == nil {
-		remoteRef, err = gs.cache.New(ctx, nil, g, cache.CachePolicyRetain, cache.WithDescription(fmt.Sprintf("shared git repo for %s", remote)))
+		remoteRef, err = gs.cache.New(ctx, nil, g, cache.CachePolicyRetain, cache.WithDescription(fmt.Sprintf("shared git repo for %s", remote)), cache.WithBuildRef(solver.BuildRefOf(ctx)), cache.WithFrontend(solver.BuildFrontendOf(ctx)))
 		if err != nil {
 			return "", nil, errors.Wrapf(err, "failed to create new mutable for %s", remote)
 		}
@@ -391,7 +391,7 @@ func (gs *gitSourceHandler) Snapshot(ctx context.Context, g session.Group) (out
 		return nil, errors.Wrapf(err, "failed to search metadata for %s", snapshotKey)
 	}
 	if len(sis) > 0 {
-		return gs.cache.Get(ctx, sis[0].ID())
+		return gs.cache.Get(ctx, sis[0].ID(), cache.WithBuildRef(solver.BuildRefOf(ctx)))
 	}
 
 	gs.locker.Lock(gs.src.Remote)
@@ -454,7 +454,7 @@ func (gs *gitSourceHandler) Snapshot(ctx context.Context, g session.Group) (out
 		}
 	}
 
-	checkoutRef, err := gs.cache.New(ctx, nil, g, cache.WithRecordType(client.UsageRecordTypeGitCheckout), cache.WithDescription(fmt.Sprintf("git snapshot for %s#%s", gs.src.Remote, ref)))
+	checkoutRef, err := gs.cache.New(ctx, nil, g, cache.WithRecordType(client.UsageRecordTypeGitCheckout), cache.WithDescription(fmt.Sprintf("git snapshot for %s#%s", gs.src.Remote, ref)), cache.WithSourceOp(fmt.Sprintf("%s://%s#%s", source.GitScheme, gs.src.Remote, ref)), cache.WithBuildRef(solver.BuildRefOf(ctx)), cache.WithFrontend(solver.BuildFrontendOf(ctx)))
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create new mutable for %s", gs.src.Remote)
 	}
@@ -250,7 +250,7 @@ func (hs *httpSourceHandler) CacheKey(ctx context.Context, g session.Group, inde
 }
 
 func (hs *httpSourceHandler) save(ctx context.Context, resp *http.Response, s session.Group) (ref cache.ImmutableRef, dgst digest.Digest, retErr error) {
-	newRef, err := hs.cache.New(ctx, nil, s, cache.CachePolicyRetain, cache.WithDescription(fmt.Sprintf("http url %s", hs.src.URL)))
+	newRef, err := hs.cache.New(ctx, nil, s, cache.CachePolicyRetain, cache.WithDescription(fmt.Sprintf("http url %s", hs.src.URL)), cache.WithSourceOp(hs.src.URL), cache.WithBuildRef(solver.BuildRefOf(ctx)), cache.WithFrontend(solver.BuildFrontendOf(ctx)))
 	if err != nil {
 		return nil, "", err
 	}
@@ -373,7 +373,7 @@ func (hs *httpSourceHandler) save(ctx context.Context, resp *http.Response, s se
 
 func (hs *httpSourceHandler) Snapshot(ctx context.Context, g session.Group) (cache.ImmutableRef, error) {
 	if hs.refID != "" {
-		ref, err := hs.cache.Get(ctx, hs.refID)
+		ref, err := hs.cache.Get(ctx, hs.refID, cache.WithBuildRef(solver.BuildRefOf(ctx)))
 		if err == nil {
 			return ref, nil
 		}
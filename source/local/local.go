@@ -118,7 +118,7 @@ func (ls *localSourceHandler) snapshot(ctx context.Context, s session.Group, cal
 		return nil, err
 	}
 	for _, si := range sis {
-		if m, err := ls.cm.GetMutable(ctx, si.ID()); err == nil {
+		if m, err := ls.cm.GetMutable(ctx, si.ID(), cache.WithBuildRef(solver.BuildRefOf(ctx))); err == nil {
 			logrus.Debugf("reusing ref for local: %s", m.ID())
 			mutable = m
 			break
@@ -126,7 +126,7 @@ func (ls *localSourceHandler) snapshot(ctx context.Context, s session.Group, cal
 	}
 
 	if mutable == nil {
-		m, err := ls.cm.New(ctx, nil, s, cache.CachePolicyRetain, cache.WithRecordType(client.UsageRecordTypeLocalSource), cache.WithDescription(fmt.Sprintf("local source for %s", ls.src.Name)))
+		m, err := ls.cm.New(ctx, nil, s, cache.CachePolicyRetain, cache.WithRecordType(client.UsageRecordTypeLocalSource), cache.WithDescription(fmt.Sprintf("local source for %s", ls.src.Name)), cache.WithSourceOp(source.LocalScheme+"://"+ls.src.Name), cache.WithBuildRef(solver.BuildRefOf(ctx)), cache.WithFrontend(solver.BuildFrontendOf(ctx)))
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,8 @@
+package disk
+
+// DiskStat describes the size of the filesystem containing a path.
+type DiskStat struct {
+	Total     int64
+	Free      int64
+	Available int64
+}
@@ -9,11 +9,15 @@ type Entitlement string
 const (
 	EntitlementSecurityInsecure Entitlement = "security.insecure"
 	EntitlementNetworkHost      Entitlement = "network.host"
+	EntitlementDevice           Entitlement = "device"
+	EntitlementCgroupParent     Entitlement = "cgroup.parent"
 )
 
 var all = map[Entitlement]struct{}{
 	EntitlementSecurityInsecure: {},
 	EntitlementNetworkHost:      {},
+	EntitlementDevice:           {},
+	EntitlementCgroupParent:     {},
 }
 
 func Parse(s string) (Entitlement, error) {
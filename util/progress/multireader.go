@@ -11,14 +11,36 @@ type MultiReader struct {
 	main        Reader
 	initialized bool
 	done        chan struct{}
+	closed      bool
 	writers     map[*progressWriter]func()
+	sent        []*Progress
+	sentIndex   map[string]int
+	sentLogs    []*Progress
+	isLog       func(*Progress) bool
+	maxLogs     int
 }
 
-func NewMultiReader(pr Reader) *MultiReader {
+type MultiReaderOpt func(*MultiReader)
+
+// WithLogReplayLimit limits the logs replayed to readers that attach late to
+// the last n items that isLog returns true for. Logs have a unique ID per
+// write so unlike other items they are not coalesced.
+func WithLogReplayLimit(isLog func(*Progress) bool, n int) MultiReaderOpt {
+	return func(mr *MultiReader) {
+		mr.isLog = isLog
+		mr.maxLogs = n
+	}
+}
+
+func NewMultiReader(pr Reader, opts ...MultiReaderOpt) *MultiReader {
 	mr := &MultiReader{
-		main:    pr,
-		writers: make(map[*progressWriter]func()),
-		done:    make(chan struct{}),
+		main:      pr,
+		writers:   make(map[*progressWriter]func()),
+		done:      make(chan struct{}),
+		sentIndex: make(map[string]int),
+	}
+	for _, o := range opts {
+		o(mr)
 	}
 	return mr
 }
@@ -31,6 +53,19 @@ func (mr *MultiReader) Reader(ctx context.Context) Reader {
 	pw, _, ctx := FromContext(ctx)
 
 	w := pw.(*progressWriter)
+
+	// readers that attach late receive everything that was already sent
+	for _, p := range mr.sent {
+		w.writeRawProgress(p)
+	}
+	for _, p := range mr.sentLogs {
+		w.writeRawProgress(p)
+	}
+	if mr.closed {
+		w.Close()
+		closeWriter()
+		return pr
+	}
 	mr.writers[w] = closeWriter
 
 	go func() {
@@ -61,6 +96,7 @@ func (mr *MultiReader) handle() error {
 					w.Close()
 					c()
 				}
+				mr.closed = true
 				mr.mu.Unlock()
 				return nil
 			}
@@ -71,6 +107,24 @@ func (mr *MultiReader) handle() error {
 			for w := range mr.writers {
 				w.writeRawProgress(p)
 			}
+			if mr.isLog != nil && mr.isLog(p) {
+				if mr.maxLogs > 0 {
+					if len(mr.sentLogs) == mr.maxLogs {
+						copy(mr.sentLogs, mr.sentLogs[1:])
+						mr.sentLogs = mr.sentLogs[:len(mr.sentLogs)-1]
+					}
+					mr.sentLogs = append(mr.sentLogs, p)
+				}
+				continue
+			}
+			// only the last update for an ID is kept, readers coalesce
+			// updates with the same ID anyway
+			if i, ok := mr.sentIndex[p.ID]; ok {
+				mr.sent[i] = p
+			} else {
+				mr.sentIndex[p.ID] = len(mr.sent)
+				mr.sent = append(mr.sent, p)
+			}
 		}
 		mr.mu.Unlock()
 	}
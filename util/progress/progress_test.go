@@ -132,3 +132,97 @@ func saveProgress(ctx context.Context, pr Reader, t *trace) error {
 		t.items = append(t.items, p...)
 	}
 }
+
+func TestMultiReaderReplay(t *testing.T) {
+	t.Parallel()
+
+	pr, ctx, cancelProgress := NewContext(context.Background())
+	mr := NewMultiReader(pr)
+
+	var trace1 trace
+	eg, egctx := errgroup.WithContext(context.Background())
+	r1 := mr.Reader(egctx)
+	eg.Go(func() error {
+		return saveProgress(egctx, r1, &trace1)
+	})
+
+	pw, _, _ := FromContext(ctx)
+	assert.NoError(t, pw.Write("foo", 1))
+	assert.NoError(t, pw.Write("bar", 2))
+	assert.NoError(t, pw.Write("foo", 3))
+
+	// wait for the first reader to receive the updates
+	for i := 0; i < 100; i++ {
+		mr.mu.Lock()
+		n := len(mr.sent)
+		v := 0
+		if i, ok := mr.sentIndex["foo"]; ok {
+			v, _ = mr.sent[i].Sys.(int)
+		}
+		mr.mu.Unlock()
+		if n == 2 && v == 3 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var trace2 trace
+	r2 := mr.Reader(egctx)
+	eg.Go(func() error {
+		return saveProgress(egctx, r2, &trace2)
+	})
+
+	pw.Close()
+	cancelProgress()
+	assert.NoError(t, eg.Wait())
+
+	values := map[string]interface{}{}
+	for _, p := range trace2.items {
+		values[p.ID] = p.Sys
+	}
+	assert.Equal(t, map[string]interface{}{"foo": 3, "bar": 2}, values)
+
+	// readers attaching after the main reader has finished get the final state
+	var trace3 trace
+	assert.NoError(t, saveProgress(context.TODO(), mr.Reader(context.TODO()), &trace3))
+	assert.Equal(t, 2, len(trace3.items))
+}
+
+func TestMultiReaderReplayLogLimit(t *testing.T) {
+	t.Parallel()
+
+	type log string
+	isLog := func(p *Progress) bool {
+		_, ok := p.Sys.(log)
+		return ok
+	}
+
+	pr, ctx, cancelProgress := NewContext(context.Background())
+	mr := NewMultiReader(pr, WithLogReplayLimit(isLog, 2))
+
+	var trace1 trace
+	eg, egctx := errgroup.WithContext(context.Background())
+	r1 := mr.Reader(egctx)
+	eg.Go(func() error {
+		return saveProgress(egctx, r1, &trace1)
+	})
+
+	pw, _, _ := FromContext(ctx)
+	assert.NoError(t, pw.Write("foo", 1))
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, pw.Write(fmt.Sprintf("log%d", i), log(fmt.Sprintf("line%d", i))))
+	}
+	pw.Close()
+	cancelProgress()
+	assert.NoError(t, eg.Wait())
+	assert.Equal(t, 6, len(trace1.items))
+
+	// only the last logs are replayed
+	var trace2 trace
+	assert.NoError(t, saveProgress(context.TODO(), mr.Reader(context.TODO()), &trace2))
+	values := map[string]interface{}{}
+	for _, p := range trace2.items {
+		values[p.ID] = p.Sys
+	}
+	assert.Equal(t, map[string]interface{}{"foo": 1, "log3": log("line3"), "log4": log("line4")}, values)
+}
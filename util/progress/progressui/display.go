@@ -14,6 +14,7 @@ import (
 	"github.com/containerd/console"
 	"github.com/jaguilar/vt100"
 	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/solver/pb"
 	"github.com/morikuni/aec"
 	digest "github.com/opencontainers/go-digest"
 	"github.com/tonistiigi/units"
@@ -69,6 +70,7 @@ func DisplaySolveStatus(ctx context.Context, phase string, c console.Console, w
 			if done {
 				disp.print(t.displayInfo(), width, height, true)
 				t.printErrorLogs(c)
+				t.printWarnings(c)
 				return nil
 			} else if displayLimiter.Allow() {
 				ticker.Stop()
@@ -80,6 +82,7 @@ func DisplaySolveStatus(ctx context.Context, phase string, c console.Console, w
 				printer.print(t)
 				if done {
 					t.printErrorLogs(w)
+					t.printWarnings(w)
 					return nil
 				}
 				ticker.Stop()
@@ -118,6 +121,7 @@ type trace struct {
 	nextIndex     int
 	updates       map[digest.Digest]struct{}
 	modeConsole   bool
+	warnings      []client.VertexWarning
 }
 
 type vertex struct {
@@ -288,6 +292,29 @@ func (t *trace) update(s *client.SolveStatus, termWidth int) {
 		t.updates[v.Digest] = struct{}{}
 		v.update(1)
 	}
+	for _, w := range s.Warnings {
+		t.warnings = append(t.warnings, *w)
+	}
+	for _, r := range s.Resources {
+		v, ok := t.byDigest[r.Vertex]
+		if !ok {
+			continue // shouldn't happen
+		}
+		v.events = append(v.events, formatResourceUsage(r))
+		t.updates[v.Digest] = struct{}{}
+		v.update(1)
+	}
+}
+
+func formatResourceUsage(r *client.VertexResourceUsage) string {
+	s := fmt.Sprintf("resources: cpu %.2fs, memory %.2f peak", r.CPUTime.Seconds(), units.Bytes(r.MemoryPeak))
+	if r.IOReadBytes != 0 || r.IOWriteBytes != 0 {
+		s += fmt.Sprintf(", io %.2f read / %.2f written", units.Bytes(r.IOReadBytes), units.Bytes(r.IOWriteBytes))
+	}
+	if r.PidsPeak != 0 {
+		s += fmt.Sprintf(", %d pids", r.PidsPeak)
+	}
+	return s
 }
 
 func (t *trace) printErrorLogs(f io.Writer) {
@@ -304,6 +331,45 @@ func (t *trace) printErrorLogs(f io.Writer) {
 	}
 }
 
+func (t *trace) printWarnings(f io.Writer) {
+	if len(t.warnings) == 0 {
+		return
+	}
+	fmt.Fprintf(f, "\n%d warning(s) found:\n", len(t.warnings))
+	for _, w := range t.warnings {
+		fmt.Fprintf(f, " - %s\n", w.Short)
+		for _, d := range w.Detail {
+			fmt.Fprintf(f, "   %s\n", d)
+		}
+		if w.URL != "" {
+			fmt.Fprintf(f, "   More info: %s\n", w.URL)
+		}
+		if w.SourceInfo != nil {
+			for _, r := range w.Range {
+				printSourceRange(f, w.SourceInfo, r)
+			}
+		}
+	}
+	fmt.Fprintln(f)
+}
+
+func printSourceRange(f io.Writer, si *pb.SourceInfo, r *pb.Range) {
+	fmt.Fprintf(f, "   %s:%d\n", si.Filename, r.Start.Line)
+	lines := strings.Split(string(si.Data), "\n")
+	start, end := int(r.Start.Line), int(r.End.Line)
+	if end < start {
+		end = start
+	}
+	if start < 1 || end > len(lines) {
+		return
+	}
+	fmt.Fprintln(f, "   --------------------")
+	for i := start; i <= end; i++ {
+		fmt.Fprintf(f, "   %3d | %s\n", i, lines[i-1])
+	}
+	fmt.Fprintln(f, "   --------------------")
+}
+
 func (t *trace) displayInfo() (d displayInfo) {
 	d.startTime = time.Now()
 	if t.localTimeDiff != 0 {
@@ -50,6 +50,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	bolt "go.etcd.io/bbolt"
+	"golang.org/x/sync/semaphore"
 )
 
 const labelCreatedAt = "buildkit/createdat"
@@ -60,6 +61,7 @@ const labelCreatedAt = "buildkit/createdat"
 // See also CommonOpt.
 type WorkerOpt struct {
 	ID              string
+	Root            string
 	Labels          map[string]string
 	Platforms       []specs.Platform
 	GCPolicy        []client.PruneInfo
@@ -74,6 +76,8 @@ type WorkerOpt struct {
 	IdentityMapping *idtools.IdentityMapping
 	LeaseManager    leases.Manager
 	GarbageCollect  func(context.Context) (gc.Stats, error)
+	// ParallelismSem limits the exec steps running at the same time
+	ParallelismSem *semaphore.Weighted
 }
 
 // Worker is a local worker instance with dedicated snapshotter, cache, and so on.
@@ -102,6 +106,7 @@ func NewWorker(opt WorkerOpt) (*Worker, error) {
 		LeaseManager:    opt.LeaseManager,
 		ContentStore:    opt.ContentStore,
 		Differ:          opt.Differ,
+		Root:            opt.Root,
 	})
 	if err != nil {
 		return nil, err
@@ -222,6 +227,8 @@ func (w *Worker) LoadRef(ctx context.Context, id string, hidden bool) (cache.Imm
 	var opts []cache.RefOption
 	if hidden {
 		opts = append(opts, cache.NoUpdateLastUsed)
+	} else {
+		opts = append(opts, cache.WithBuildRef(solver.BuildRefOf(ctx)))
 	}
 
 	ref, err := w.CacheMgr.Get(ctx, id, opts...)
@@ -263,11 +270,15 @@ func (w *Worker) ResolveOp(v solver.Vertex, s frontend.FrontendLLBBridge, sm *se
 		case *pb.Op_Source:
 			return ops.NewSourceOp(v, op, baseOp.Platform, w.SourceManager, sm, w)
 		case *pb.Op_Exec:
-			return ops.NewExecOp(v, op, baseOp.Platform, w.CacheMgr, sm, w.WorkerOpt.MetadataStore, w.WorkerOpt.Executor, w)
+			return ops.NewExecOp(v, op, baseOp.Platform, w.CacheMgr, sm, w.WorkerOpt.MetadataStore, w.WorkerOpt.Executor, w.WorkerOpt.ParallelismSem, w)
 		case *pb.Op_File:
 			return ops.NewFileOp(v, op, w.CacheMgr, w.WorkerOpt.MetadataStore, w)
 		case *pb.Op_Build:
 			return ops.NewBuildOp(v, op, s, w)
+		case *pb.Op_Merge:
+			return ops.NewMergeOp(v, op, w)
+		case *pb.Op_Diff:
+			return ops.NewDiffOp(v, op, w)
 		default:
 			return nil, errors.Errorf("no support for %T", op)
 		}
@@ -321,6 +332,14 @@ func (w *Worker) PruneCacheMounts(ctx context.Context, ids []string) error {
 	return nil
 }
 
+func (w *Worker) ExportCacheMount(ctx context.Context, id string, g session.Group) (*solver.Remote, func(context.Context) error, error) {
+	return mounts.ExportCacheMount(ctx, w.CacheMgr, w.WorkerOpt.MetadataStore, w.ContentStore(), w.LeaseManager, id, g)
+}
+
+func (w *Worker) ImportCacheMount(ctx context.Context, id string, remote *solver.Remote) error {
+	return mounts.AddCacheMountImport(ctx, id, remote)
+}
+
 func (w *Worker) ResolveImageConfig(ctx context.Context, ref string, opt llb.ResolveImageConfigOpt, sm *session.Manager, g session.Group) (digest.Digest, []byte, error) {
 	return w.ImageSource.ResolveImageConfig(ctx, ref, opt, sm, g)
 }
@@ -399,6 +418,8 @@ func (w *Worker) FromRemote(ctx context.Context, remote *solver.Remote) (ref cac
 		ref, err := w.CacheMgr.GetByBlob(ctx, desc, current,
 			cache.WithDescription(descr),
 			cache.WithCreationTime(tm),
+			cache.WithBuildRef(solver.BuildRefOf(ctx)),
+			cache.WithFrontend(solver.BuildFrontendOf(ctx)),
 			descHandlers)
 		if current != nil {
 			current.Release(context.TODO())
@@ -32,7 +32,7 @@ type SnapshotterFactory struct {
 }
 
 // NewWorkerOpt creates a WorkerOpt.
-func NewWorkerOpt(root string, snFactory SnapshotterFactory, rootless bool, processMode oci.ProcessMode, labels map[string]string, idmap *idtools.IdentityMapping, nopt netproviders.Opt, dns *oci.DNSConfig, binary string) (base.WorkerOpt, error) {
+func NewWorkerOpt(root string, snFactory SnapshotterFactory, rootless bool, processMode oci.ProcessMode, labels map[string]string, idmap *idtools.IdentityMapping, nopt netproviders.Opt, dns *oci.DNSConfig, limits *oci.Limits, cgroupParent, binary string) (base.WorkerOpt, error) {
 	var opt base.WorkerOpt
 	name := "runc-" + snFactory.Name
 	root = filepath.Join(root, name)
@@ -58,10 +58,12 @@ func NewWorkerOpt(root string, snFactory SnapshotterFactory, rootless bool, proc
 		// Otherwise, a nil array will be sent and the default OCI worker binary will be used
 		CommandCandidates: cmds,
 		// without root privileges
-		Rootless:        rootless,
-		ProcessMode:     processMode,
-		IdentityMapping: idmap,
-		DNS:             dns,
+		Rootless:            rootless,
+		ProcessMode:         processMode,
+		IdentityMapping:     idmap,
+		DNS:                 dns,
+		Limits:              limits,
+		DefaultCgroupParent: cgroupParent,
 	}, np)
 	if err != nil {
 		return opt, err
@@ -111,6 +113,7 @@ func NewWorkerOpt(root string, snFactory SnapshotterFactory, rootless bool, proc
 
 	opt = base.WorkerOpt{
 		ID:              id,
+		Root:            root,
 		Labels:          xlabels,
 		MetadataStore:   md,
 		Executor:        exe,
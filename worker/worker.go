@@ -33,6 +33,14 @@ type Worker interface {
 	Prune(ctx context.Context, ch chan client.UsageInfo, opt ...client.PruneInfo) error
 	FromRemote(ctx context.Context, remote *solver.Remote) (cache.ImmutableRef, error)
 	PruneCacheMounts(ctx context.Context, ids []string) error
+	// ExportCacheMount returns the content of a cache mount as a single blob
+	// or nil if the cache mount does not exist. The blob is kept until the
+	// returned release function is called.
+	ExportCacheMount(ctx context.Context, id string, g session.Group) (*solver.Remote, func(context.Context) error, error)
+	// ImportCacheMount sets the content of a cache mount that is used when
+	// the cache mount is created for the first time by the build of the
+	// context.
+	ImportCacheMount(ctx context.Context, id string, remote *solver.Remote) error
 	ContentStore() content.Store
 	Executor() executor.Executor
 	CacheManager() cache.Manager